@@ -127,6 +127,289 @@ const docTemplate = `{
                 }
             }
         },
+        "/benchmarkSuite": {
+            "get": {
+                "description": "List built-in (sysbench-cpu, sysbench-mem, fio, iperf3, ping) and custom benchmark suites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "List benchmark suites",
+                "operationId": "GetAllBenchmarkSuite",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.BenchmarkSuiteList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register (or update) a custom benchmark suite, which is executed by remote commands to VMs.\nThe output of the last run command is parsed by the pattern (regular expression with a capture group) of each metric.\nA pair suite runs from each VM to every other VM, and {{targetIp}}, {{targetPublicIp}}, {{targetPrivateIp}}, {{targetVmId}} in run commands are replaced with those of the target VM.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Register a custom benchmark suite",
+                "operationId": "PostBenchmarkSuite",
+                "parameters": [
+                    {
+                        "description": "Benchmark suite",
+                        "name": "benchmarkSuite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.BenchmarkSuite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.BenchmarkSuite"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/benchmarkSuite/{suiteName}": {
+            "get": {
+                "description": "Get a benchmark suite (built-in or custom)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Get a benchmark suite",
+                "operationId": "GetBenchmarkSuite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "sysbench-cpu",
+                        "description": "Benchmark suite name",
+                        "name": "suiteName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.BenchmarkSuite"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a custom benchmark suite (built-in suites cannot be deleted)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Delete a custom benchmark suite",
+                "operationId": "DelBenchmarkSuite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "my-bench",
+                        "description": "Benchmark suite name",
+                        "name": "suiteName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/carbonIntensity": {
+            "get": {
+                "description": "List carbon intensities (gCO2eq/kWh) of regions from assets/carbonintensity.yaml and updates via API, with the power model of specs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "List carbon intensities of regions",
+                "operationId": "GetAllCarbonIntensity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "aws",
+                        "description": "List only regions of the provider",
+                        "name": "providerName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RegionCarbonIntensityList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update carbon intensities (gCO2eq/kWh) of regions overriding assets/carbonintensity.yaml.\nThe carbon priority of recommendation ranks specs by the estimated gCO2eq per hour (carbon intensity of the region x estimated power of the spec).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Update carbon intensities of regions",
+                "operationId": "PutCarbonIntensity",
+                "parameters": [
+                    {
+                        "description": "Carbon intensities of regions",
+                        "name": "carbonIntensityUpdateReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.CarbonIntensityUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RegionCarbonIntensityList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all carbon intensities updated via API (assets/carbonintensity.yaml is used again)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Delete all carbon intensities updated via API",
+                "operationId": "DelAllCarbonIntensity",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
         "/cloudInfo": {
             "get": {
                 "description": "Get cloud information",
@@ -717,9 +1000,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/loadCommonResource": {
+        "/latency": {
             "get": {
-                "description": "Load Common Resources from internal asset files (Spec, Image)",
+                "description": "List latencies in the latency store measured by benchmarks (mrtt) or imported",
                 "consumes": [
                     "application/json"
                 ],
@@ -727,15 +1010,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Multi-Cloud environment configuration"
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "List measured latencies between regions",
+                "operationId": "GetAllLatency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "aws-ap-northeast-2",
+                        "description": "List only latencies from/to the region",
+                        "name": "region",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Load Common Resources from internal asset files",
-                "operationId": "LoadCommonResource",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcis.LatencyInfoList"
                         }
                     },
                     "404": {
@@ -743,9 +1035,172 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    }
-                }
-            }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Import measured latencies between regions into the latency store. Each latency is added as a sample to the moving average of recent samples.\nMeasured latencies are used by recommendation and placement instead of the static latency map (assets/cloudlatencymap.csv).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Import latencies between regions",
+                "operationId": "PostLatency",
+                "parameters": [
+                    {
+                        "description": "Latencies between regions ({providerName}-{regionName})",
+                        "name": "latencyImportReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.LatencyImportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.LatencyInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all latencies in the latency store (the static latency map is used again)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Delete all measured latencies",
+                "operationId": "DelAllLatency",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/latency/{src}/{dest}": {
+            "get": {
+                "description": "Get the latency between two regions (the measured latency first, and the static latency map otherwise)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Get the latency between two regions",
+                "operationId": "GetLatency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "aws-ap-northeast-2",
+                        "description": "Source region",
+                        "name": "src",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "gcp-asia-northeast3",
+                        "description": "Destination region",
+                        "name": "dest",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.LatencyInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/loadCommonResource": {
+            "get": {
+                "description": "Load Common Resources from internal asset files (Spec, Image)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Multi-Cloud environment configuration"
+                ],
+                "summary": "Load Common Resources from internal asset files",
+                "operationId": "LoadCommonResource",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.IdList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
         },
         "/lookupImage": {
             "post": {
@@ -982,9 +1437,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/mcisPlacement": {
+            "post": {
+                "description": "Find the optimized assignment of specs (and regions) for all subGroups of MCIS under the constraints\n(minimum number of distinct providers, maximum inter-region latency, total budget per hour, allowed regions, and VM quota per provider).\nCandidate specs of each subGroup are filtered and ranked as in mcisRecommendVm. The result includes mcisDynamicReq to create the MCIS by /ns/{nsId}/mcisDynamic.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Optimize placement of a whole MCIS across regions and providers",
+                "operationId": "PostMcisPlacement",
+                "parameters": [
+                    {
+                        "description": "Subgroups and constraints of MCIS",
+                        "name": "mcisPlacementReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisPlacementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisPlacementResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
         "/mcisRecommendVm": {
             "post": {
-                "description": "Recommend MCIS plan (filter and priority) Find details from https://github.com/cloud-barista/cb-tumblebug/discussions/1234",
+                "description": "Recommend MCIS plan (filter and priority) Find details from https://github.com/cloud-barista/cb-tumblebug/discussions/1234\nSpecs are ranked by the weighted sum of normalized scores (0~1) of priority policies (weight is 1 if omitted),\nand the score of each criterion is included in the result. Ties are broken by lower cost and then spec ID.\nSpot prices (spotCostPerHour) are used for the cost priority with the parameter {\"key\": \"market\", \"val\": [\"spot\"]} (on-demand price if unknown).\nThe carbon priority ranks specs by the estimated gCO2eq per hour (carbon intensity of the region x estimated power of the spec, see /carbonIntensity).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1012,7 +1514,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/mcir.TbSpecInfo"
+                                "$ref": "#/definitions/mcis.SpecRecommendInfo"
                             }
                         }
                     },
@@ -1031,6 +1533,27 @@ const docTemplate = `{
                 }
             }
         },
+        "/metrics": {
+            "get": {
+                "description": "Get metrics of CB-Tumblebug in Prometheus exposition format\n(API requests/latency by route, in-flight/failed requests, CB-Spider call latency and cache hit,\nVM counts by status per namespace, MCIS auto-control policy executions)",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Get metrics of CB-Tumblebug in Prometheus format",
+                "operationId": "GetMetrics",
+                "responses": {
+                    "200": {
+                        "description": "Metrics in Prometheus exposition format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/ns": {
             "get": {
                 "description": "List all namespaces or namespaces' ID",
@@ -1505,9 +2028,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/cmd/mcis/{mcisId}": {
+        "/ns/{nsId}/benchmarkMesh/mcis/{mcisId}": {
             "post": {
-                "description": "Send a command to specified MCIS",
+                "description": "Measure latency, jitter and packet loss (ping) and optionally throughput (iperf3) between every pair of VMs\n(or representatives of subGroups) in MCIS. The result includes matrices ([source][target], -1 for unmeasured links)\nand the worst links with issues (unreachable, packetLoss, highLatency, highJitter, lowThroughput compared to medians).\nMeasured latencies between regions are also recorded to the latency store.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1515,10 +2038,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Remote command"
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
                 ],
-                "summary": "Send a command to specified MCIS",
-                "operationId": "PostCmdMcis",
+                "summary": "Run full-mesh network benchmark between VMs in MCIS",
+                "operationId": "PostNetworkMeshBenchmark",
                 "parameters": [
                     {
                         "type": "string",
@@ -1537,40 +2060,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "MCIS Command Request",
-                        "name": "mcisCmdReq",
+                        "description": "Options of full-mesh network benchmark",
+                        "name": "networkMeshReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisCmdReq"
+                            "$ref": "#/definitions/mcis.NetworkMeshReq"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "default": "g1",
-                        "description": "subGroupId to apply the command only for VMs in subGroup of MCIS",
-                        "name": "subGroupId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "g1-1",
-                        "description": "vmId to apply the command only for a VM in MCIS",
-                        "name": "vmId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Custom request ID",
-                        "name": "x-request-id",
-                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisSshCmdResult"
+                            "$ref": "#/definitions/mcis.NetworkMeshResult"
                         }
                     },
                     "404": {
@@ -1588,9 +2091,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/control/mcis/{mcisId}": {
-            "get": {
-                "description": "Control the lifecycle of MCIS (refine, suspend, resume, reboot, terminate)",
+        "/ns/{nsId}/benchmarkSuite/mcis/{mcisId}": {
+            "post": {
+                "description": "Run a benchmark suite in VMs of MCIS (install commands, then run commands in each VM or each pair of VMs) and get parsed metrics",
                 "consumes": [
                     "application/json"
                 ],
@@ -1598,10 +2101,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Control lifecycle"
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
                 ],
-                "summary": "Control the lifecycle of MCIS (refine, suspend, resume, reboot, terminate)",
-                "operationId": "GetControlMcis",
+                "summary": "Run a benchmark suite in MCIS",
+                "operationId": "PostRunBenchmarkSuite",
                 "parameters": [
                     {
                         "type": "string",
@@ -1620,37 +2123,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "suspend",
-                            "resume",
-                            "reboot",
-                            "terminate",
-                            "refine",
-                            "continue",
-                            "withdraw"
-                        ],
-                        "type": "string",
-                        "description": "Action to MCIS",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "false",
-                            "true"
-                        ],
-                        "type": "string",
-                        "description": "Force control to skip checking controllable status",
-                        "name": "force",
-                        "in": "query"
+                        "description": "Benchmark suite to run",
+                        "name": "benchmarkSuiteRunReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.BenchmarkSuiteRunReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.BenchmarkSuiteResult"
                         }
                     },
                     "404": {
@@ -1668,9 +2154,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/control/mcis/{mcisId}/vm/{vmId}": {
-            "get": {
-                "description": "Control the lifecycle of VM (suspend, resume, reboot, terminate)",
+        "/ns/{nsId}/cmd/mcis/{mcisId}": {
+            "post": {
+                "description": "Send a command to specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -1678,10 +2164,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Control lifecycle"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Control the lifecycle of VM (suspend, resume, reboot, terminate)",
-                "operationId": "GetControlMcisVm",
+                "summary": "Send a command to specified MCIS",
+                "operationId": "PostCmdMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -1700,42 +2186,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
-                        "in": "path",
-                        "required": true
+                        "description": "MCIS Command Request",
+                        "name": "mcisCmdReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisCmdReq"
+                        }
                     },
                     {
-                        "enum": [
-                            "suspend",
-                            "resume",
-                            "reboot",
-                            "terminate"
-                        ],
                         "type": "string",
-                        "description": "Action to MCIS",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "default": "g1",
+                        "description": "subGroupId to apply the command only for VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
                     },
                     {
-                        "enum": [
-                            "false",
-                            "true"
-                        ],
                         "type": "string",
-                        "description": "Force control to skip checking controllable status",
-                        "name": "force",
+                        "default": "g1-1",
+                        "description": "vmId to apply the command only for a VM in MCIS",
+                        "name": "vmId",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Custom request ID",
+                        "name": "x-request-id",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.McisSshCmdResult"
                         }
                     },
                     "404": {
@@ -1753,9 +2237,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/defaultResources": {
-            "delete": {
-                "description": "Delete all Default Resource Objects in the given namespace",
+        "/ns/{nsId}/cmd/mcis/{mcisId}/dryRun": {
+            "post": {
+                "description": "Show the fully expanded commands for each VM without execution. Secrets are masked.\nBuilt-in functions: $$Func(GetPublicIP(target=this)), $$Func(GetPublicIPs(target=this, separator=',')),\n$$Func(GetPrivateIP(target=this)), $$Func(GetPrivateIPs(target=this, subGroup=g1, separator=',')),\n$$Func(GetVmIndex()), $$Func(GetVmId()), $$Func(GetSubGroupId()), $$Func(GetRegion()), $$Func(GetZone()), $$Func(GetProvider()),\n$$Func(GetNlbEndpoint(subGroup=g1)), $$Func(GetSecret(key=repoToken)), $$Func(AssignTask(task='a, b')).\nCommon params: prefix, postfix, escape=shell|none (quote the value for shell; GetSecret is quoted by default). Use \\$$Func( for a literal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1763,10 +2247,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Common"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Delete all Default Resource Objects in the given namespace",
-                "operationId": "DelAllDefaultResources",
+                "summary": "Show expanded commands for specified MCIS without execution (dry run)",
+                "operationId": "PostCmdMcisDryRun",
                 "parameters": [
                     {
                         "type": "string",
@@ -1775,13 +2259,44 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "MCIS Command Request",
+                        "name": "mcisCmdReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisCmdReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1",
+                        "description": "subGroupId to apply the command only for VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "vmId to apply the command only for a VM in MCIS",
+                        "name": "vmId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcis.McisCmdDryRunResults"
                         }
                     },
                     "404": {
@@ -1789,13 +2304,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/installBenchmarkAgent/mcis/{mcisId}": {
+        "/ns/{nsId}/cmd/mcis/{mcisId}/script": {
             "post": {
-                "description": "Install the benchmark agent to specified MCIS",
+                "description": "Execute a script of the script library to VMs in MCIS by name. The execution is recorded with the results of each VM.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1803,10 +2324,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Install the benchmark agent to specified MCIS",
-                "operationId": "PostInstallBenchmarkAgentToMcis",
+                "summary": "Execute a script of the script library to specified MCIS",
+                "operationId": "PostScriptExecMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -1825,21 +2346,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "MCIS Command Request",
-                        "name": "mcisCmdReq",
+                        "description": "Script execution request",
+                        "name": "scriptExecReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisCmdReq"
+                            "$ref": "#/definitions/mcis.ScriptExecReq"
                         }
                     },
                     {
-                        "enum": [
-                            "update"
-                        ],
                         "type": "string",
-                        "description": "Option for checking update",
-                        "name": "option",
+                        "default": "g1",
+                        "description": "subGroupId to execute the script only for VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "vmId to execute the script only for a VM in MCIS",
+                        "name": "vmId",
                         "in": "query"
                     }
                 ],
@@ -1847,7 +2373,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisSshCmdResult"
+                            "$ref": "#/definitions/mcis.ScriptExecutionInfo"
                         }
                     },
                     "404": {
@@ -1865,9 +2391,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/k8scluster": {
+        "/ns/{nsId}/cmdJob": {
             "get": {
-                "description": "List all K8sClusters or K8sClusters' ID",
+                "description": "List command jobs in a namespace",
                 "consumes": [
                     "application/json"
                 ],
@@ -1875,10 +2401,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "List all K8sClusters or K8sClusters' ID",
-                "operationId": "GetAllK8sCluster",
+                "summary": "List command jobs",
+                "operationId": "GetAllCmdJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -1888,48 +2414,19 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field key for filtering (ex: cspK8sClusterName)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
-                        "name": "filterVal",
+                        "default": "mcis01",
+                        "description": "List only jobs of the MCIS",
+                        "name": "mcisId",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcis.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcis.RestGetAllK8sClusterResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/mcis.McisCmdJobInfoList"
                         }
                     },
                     "404": {
@@ -1945,9 +2442,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ns/{nsId}/cmdJob/mcis/{mcisId}": {
             "post": {
-                "description": "Create K8sCluster",
+                "description": "Submit an asynchronous command job to specified MCIS. Returns a job ID immediately.\nOutput of each VM can be streamed line by line via /ns/{nsId}/cmdJob/{jobId}/stream,\nand the results are kept for later retrieval via /ns/{nsId}/cmdJob/{jobId}.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1955,10 +2454,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Create K8sCluster",
-                "operationId": "PostK8sCluster",
+                "summary": "Submit an asynchronous command job to specified MCIS",
+                "operationId": "PostCmdJobMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -1969,29 +2468,48 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "register"
-                        ],
                         "type": "string",
-                        "description": "Option: [required params for register] connectionName, name, cspK8sClusterId",
-                        "name": "option",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Details of the K8sCluster object",
-                        "name": "k8sClusterReq",
+                        "description": "MCIS Command Request",
+                        "name": "mcisCmdReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbK8sClusterReq"
+                            "$ref": "#/definitions/mcis.McisCmdReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1",
+                        "description": "subGroupId to apply the command only for VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "vmId to apply the command only for a VM in MCIS",
+                        "name": "vmId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Custom request ID",
+                        "name": "x-request-id",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
+                            "$ref": "#/definitions/mcis.McisCmdJobInfo"
                         }
                     },
                     "404": {
@@ -2007,9 +2525,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all K8sClusters",
+            }
+        },
+        "/ns/{nsId}/cmdJob/{jobId}": {
+            "get": {
+                "description": "Get a command job (results are included when the job is finished)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2017,10 +2537,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Delete all K8sClusters",
-                "operationId": "DeleteAllK8sCluster",
+                "summary": "Get a command job",
+                "operationId": "GetCmdJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -2032,17 +2552,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
+                        "description": "Command job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcis.McisCmdJobInfo"
                         }
                     },
                     "404": {
@@ -2050,13 +2570,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}": {
-            "get": {
-                "description": "Get K8sCluster",
+            },
+            "delete": {
+                "description": "Delete a finished command job and its results",
                 "consumes": [
                     "application/json"
                 ],
@@ -2064,10 +2588,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Get K8sCluster",
-                "operationId": "GetK8sCluster",
+                "summary": "Delete a command job",
+                "operationId": "DelCmdJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -2079,9 +2603,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "c1",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "description": "Command job ID",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
                     }
@@ -2090,7 +2613,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -2098,17 +2621,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete K8sCluster",
+            }
+        },
+        "/ns/{nsId}/cmdJob/{jobId}/cancel": {
+            "put": {
+                "description": "Cancel a running command job. SSH sessions of the job are closed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2116,10 +2635,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Delete K8sCluster",
-                "operationId": "DeleteK8sCluster",
+                "summary": "Cancel a command job",
+                "operationId": "PutCancelCmdJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -2131,8 +2650,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "description": "Command job ID",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
                     }
@@ -2141,7 +2660,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.McisCmdJobInfo"
                         }
                     },
                     "404": {
@@ -2149,24 +2668,27 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup": {
-            "post": {
-                "description": "Add a K8sNodeGroup",
-                "consumes": [
-                    "application/json"
-                ],
+        "/ns/{nsId}/cmdJob/{jobId}/stream": {
+            "get": {
+                "description": "Stream output of a command job line by line as Server-Sent Events (text/event-stream).\nEach event is a JSON of mcis.McisCmdJobOutput. Outputs so far are sent first.\nThe stream ends after the \"jobDone\" event. For a finished job, persisted results are replayed.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Add a K8sNodeGroup",
-                "operationId": "PostK8sNodeGroup",
+                "summary": "Stream output of a command job",
+                "operationId": "GetCmdJobStream",
                 "parameters": [
                     {
                         "type": "string",
@@ -2178,26 +2700,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "description": "Command job ID",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Details of the K8sNodeGroup object",
-                        "name": "k8sNodeGroupReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbK8sNodeGroupReq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
+                            "$ref": "#/definitions/mcis.McisCmdJobOutput"
                         }
                     },
                     "404": {
@@ -2205,19 +2718,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}": {
-            "delete": {
-                "description": "Remove a K8sNodeGroup",
+        "/ns/{nsId}/control/mcis/{mcisId}": {
+            "get": {
+                "description": "Control the lifecycle of MCIS (refine, suspend, resume, reboot, terminate)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2225,10 +2732,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Control lifecycle"
                 ],
-                "summary": "Remove a K8sNodeGroup",
-                "operationId": "DeleteK8sNodeGroup",
+                "summary": "Control the lifecycle of MCIS (refine, suspend, resume, reboot, terminate)",
+                "operationId": "GetControlMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -2240,17 +2747,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "suspend",
+                            "resume",
+                            "reboot",
+                            "terminate",
+                            "refine",
+                            "continue",
+                            "withdraw"
+                        ],
                         "type": "string",
-                        "description": "K8sNodeGroup Name",
-                        "name": "k8sNodeGroupName",
-                        "in": "path",
+                        "description": "Action to MCIS",
+                        "name": "action",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "false",
+                            "true"
+                        ],
+                        "type": "string",
+                        "description": "Force control to skip checking controllable status",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2265,13 +2792,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}/autoscalesize": {
-            "put": {
-                "description": "Change a K8sNodeGroup's Autoscale Size",
+        "/ns/{nsId}/control/mcis/{mcisId}/vm/{vmId}": {
+            "get": {
+                "description": "Control the lifecycle of VM (suspend, resume, reboot, terminate)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2279,10 +2812,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Control lifecycle"
                 ],
-                "summary": "Change a K8sNodeGroup's Autoscale Size",
-                "operationId": "PutChangeK8sNodeGroupAutoscaleSize",
+                "summary": "Control the lifecycle of VM (suspend, resume, reboot, terminate)",
+                "operationId": "GetControlMcisVm",
                 "parameters": [
                     {
                         "type": "string",
@@ -2294,26 +2827,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "K8sNodeGroup Name",
-                        "name": "k8sNodeGroupName",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Details of the TbChangeK8sNodeGroupAutoscaleSizeReq object",
-                        "name": "changeK8sNodeGroupAutoscaleSizeReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbChangeK8sNodeGroupAutoscaleSizeReq"
-                        }
+                        "enum": [
+                            "suspend",
+                            "resume",
+                            "reboot",
+                            "terminate"
+                        ],
+                        "type": "string",
+                        "description": "Action to MCIS",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "false",
+                            "true"
+                        ],
+                        "type": "string",
+                        "description": "Force control to skip checking controllable status",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2338,9 +2887,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}/onautoscaling": {
-            "put": {
-                "description": "Set a K8sNodeGroup's Autoscaling On/Off",
+        "/ns/{nsId}/defaultResources": {
+            "delete": {
+                "description": "Delete all Default Resource Objects in the given namespace",
                 "consumes": [
                     "application/json"
                 ],
@@ -2348,10 +2897,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra resource] MCIR Common"
                 ],
-                "summary": "Set a K8sNodeGroup's Autoscaling On/Off",
-                "operationId": "PutSetK8sNodeGroupAutoscaling",
+                "summary": "Delete all Default Resource Objects in the given namespace",
+                "operationId": "DelAllDefaultResources",
                 "parameters": [
                     {
                         "type": "string",
@@ -2360,36 +2909,13 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "K8sNodeGroup Name",
-                        "name": "k8sNodeGroupName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Details of the TbSetK8sNodeGroupAutoscalingReq object",
-                        "name": "setK8sNodeGroupAutoscalingReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbSetK8sNodeGroupAutoscalingReq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -2397,19 +2923,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/k8scluster/{k8sClusterId}/upgrade": {
-            "put": {
-                "description": "Upgrade a K8sCluster's version",
+        "/ns/{nsId}/installBenchmarkAgent/mcis/{mcisId}": {
+            "post": {
+                "description": "Install the benchmark agent to specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -2417,10 +2937,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] K8sCluster management"
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
                 ],
-                "summary": "Upgrade a K8sCluster's version",
-                "operationId": "PutUpgradeK8sCluster",
+                "summary": "Install the benchmark agent to specified MCIS",
+                "operationId": "PostInstallBenchmarkAgentToMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -2432,26 +2952,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "K8sCluster ID",
-                        "name": "k8sClusterId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Details of the TbUpgradeK8sClusterReq object",
-                        "name": "upgradeK8sClusterReq",
+                        "description": "MCIS Command Request",
+                        "name": "mcisCmdReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbUpgradeK8sClusterReq"
+                            "$ref": "#/definitions/mcis.McisCmdReq"
                         }
+                    },
+                    {
+                        "enum": [
+                            "update"
+                        ],
+                        "type": "string",
+                        "description": "Option for checking update",
+                        "name": "option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.McisSshCmdResult"
                         }
                     },
                     "404": {
@@ -2469,9 +2999,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/loadDefaultResource": {
+        "/ns/{nsId}/k8scluster": {
             "get": {
-                "description": "Load Default Resource from internal asset file",
+                "description": "List all K8sClusters or K8sClusters' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2479,10 +3009,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Common"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Load Default Resource from internal asset file",
-                "operationId": "LoadDefaultResource",
+                "summary": "List all K8sClusters or K8sClusters' ID",
+                "operationId": "GetAllK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -2494,73 +3024,23 @@ const docTemplate = `{
                     },
                     {
                         "enum": [
-                            "all",
-                            "vnet",
-                            "sg",
-                            "sshkey"
+                            "id"
                         ],
                         "type": "string",
                         "description": "Option",
                         "name": "option",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "connectionName of cloud for designated resource",
-                        "name": "connectionName",
+                        "description": "Field key for filtering (ex: cspK8sClusterName)",
+                        "name": "filterKey",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    }
-                }
-            }
-        },
-        "/ns/{nsId}/mcis": {
-            "get": {
-                "description": "List all MCISs or MCISs' ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Infra service] MCIS Provisioning management"
-                ],
-                "summary": "List all MCISs or MCISs' ID",
-                "operationId": "GetAllMcis",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "ns01",
-                        "description": "Namespace ID",
-                        "name": "nsId",
-                        "in": "path",
-                        "required": true
                     },
                     {
-                        "enum": [
-                            "id",
-                            "simple",
-                            "status"
-                        ],
                         "type": "string",
-                        "description": "Option",
-                        "name": "option",
+                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
+                        "name": "filterVal",
                         "in": "query"
                     }
                 ],
@@ -2576,16 +3056,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcis.RestGetAllMcisResponse"
+                                            "$ref": "#/definitions/mcis.RestGetAllK8sClusterResponse"
                                         },
                                         "[ID]": {
                                             "$ref": "#/definitions/common.IdList"
-                                        },
-                                        "[SIMPLE]": {
-                                            "$ref": "#/definitions/mcis.RestGetAllMcisResponse"
-                                        },
-                                        "[STATUS]": {
-                                            "$ref": "#/definitions/mcis.RestGetAllMcisStatusResponse"
                                         }
                                     }
                                 }
@@ -2607,7 +3081,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create MCIS",
+                "description": "Create K8sCluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -2615,10 +3089,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Create MCIS",
-                "operationId": "PostMcis",
+                "summary": "Create K8sCluster",
+                "operationId": "PostK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -2629,12 +3103,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for an MCIS object",
-                        "name": "mcisReq",
+                        "enum": [
+                            "register"
+                        ],
+                        "type": "string",
+                        "description": "Option: [required params for register] connectionName, name, cspK8sClusterId",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Details of the K8sCluster object",
+                        "name": "k8sClusterReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisReq"
+                            "$ref": "#/definitions/mcis.TbK8sClusterReq"
                         }
                     }
                 ],
@@ -2642,7 +3125,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
                         }
                     },
                     "404": {
@@ -2660,7 +3143,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete all MCISs",
+                "description": "Delete all K8sClusters",
                 "consumes": [
                     "application/json"
                 ],
@@ -2668,10 +3151,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Delete all MCISs",
-                "operationId": "DelAllMcis",
+                "summary": "Delete all K8sClusters",
+                "operationId": "DeleteAllK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -2682,12 +3165,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "force"
-                        ],
                         "type": "string",
-                        "description": "Option for delete MCIS (support force delete)",
-                        "name": "option",
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
                         "in": "query"
                     }
                 ],
@@ -2695,7 +3176,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -2707,9 +3188,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}": {
+        "/ns/{nsId}/k8scluster/{k8sClusterId}": {
             "get": {
-                "description": "Get MCIS object (option: status, accessInfo, vmId)",
+                "description": "Get K8sCluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -2717,10 +3198,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Get MCIS object (option: status, accessInfo, vmId)",
-                "operationId": "GetMcis",
+                "summary": "Get K8sCluster",
+                "operationId": "GetK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -2732,69 +3213,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "default": "c1",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "default",
-                            "id",
-                            "status",
-                            "accessinfo"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "(For option=id) Field key for filtering (ex: connectionName)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "(For option=id) Field value for filtering (ex: aws-ap-northeast-2)",
-                        "name": "filterVal",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "(For option=accessinfo) accessInfoOption (showSshKey)",
-                        "name": "accessInfoOption",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given action param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcis.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[AccessInfo]": {
-                                            "$ref": "#/definitions/mcis.McisAccessInfo"
-                                        },
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcis.TbMcisInfo"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        },
-                                        "[STATUS]": {
-                                            "$ref": "#/definitions/mcis.McisStatusInfo"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
                         }
                     },
                     "404": {
@@ -2812,7 +3242,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete MCIS",
+                "description": "Delete K8sCluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -2820,10 +3250,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Delete MCIS",
-                "operationId": "DelMcis",
+                "summary": "Delete K8sCluster",
+                "operationId": "DeleteK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -2835,28 +3265,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "terminate",
-                            "force"
-                        ],
-                        "type": "string",
-                        "description": "Option for delete MCIS (support force delete)",
-                        "name": "option",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -2868,9 +3287,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/bastion/{bastionVmId}": {
-            "delete": {
-                "description": "Remove a bastion VM from all vNets",
+        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup": {
+            "post": {
+                "description": "Add a K8sNodeGroup",
                 "consumes": [
                     "application/json"
                 ],
@@ -2878,10 +3297,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Remote command"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Remove a bastion VM from all vNets",
-                "operationId": "RemoveBastionNodes",
+                "summary": "Add a K8sNodeGroup",
+                "operationId": "PostK8sNodeGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -2893,26 +3312,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "g1-1",
-                        "description": "Bastion VM ID",
-                        "name": "bastionVmId",
-                        "in": "path",
-                        "required": true
+                        "description": "Details of the K8sNodeGroup object",
+                        "name": "k8sNodeGroupReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbK8sNodeGroupReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.TbK8sClusterInfo"
                         }
                     },
                     "404": {
@@ -2930,9 +3349,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/mcSwNlb": {
-            "post": {
-                "description": "Create a special purpose MCIS for NLB and depoly and setting SW NLB",
+        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}": {
+            "delete": {
+                "description": "Remove a K8sNodeGroup",
                 "consumes": [
                     "application/json"
                 ],
@@ -2940,10 +3359,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Create a special purpose MCIS for NLB and depoly and setting SW NLB",
-                "operationId": "PostMcNLB",
+                "summary": "Remove a K8sNodeGroup",
+                "operationId": "DeleteK8sNodeGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -2955,27 +3374,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Details of the NLB object",
-                        "name": "nlbReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBReq"
-                        }
-                    }
+                        "type": "string",
+                        "description": "K8sNodeGroup Name",
+                        "name": "k8sNodeGroupName",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.McNlbInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -2983,19 +3399,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/nlb": {
-            "get": {
-                "description": "List all NLBs or NLBs' ID",
+        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}/autoscalesize": {
+            "put": {
+                "description": "Change a K8sNodeGroup's Autoscale Size",
                 "consumes": [
                     "application/json"
                 ],
@@ -3003,10 +3413,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "List all NLBs or NLBs' ID",
-                "operationId": "GetAllNLB",
+                "summary": "Change a K8sNodeGroup's Autoscale Size",
+                "operationId": "PutChangeK8sNodeGroupAutoscaleSize",
                 "parameters": [
                     {
                         "type": "string",
@@ -3018,54 +3428,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Field key for filtering (ex: cspNLBName)",
-                        "name": "filterKey",
-                        "in": "query"
+                        "description": "K8sNodeGroup Name",
+                        "name": "k8sNodeGroupName",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
-                        "name": "filterVal",
-                        "in": "query"
+                        "description": "Details of the TbChangeK8sNodeGroupAutoscaleSizeReq object",
+                        "name": "changeK8sNodeGroupAutoscaleSizeReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbChangeK8sNodeGroupAutoscaleSizeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcis.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcis.RestGetAllNLBResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -3081,9 +3470,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create NLB",
+            }
+        },
+        "/ns/{nsId}/k8scluster/{k8sClusterId}/k8snodegroup/{k8sNodeGroupName}/onautoscaling": {
+            "put": {
+                "description": "Set a K8sNodeGroup's Autoscaling On/Off",
                 "consumes": [
                     "application/json"
                 ],
@@ -3091,10 +3482,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Create NLB",
-                "operationId": "PostNLB",
+                "summary": "Set a K8sNodeGroup's Autoscaling On/Off",
+                "operationId": "PutSetK8sNodeGroupAutoscaling",
                 "parameters": [
                     {
                         "type": "string",
@@ -3106,28 +3497,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "register"
-                        ],
                         "type": "string",
-                        "description": "Option: [required params for register] connectionName, name, cspNLBId",
-                        "name": "option",
-                        "in": "query"
+                        "description": "K8sNodeGroup Name",
+                        "name": "k8sNodeGroupName",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Details of the NLB object",
-                        "name": "nlbReq",
+                        "description": "Details of the TbSetK8sNodeGroupAutoscalingReq object",
+                        "name": "setK8sNodeGroupAutoscalingReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBReq"
+                            "$ref": "#/definitions/mcis.TbSetK8sNodeGroupAutoscalingReq"
                         }
                     }
                 ],
@@ -3135,7 +3523,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -3151,9 +3539,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all NLBs",
+            }
+        },
+        "/ns/{nsId}/k8scluster/{k8sClusterId}/upgrade": {
+            "put": {
+                "description": "Upgrade a K8sCluster's version",
                 "consumes": [
                     "application/json"
                 ],
@@ -3161,10 +3551,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra resource] K8sCluster management"
                 ],
-                "summary": "Delete all NLBs",
-                "operationId": "DelAllNLB",
+                "summary": "Upgrade a K8sCluster's version",
+                "operationId": "PutUpgradeK8sCluster",
                 "parameters": [
                     {
                         "type": "string",
@@ -3176,25 +3566,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
+                        "description": "K8sCluster ID",
+                        "name": "k8sClusterId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
+                        "description": "Details of the TbUpgradeK8sClusterReq object",
+                        "name": "upgradeK8sClusterReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbUpgradeK8sClusterReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -3202,13 +3593,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}": {
+        "/ns/{nsId}/loadDefaultResource": {
             "get": {
-                "description": "Get NLB",
+                "description": "Load Default Resource from internal asset file",
                 "consumes": [
                     "application/json"
                 ],
@@ -3216,10 +3613,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra resource] MCIR Common"
                 ],
-                "summary": "Get NLB",
-                "operationId": "GetNLB",
+                "summary": "Load Default Resource from internal asset file",
+                "operationId": "LoadDefaultResource",
                 "parameters": [
                     {
                         "type": "string",
@@ -3230,27 +3627,31 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "all",
+                            "vnet",
+                            "sg",
+                            "sshkey"
+                        ],
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "g1",
-                        "description": "NLB ID",
-                        "name": "nlbId",
-                        "in": "path",
-                        "required": true
+                        "default": "",
+                        "description": "connectionName of cloud for designated resource",
+                        "name": "connectionName",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -3258,17 +3659,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete NLB",
+            }
+        },
+        "/ns/{nsId}/mcis": {
+            "get": {
+                "description": "List all MCISs or MCISs' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3276,10 +3673,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Delete NLB",
-                "operationId": "DelNLB",
+                "summary": "List all MCISs or MCISs' ID",
+                "operationId": "GetAllMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -3290,26 +3687,43 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "id",
+                            "simple",
+                            "status"
+                        ],
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "NLB ID",
-                        "name": "nlbId",
-                        "in": "path",
-                        "required": true
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcis.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcis.RestGetAllMcisResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        },
+                                        "[SIMPLE]": {
+                                            "$ref": "#/definitions/mcis.RestGetAllMcisResponse"
+                                        },
+                                        "[STATUS]": {
+                                            "$ref": "#/definitions/mcis.RestGetAllMcisStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -3317,13 +3731,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    }
-                }
-            }
-        },
-        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}/healthz": {
-            "get": {
-                "description": "Get NLB Health",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -3331,10 +3749,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Get NLB Health",
-                "operationId": "GetNLBHealth",
+                "summary": "Create MCIS",
+                "operationId": "PostMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -3345,27 +3763,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "g1",
-                        "description": "NLB ID",
-                        "name": "nlbId",
-                        "in": "path",
-                        "required": true
+                        "description": "Details for an MCIS object",
+                        "name": "mcisReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbMcisReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBInfo"
+                            "$ref": "#/definitions/mcis.TbMcisInfo"
                         }
                     },
                     "404": {
@@ -3381,11 +3792,58 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Delete all MCISs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Delete all MCISs",
+                "operationId": "DelAllMcis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "force"
+                        ],
+                        "type": "string",
+                        "description": "Option for delete MCIS (support force delete)",
+                        "name": "option",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}/vm": {
-            "post": {
-                "description": "Add VMs to NLB",
+        "/ns/{nsId}/mcis/{mcisId}": {
+            "get": {
+                "description": "Get MCIS object (option: status, accessInfo, vmId)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3393,10 +3851,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management (for developer)"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Add VMs to NLB",
-                "operationId": "AddNLBVMs",
+                "summary": "Get MCIS object (option: status, accessInfo, vmId)",
+                "operationId": "GetMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -3415,28 +3873,62 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "default",
+                            "id",
+                            "status",
+                            "accessinfo"
+                        ],
                         "type": "string",
-                        "default": "g1",
-                        "description": "NLB ID",
-                        "name": "nlbId",
-                        "in": "path",
-                        "required": true
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
                     },
                     {
-                        "description": "VMs to add to NLB",
-                        "name": "nlbAddRemoveVMReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBAddRemoveVMReq"
-                        }
+                        "type": "string",
+                        "description": "(For option=id) Field key for filtering (ex: connectionName)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "(For option=id) Field value for filtering (ex: aws-ap-northeast-2)",
+                        "name": "filterVal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "(For option=accessinfo) accessInfoOption (showSshKey)",
+                        "name": "accessInfoOption",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given action param",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBInfo"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcis.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[AccessInfo]": {
+                                            "$ref": "#/definitions/mcis.McisAccessInfo"
+                                        },
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        },
+                                        "[STATUS]": {
+                                            "$ref": "#/definitions/mcis.McisStatusInfo"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -3454,7 +3946,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete VMs from NLB",
+                "description": "Delete MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -3462,10 +3954,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] NLB management (for developer)"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Delete VMs from NLB",
-                "operationId": "RemoveNLBVMs",
+                "summary": "Delete MCIS",
+                "operationId": "DelMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -3484,28 +3976,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "terminate",
+                            "force"
+                        ],
                         "type": "string",
-                        "default": "g1",
-                        "description": "NLB ID",
-                        "name": "nlbId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "VMs to add to NLB",
-                        "name": "nlbAddRemoveVMReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbNLBAddRemoveVMReq"
-                        }
+                        "description": "Option for delete MCIS (support force delete)",
+                        "name": "option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -3517,9 +4002,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/site": {
-            "get": {
-                "description": "Get sites in MCIS",
+        "/ns/{nsId}/mcis/{mcisId}/bastion/{bastionVmId}": {
+            "delete": {
+                "description": "Remove a bastion VM from all vNets",
                 "consumes": [
                     "application/json"
                 ],
@@ -3527,10 +4012,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[VPN] Sites in MCIS"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Get sites in MCIS",
-                "operationId": "GetSitesInMcis",
+                "summary": "Remove a bastion VM from all vNets",
+                "operationId": "RemoveBastionNodes",
                 "parameters": [
                     {
                         "type": "string",
@@ -3547,17 +4032,25 @@ const docTemplate = `{
                         "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "Bastion VM ID",
+                        "name": "bastionVmId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SitesInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -3567,19 +4060,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/subgroup": {
-            "get": {
-                "description": "List SubGroup IDs in a specified MCIS",
+        "/ns/{nsId}/mcis/{mcisId}/mcSwNlb": {
+            "post": {
+                "description": "Create a special purpose MCIS for NLB and depoly and setting SW NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -3587,10 +4074,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "List SubGroup IDs in a specified MCIS",
-                "operationId": "GetMcisGroupIds",
+                "summary": "Create a special purpose MCIS for NLB and depoly and setting SW NLB",
+                "operationId": "PostMcNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3607,13 +4094,22 @@ const docTemplate = `{
                         "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Details of the NLB object",
+                        "name": "nlbReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbNLBReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcis.McNlbInfo"
                         }
                     },
                     "404": {
@@ -3631,9 +4127,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/subgroup/{subgroupId}": {
+        "/ns/{nsId}/mcis/{mcisId}/nlb": {
             "get": {
-                "description": "List VMs with a SubGroup label in a specified MCIS",
+                "description": "List all NLBs or NLBs' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3641,10 +4137,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "List VMs with a SubGroup label in a specified MCIS",
-                "operationId": "GetMcisGroupVms",
+                "summary": "List all NLBs or NLBs' ID",
+                "operationId": "GetAllNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3662,14 +4158,6 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "default": "g1",
-                        "description": "subGroup ID",
-                        "name": "subgroupId",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "enum": [
                             "id"
@@ -3678,13 +4166,40 @@ const docTemplate = `{
                         "description": "Option",
                         "name": "option",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field key for filtering (ex: cspNLBName)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
+                        "name": "filterVal",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcis.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcis.RestGetAllNLBResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -3702,7 +4217,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "ScaleOut subGroup in specified MCIS",
+                "description": "Create NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -3710,10 +4225,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "ScaleOut subGroup in specified MCIS",
-                "operationId": "PostMcisSubGroupScaleOut",
+                "summary": "Create NLB",
+                "operationId": "PostNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3732,20 +4247,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "register"
+                        ],
                         "type": "string",
-                        "default": "g1",
-                        "description": "subGroup ID",
-                        "name": "subgroupId",
-                        "in": "path",
-                        "required": true
+                        "description": "Option: [required params for register] connectionName, name, cspNLBId",
+                        "name": "option",
+                        "in": "query"
                     },
                     {
-                        "description": "subGroup scaleOut request",
-                        "name": "vmReq",
+                        "description": "Details of the NLB object",
+                        "name": "nlbReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbScaleOutSubGroupReq"
+                            "$ref": "#/definitions/mcis.TbNLBReq"
                         }
                     }
                 ],
@@ -3753,7 +4269,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                            "$ref": "#/definitions/mcis.TbNLBInfo"
                         }
                     },
                     "404": {
@@ -3769,11 +4285,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/mcis/{mcisId}/vm": {
-            "post": {
-                "description": "Create and add homogeneous VMs(subGroup) to a specified MCIS (Set subGroupSize for multiple VMs)",
+            },
+            "delete": {
+                "description": "Delete all NLBs",
                 "consumes": [
                     "application/json"
                 ],
@@ -3781,10 +4295,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "Create and add homogeneous VMs(subGroup) to a specified MCIS (Set subGroupSize for multiple VMs)",
-                "operationId": "PostMcisVm",
+                "summary": "Delete all NLBs",
+                "operationId": "DelAllNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3803,20 +4317,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for VMs(subGroup)",
-                        "name": "vmReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbVmReq"
-                        }
+                        "type": "string",
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -3824,19 +4336,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/vm/{targetVmId}/bastion": {
+        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}": {
             "get": {
-                "description": "Get bastion nodes for a VM",
+                "description": "Get NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -3844,10 +4350,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Remote command"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "Get bastion nodes for a VM",
-                "operationId": "GetBastionNodes",
+                "summary": "Get NLB",
+                "operationId": "GetNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3867,9 +4373,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "Target VM ID",
-                        "name": "targetVmId",
+                        "default": "g1",
+                        "description": "NLB ID",
+                        "name": "nlbId",
                         "in": "path",
                         "required": true
                     }
@@ -3878,10 +4384,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/mcir.BastionNode"
-                            }
+                            "$ref": "#/definitions/mcis.TbNLBInfo"
                         }
                     },
                     "404": {
@@ -3897,11 +4400,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/mcis/{mcisId}/vm/{targetVmId}/bastion/{bastionVmId}": {
-            "put": {
-                "description": "Set bastion nodes for a VM",
+            },
+            "delete": {
+                "description": "Delete NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -3909,10 +4410,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Remote command"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "Set bastion nodes for a VM",
-                "operationId": "SetBastionNodes",
+                "summary": "Delete NLB",
+                "operationId": "DelNLB",
                 "parameters": [
                     {
                         "type": "string",
@@ -3932,17 +4433,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "Target VM ID",
-                        "name": "targetVmId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "g1-1",
-                        "description": "Bastion VM ID",
-                        "name": "bastionVmId",
+                        "description": "NLB ID",
+                        "name": "nlbId",
                         "in": "path",
                         "required": true
                     }
@@ -3959,19 +4451,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}": {
+        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}/healthz": {
             "get": {
-                "description": "Get VM in specified MCIS",
+                "description": "Get NLB Health",
                 "consumes": [
                     "application/json"
                 ],
@@ -3979,10 +4465,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management"
                 ],
-                "summary": "Get VM in specified MCIS",
-                "operationId": "GetMcisVm",
+                "summary": "Get NLB Health",
+                "operationId": "GetNLBHealth",
                 "parameters": [
                     {
                         "type": "string",
@@ -4002,47 +4488,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "g1",
+                        "description": "NLB ID",
+                        "name": "nlbId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "default",
-                            "status",
-                            "idsInDetail"
-                        ],
-                        "type": "string",
-                        "description": "Option for MCIS",
-                        "name": "option",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcis.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcis.TbVmInfo"
-                                        },
-                                        "[IDNAME]": {
-                                            "$ref": "#/definitions/mcis.TbIdNameInDetailInfo"
-                                        },
-                                        "[STATUS]": {
-                                            "$ref": "#/definitions/mcis.TbVmStatusInfo"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/mcis.TbNLBInfo"
                         }
                     },
                     "404": {
@@ -4058,9 +4515,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete VM in specified MCIS",
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/nlb/{nlbId}/vm": {
+            "post": {
+                "description": "Add VMs to NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -4068,10 +4527,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] NLB management (for developer)"
                 ],
-                "summary": "Delete VM in specified MCIS",
-                "operationId": "DelMcisVm",
+                "summary": "Add VMs to NLB",
+                "operationId": "AddNLBVMs",
                 "parameters": [
                     {
                         "type": "string",
@@ -4091,27 +4550,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "g1",
+                        "description": "NLB ID",
+                        "name": "nlbId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "force"
-                        ],
-                        "type": "string",
-                        "description": "Option for delete VM (support force delete)",
-                        "name": "option",
-                        "in": "query"
+                        "description": "VMs to add to NLB",
+                        "name": "nlbAddRemoveVMReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbNLBAddRemoveVMReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.TbNLBInfo"
                         }
                     },
                     "404": {
@@ -4119,13 +4578,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/dataDisk": {
-            "get": {
-                "description": "Get available dataDisks for a VM",
+            },
+            "delete": {
+                "description": "Delete VMs from NLB",
                 "consumes": [
                     "application/json"
                 ],
@@ -4133,10 +4596,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra resource] NLB management (for developer)"
                 ],
-                "summary": "Get available dataDisks for a VM",
-                "operationId": "GetVmDataDisk",
+                "summary": "Delete VMs from NLB",
+                "operationId": "RemoveNLBVMs",
                 "parameters": [
                     {
                         "type": "string",
@@ -4156,33 +4619,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "g1",
+                        "description": "NLB ID",
+                        "name": "nlbId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "VMs to add to NLB",
+                        "name": "nlbAddRemoveVMReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbNLBAddRemoveVMReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllDataDiskResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -4190,17 +4647,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Attach/Detach available dataDisk",
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/site": {
+            "get": {
+                "description": "Get sites in MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -4208,19 +4661,11 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[VPN] Sites in MCIS"
                 ],
-                "summary": "Attach/Detach available dataDisk",
-                "operationId": "PutVmDataDisk",
+                "summary": "Get sites in MCIS",
+                "operationId": "GetSitesInMcis",
                 "parameters": [
-                    {
-                        "description": "Request body to attach/detach dataDisk",
-                        "name": "attachDetachDataDiskReq",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbAttachDetachDataDiskReq"
-                        }
-                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4236,42 +4681,73 @@ const docTemplate = `{
                         "name": "mcisId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SitesInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/subgroup": {
+            "get": {
+                "description": "List SubGroup IDs in a specified MCIS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "List SubGroup IDs in a specified MCIS",
+                "operationId": "GetMcisGroupIds",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "attach",
-                            "detach"
-                        ],
                         "type": "string",
-                        "description": "Option for MCIS",
-                        "name": "option",
-                        "in": "query",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "true",
-                            "false"
-                        ],
-                        "type": "string",
-                        "description": "Force to attach/detach even if VM info is not matched",
-                        "name": "force",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbVmInfo"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -4287,9 +4763,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Provisioning (Create and attach) dataDisk",
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/subgroup/{subgroupId}": {
+            "get": {
+                "description": "List VMs with a SubGroup label in a specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -4297,20 +4775,11 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Provisioning (Create and attach) dataDisk",
-                "operationId": "PostVmDataDisk",
+                "summary": "List VMs with a SubGroup label in a specified MCIS",
+                "operationId": "GetMcisGroupVms",
                 "parameters": [
-                    {
-                        "description": "Details for an Data Disk object",
-                        "name": "dataDiskInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskVmReq"
-                        }
-                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4329,18 +4798,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "g1",
+                        "description": "subGroup ID",
+                        "name": "subgroupId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbVmInfo"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -4348,13 +4826,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/snapshot": {
+            },
             "post": {
-                "description": "Snapshot VM and create a Custom Image Object using the Snapshot",
+                "description": "ScaleOut subGroup in specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -4362,20 +4844,11 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Snapshot VM and create a Custom Image Object using the Snapshot",
-                "operationId": "PostMcisVmSnapshot",
+                "summary": "ScaleOut subGroup in specified MCIS",
+                "operationId": "PostMcisSubGroupScaleOut",
                 "parameters": [
-                    {
-                        "description": "Request body to create VM snapshot",
-                        "name": "vmSnapshotReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbVmSnapshotReq"
-                        }
-                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4394,18 +4867,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "g1-1",
-                        "description": "VM ID",
-                        "name": "vmId",
+                        "default": "g1",
+                        "description": "subGroup ID",
+                        "name": "subgroupId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "subGroup scaleOut request",
+                        "name": "vmReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbScaleOutSubGroupReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
+                            "$ref": "#/definitions/mcis.TbMcisInfo"
                         }
                     },
                     "404": {
@@ -4423,9 +4905,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/vmDynamic": {
+        "/ns/{nsId}/mcis/{mcisId}/vm": {
             "post": {
-                "description": "Create VM Dynamically and add it to MCIS",
+                "description": "Create and add homogeneous VMs(subGroup) to a specified MCIS (Set subGroupSize for multiple VMs)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4435,8 +4917,8 @@ const docTemplate = `{
                 "tags": [
                     "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Create VM Dynamically and add it to MCIS",
-                "operationId": "PostMcisVmDynamic",
+                "summary": "Create and add homogeneous VMs(subGroup) to a specified MCIS (Set subGroupSize for multiple VMs)",
+                "operationId": "PostMcisVm",
                 "parameters": [
                     {
                         "type": "string",
@@ -4455,12 +4937,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for Vm dynamic request",
+                        "description": "Details for VMs(subGroup)",
                         "name": "vmReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbVmDynamicReq"
+                            "$ref": "#/definitions/mcis.TbVmReq"
                         }
                     }
                 ],
@@ -4486,9 +4968,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}": {
+        "/ns/{nsId}/mcis/{mcisId}/vm/{targetVmId}/bastion": {
             "get": {
-                "description": "Get resource info of a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "description": "Get bastion nodes for a VM",
                 "consumes": [
                     "application/json"
                 ],
@@ -4496,10 +4978,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[VPN] Site-to-site VPN (under development)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Get resource info of a site-to-site VPN (Currently, GCP-AWS is supported)",
-                "operationId": "GetSiteToSiteVpn",
+                "summary": "Get bastion nodes for a VM",
+                "operationId": "GetBastionNodes",
                 "parameters": [
                     {
                         "type": "string",
@@ -4519,51 +5001,41 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "vpn01",
-                        "description": "VPN ID",
-                        "name": "vpnId",
+                        "default": "g1-1",
+                        "description": "Target VM ID",
+                        "name": "targetVmId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "refined",
-                        "description": "Resource info by detail (refined, raw)",
-                        "name": "detail",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/mcir.BastionNode"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}/request/{requestId}": {
-            "get": {
-                "description": "Check the status of a specific request by its ID",
+        "/ns/{nsId}/mcis/{mcisId}/vm/{targetVmId}/bastion/{bastionVmId}": {
+            "put": {
+                "description": "Set bastion nodes for a VM. If no bastion is set, one of VMs with public IPs in the same subnet (or vNet) is assigned automatically\nat the first remote access, and other bastions are tried if the bastion is down.\nA bastion without a public IP is reached through its own bastions (multi-hop).",
                 "consumes": [
                     "application/json"
                 ],
@@ -4571,10 +5043,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[VPN] Site-to-site VPN (under development)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Check the status of a specific request by its ID",
-                "operationId": "GetRequestStatusOfSiteToSiteVpn",
+                "summary": "Set bastion nodes for a VM",
+                "operationId": "SetBastionNodes",
                 "parameters": [
                     {
                         "type": "string",
@@ -4594,16 +5066,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "vpn01",
-                        "description": "VPN ID",
-                        "name": "vpnId",
+                        "default": "g1-1",
+                        "description": "Target VM ID",
+                        "name": "targetVmId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Request ID",
-                        "name": "requestId",
+                        "default": "g1-1",
+                        "description": "Bastion VM ID",
+                        "name": "bastionVmId",
                         "in": "path",
                         "required": true
                     }
@@ -4612,33 +5085,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     }
                 }
             }
         },
-        "/ns/{nsId}/mcisDynamic": {
-            "post": {
-                "description": "Create MCIS Dynamically from common spec and image",
+        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}": {
+            "get": {
+                "description": "Get VM in specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -4648,8 +5115,8 @@ const docTemplate = `{
                 "tags": [
                     "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Create MCIS Dynamically",
-                "operationId": "PostMcisDynamic",
+                "summary": "Get VM in specified MCIS",
+                "operationId": "GetMcisVm",
                 "parameters": [
                     {
                         "type": "string",
@@ -4660,35 +5127,56 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Request body to provision MCIS dynamically. Must include commonSpec and commonImage info of each VM request.(ex: {name: mcis01,vm: [{commonImage: aws+ap-northeast-2+ubuntu22.04,commonSpec: aws+ap-northeast-2+t2.small}]} ) You can use /mcisRecommendVm and /mcisDynamicCheckRequest to get it) Check the guide: https://github.com/cloud-barista/cb-tumblebug/discussions/1570",
-                        "name": "mcisReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisDynamicReq"
-                        }
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "enum": [
-                            "hold"
+                            "default",
+                            "status",
+                            "idsInDetail"
                         ],
                         "type": "string",
-                        "description": "Option for MCIS creation",
+                        "description": "Option for MCIS",
                         "name": "option",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Custom request ID",
-                        "name": "x-request-id",
-                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcis.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcis.TbVmInfo"
+                                        },
+                                        "[IDNAME]": {
+                                            "$ref": "#/definitions/mcis.TbIdNameInDetailInfo"
+                                        },
+                                        "[STATUS]": {
+                                            "$ref": "#/definitions/mcis.TbVmStatusInfo"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -4704,11 +5192,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/monitoring/install/mcis/{mcisId}": {
-            "post": {
-                "description": "Install monitoring agent (CB-Dragonfly agent) to MCIS",
+            },
+            "delete": {
+                "description": "Delete VM in specified MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -4716,10 +5202,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Resource monitor (for developer)"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Install monitoring agent (CB-Dragonfly agent) to MCIS",
-                "operationId": "PostInstallMonitorAgentToMcis",
+                "summary": "Delete VM in specified MCIS",
+                "operationId": "DelMcisVm",
                 "parameters": [
                     {
                         "type": "string",
@@ -4738,20 +5224,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for an MCIS object",
-                        "name": "mcisInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.McisCmdReq"
-                        }
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "force"
+                        ],
+                        "type": "string",
+                        "description": "Option for delete VM (support force delete)",
+                        "name": "option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.AgentInstallContentWrapper"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -4759,19 +5253,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}": {
+        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/dataDisk": {
             "get": {
-                "description": "Get monitoring data of specified MCIS for specified monitoring metric (cpu, memory, disk, network)",
+                "description": "Get available dataDisks for a VM",
                 "consumes": [
                     "application/json"
                 ],
@@ -4779,10 +5267,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Resource monitor (for developer)"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Get monitoring data of specified MCIS for specified monitoring metric (cpu, memory, disk, network)",
-                "operationId": "GetMonitorData",
+                "summary": "Get available dataDisks for a VM",
+                "operationId": "GetVmDataDisk",
                 "parameters": [
                     {
                         "type": "string",
@@ -4802,17 +5290,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Metric type: cpu, memory, disk, network",
-                        "name": "metric",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/mcis.MonResultSimpleResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllDataDiskResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -4828,11 +5332,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/monitoring/status/mcis/{mcisId}/vm/{vmId}": {
+            },
             "put": {
-                "description": "Set monitoring agent (CB-Dragonfly agent) installation status installed (for Windows VM only)",
+                "description": "Attach/Detach available dataDisk",
                 "consumes": [
                     "application/json"
                 ],
@@ -4840,11 +5342,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Resource monitor (for developer)"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Set monitoring agent (CB-Dragonfly agent) installation status installed (for Windows VM only)",
-                "operationId": "PutMonitorAgentStatusInstalled",
+                "summary": "Attach/Detach available dataDisk",
+                "operationId": "PutVmDataDisk",
                 "parameters": [
+                    {
+                        "description": "Request body to attach/detach dataDisk",
+                        "name": "attachDetachDataDiskReq",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbAttachDetachDataDiskReq"
+                        }
+                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4863,11 +5373,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "vm01",
+                        "default": "g1-1",
                         "description": "VM ID",
                         "name": "vmId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "attach",
+                            "detach"
+                        ],
+                        "type": "string",
+                        "description": "Option for MCIS",
+                        "name": "option",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "Force to attach/detach even if VM info is not matched",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4890,11 +5421,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/policy/mcis": {
-            "get": {
-                "description": "List all MCIS policies",
+            },
+            "post": {
+                "description": "Provisioning (Create and attach) dataDisk",
                 "consumes": [
                     "application/json"
                 ],
@@ -4902,11 +5431,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Auto control policy management (WIP)"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "List all MCIS policies",
-                "operationId": "GetAllMcisPolicy",
+                "summary": "Provisioning (Create and attach) dataDisk",
+                "operationId": "PostVmDataDisk",
                 "parameters": [
+                    {
+                        "description": "Details for an Data Disk object",
+                        "name": "dataDiskInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbDataDiskVmReq"
+                        }
+                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4914,13 +5452,29 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.RestGetAllMcisPolicyResponse"
+                            "$ref": "#/definitions/mcis.TbVmInfo"
                         }
                     },
                     "404": {
@@ -4928,17 +5482,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all MCIS policies",
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/snapshot": {
+            "post": {
+                "description": "Snapshot VM and create a Custom Image Object using the Snapshot",
                 "consumes": [
                     "application/json"
                 ],
@@ -4946,11 +5496,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Auto control policy management (WIP)"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "Delete all MCIS policies",
-                "operationId": "DelAllMcisPolicy",
+                "summary": "Snapshot VM and create a Custom Image Object using the Snapshot",
+                "operationId": "PostMcisVmSnapshot",
                 "parameters": [
+                    {
+                        "description": "Request body to create VM snapshot",
+                        "name": "vmSnapshotReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbVmSnapshotReq"
+                        }
+                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -4958,13 +5517,29 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
                         }
                     },
                     "404": {
@@ -4972,13 +5547,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/policy/mcis/{mcisId}": {
+        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/sshHostKey": {
             "get": {
-                "description": "Get MCIS Policy",
+                "description": "Get the SSH host key (fingerprint) of a VM recorded on the first SSH connection (trust-on-first-use).\nAn SSH connection with a different host key fails until the recorded key is reset.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4986,10 +5567,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Auto control policy management (WIP)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Get MCIS Policy",
-                "operationId": "GetMcisPolicy",
+                "summary": "Get the SSH host key of a VM",
+                "operationId": "GetVmSshHostKey",
                 "parameters": [
                     {
                         "type": "string",
@@ -5006,13 +5587,21 @@ const docTemplate = `{
                         "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisPolicyInfo"
+                            "$ref": "#/definitions/mcis.SshHostKeyInfo"
                         }
                     },
                     "404": {
@@ -5029,8 +5618,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create MCIS Automation policy",
+            "delete": {
+                "description": "Reset the recorded SSH host key of a VM. The host key will be recorded again on the next SSH connection.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5038,10 +5627,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Auto control policy management (WIP)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Create MCIS Automation policy",
-                "operationId": "PostMcisPolicy",
+                "summary": "Reset the SSH host key of a VM",
+                "operationId": "DelVmSshHostKey",
                 "parameters": [
                     {
                         "type": "string",
@@ -5060,20 +5649,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for an MCIS automation policy request",
-                        "name": "mcisPolicyReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcis.McisPolicyReq"
-                        }
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.McisPolicyInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -5081,28 +5669,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete MCIS Policy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/vm/{vmId}/terminal": {
+            "get": {
+                "description": "Open an interactive PTY session to a VM through bastion nodes over WebSocket (no need to download private keys).\nBinary messages from the client are sent to the terminal as input, and output is sent back as binary messages.\nText messages are JSON control messages: {\"type\":\"input\",\"data\":\"ls\\n\"} or {\"type\":\"resize\",\"cols\":120,\"rows\":40}.\nThe session output is recorded (asciicast v2) and associated with the requesting user.",
                 "tags": [
-                    "[Infra service] MCIS Auto control policy management (WIP)"
+                    "[Infra service] MCIS Remote command"
                 ],
-                "summary": "Delete MCIS Policy",
-                "operationId": "DelMcisPolicy",
+                "summary": "Open an interactive web terminal to a VM (WebSocket)",
+                "operationId": "GetVmTerminal",
                 "parameters": [
                     {
                         "type": "string",
@@ -5119,13 +5697,41 @@ const docTemplate = `{
                         "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "VM ID",
+                        "name": "vmId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SSH user name (default: user name of the VM SSH key)",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 80,
+                        "description": "Terminal width",
+                        "name": "cols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 24,
+                        "description": "Terminal height",
+                        "name": "rows",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "type": "string"
                         }
                     },
                     "404": {
@@ -5133,13 +5739,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/registerCspVm": {
+        "/ns/{nsId}/mcis/{mcisId}/vmDynamic": {
             "post": {
-                "description": "Register existing VM in a CSP to Cloud-Barista MCIS",
+                "description": "Create VM Dynamically and add it to MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -5149,8 +5761,8 @@ const docTemplate = `{
                 "tags": [
                     "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Register existing VM in a CSP to Cloud-Barista MCIS",
-                "operationId": "PostRegisterCSPNativeVM",
+                "summary": "Create VM Dynamically and add it to MCIS",
+                "operationId": "PostMcisVmDynamic",
                 "parameters": [
                     {
                         "type": "string",
@@ -5161,12 +5773,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details for an MCIS object with existing CSP VM ID",
-                        "name": "mcisReq",
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Details for Vm dynamic request",
+                        "name": "vmReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisReq"
+                            "$ref": "#/definitions/mcis.TbVmDynamicReq"
                         }
                     }
                 ],
@@ -5192,9 +5812,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/customImage": {
+        "/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}": {
             "get": {
-                "description": "List all customImages or customImages' ID",
+                "description": "Get resource info of a site-to-site VPN (Currently, GCP-AWS is supported)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5202,10 +5822,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[VPN] Site-to-site VPN (under development)"
                 ],
-                "summary": "List all customImages or customImages' ID",
-                "operationId": "GetAllCustomImage",
+                "summary": "Get resource info of a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "operationId": "GetSiteToSiteVpn",
                 "parameters": [
                     {
                         "type": "string",
@@ -5216,65 +5836,60 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "id"
-                        ],
                         "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Field key for filtering (ex:guestOS)",
-                        "name": "filterKey",
-                        "in": "query"
+                        "default": "vpn01",
+                        "description": "VPN ID",
+                        "name": "vpnId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: Ubuntu18.04)",
-                        "name": "filterVal",
+                        "default": "refined",
+                        "description": "Resource info by detail (refined, raw)",
+                        "name": "detail",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllCustomImageResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/model.Response"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Register existing Custom Image in a CSP (option=register)",
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}/request/{requestId}": {
+            "get": {
+                "description": "Check the status of a specific request by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5282,10 +5897,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[VPN] Site-to-site VPN (under development)"
                 ],
-                "summary": "Register existing Custom Image in a CSP",
-                "operationId": "PostCustomImage",
+                "summary": "Check the status of a specific request by its ID",
+                "operationId": "GetRequestStatusOfSiteToSiteVpn",
                 "parameters": [
                     {
                         "type": "string",
@@ -5296,48 +5911,60 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "register"
-                        ],
                         "type": "string",
-                        "description": "Option: ",
-                        "name": "option",
-                        "in": "query",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request to Register existing Custom Image in a CSP",
-                        "name": "customImageRegisterReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbCustomImageReq"
-                        }
+                        "type": "string",
+                        "default": "vpn01",
+                        "description": "VPN ID",
+                        "name": "vpnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request ID",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all customImages",
+            }
+        },
+        "/ns/{nsId}/mcisDynamic": {
+            "post": {
+                "description": "Create MCIS Dynamically from common spec and image\nSet market.marketType to spot (with spotMaxPrice) for spot VMs. If market.spotInterruptionAction is replace,\nan interrupted spot VM (stopped or terminated by the CSP) is replaced by a new VM in the subGroup (scale-out).",
                 "consumes": [
                     "application/json"
                 ],
@@ -5345,10 +5972,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Delete all customImages",
-                "operationId": "DelAllCustomImage",
+                "summary": "Create MCIS Dynamically",
+                "operationId": "PostMcisDynamic",
                 "parameters": [
                     {
                         "type": "string",
@@ -5359,18 +5986,35 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "description": "Request body to provision MCIS dynamically. Must include commonSpec and commonImage info of each VM request.(ex: {name: mcis01,vm: [{commonImage: aws+ap-northeast-2+ubuntu22.04,commonSpec: aws+ap-northeast-2+t2.small}]} ) You can use /mcisRecommendVm and /mcisDynamicCheckRequest to get it) Check the guide: https://github.com/cloud-barista/cb-tumblebug/discussions/1570",
+                        "name": "mcisReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbMcisDynamicReq"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "hold"
+                        ],
                         "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
+                        "description": "Option for MCIS creation",
+                        "name": "option",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Custom request ID",
+                        "name": "x-request-id",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcis.TbMcisInfo"
                         }
                     },
                     "404": {
@@ -5378,13 +6022,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/resources/customImage/{customImageId}": {
+        "/ns/{nsId}/monitoring/agentless/mcis/{mcisId}": {
             "get": {
-                "description": "Get customImage",
+                "description": "Get agentless (SSH-based) monitoring configuration of MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -5392,10 +6042,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Get customImage",
-                "operationId": "GetCustomImage",
+                "summary": "Get agentless (SSH-based) monitoring configuration of MCIS",
+                "operationId": "GetAgentlessMonitoring",
                 "parameters": [
                     {
                         "type": "string",
@@ -5407,8 +6057,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "customImage ID",
-                        "name": "customImageId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
                     }
@@ -5417,7 +6068,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
+                            "$ref": "#/definitions/mcis.AgentlessMonitoringInfo"
                         }
                     },
                     "404": {
@@ -5434,8 +6085,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete customImage",
+            "post": {
+                "description": "Enable agentless monitoring that periodically samples cpu, mem, disk, net of VMs via SSH (bastion supported) without CB-Dragonfly agents.\nCollected data can be retrieved by GET /ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}?source=agentless",
                 "consumes": [
                     "application/json"
                 ],
@@ -5443,10 +6094,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] Snapshot and Custom Image Management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Delete customImage",
-                "operationId": "DelCustomImage",
+                "summary": "Enable agentless (SSH-based) monitoring for MCIS",
+                "operationId": "PostAgentlessMonitoring",
                 "parameters": [
                     {
                         "type": "string",
@@ -5458,17 +6109,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "customImage ID",
-                        "name": "customImageId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Options for agentless monitoring",
+                        "name": "agentlessMonitoringReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.AgentlessMonitoringReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.AgentlessMonitoringInfo"
                         }
                     },
                     "404": {
@@ -5476,13 +6137,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/resources/dataDisk": {
-            "get": {
-                "description": "List all Data Disks or Data Disks' ID",
+            },
+            "delete": {
+                "description": "Disable agentless (SSH-based) monitoring for MCIS and remove the collected data",
                 "consumes": [
                     "application/json"
                 ],
@@ -5490,10 +6155,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "List all Data Disks or Data Disks' ID",
-                "operationId": "GetAllDataDisk",
+                "summary": "Disable agentless (SSH-based) monitoring for MCIS",
+                "operationId": "DelAgentlessMonitoring",
                 "parameters": [
                     {
                         "type": "string",
@@ -5503,48 +6168,20 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Field key for filtering (ex: systemLabel)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field value for filtering (ex: Registered from CSP resource)",
-                        "name": "filterVal",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllDataDiskResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -5560,9 +6197,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ns/{nsId}/monitoring/install/mcis/{mcisId}": {
             "post": {
-                "description": "Create Data Disk",
+                "description": "Install monitoring agent (CB-Dragonfly agent) to MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -5570,10 +6209,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Create Data Disk",
-                "operationId": "PostDataDisk",
+                "summary": "Install monitoring agent (CB-Dragonfly agent) to MCIS",
+                "operationId": "PostInstallMonitorAgentToMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -5584,21 +6223,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "register"
-                        ],
                         "type": "string",
-                        "description": "Option: ",
-                        "name": "option",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Details for an Data Disk object",
-                        "name": "dataDiskInfo",
+                        "description": "Details for an MCIS object",
+                        "name": "mcisInfo",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskReq"
+                            "$ref": "#/definitions/mcis.McisCmdReq"
                         }
                     }
                 ],
@@ -5606,7 +6244,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
+                            "$ref": "#/definitions/mcis.AgentInstallContentWrapper"
                         }
                     },
                     "404": {
@@ -5622,9 +6260,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all Data Disks",
+            }
+        },
+        "/ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}": {
+            "get": {
+                "description": "Get monitoring data of specified MCIS for specified monitoring metric (cpu, memory, disk, network)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5632,10 +6272,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Delete all Data Disks",
-                "operationId": "DelAllDataDisk",
+                "summary": "Get monitoring data of specified MCIS for specified monitoring metric (cpu, memory, disk, network)",
+                "operationId": "GetMonitorData",
                 "parameters": [
                     {
                         "type": "string",
@@ -5647,17 +6287,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metric type: cpu, memory, disk, network",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "dragonfly",
+                            "agentless"
+                        ],
+                        "type": "string",
+                        "default": "dragonfly",
+                        "description": "Source of monitoring data (dragonfly: CB-Dragonfly agent, agentless: SSH-based collector)",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.MonResultSimpleResponse"
                         }
                     },
                     "404": {
@@ -5665,13 +6324,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/resources/dataDisk/{dataDiskId}": {
+        "/ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}/history": {
             "get": {
-                "description": "Get Data Disk",
+                "description": "Get historical monitoring data of MCIS as per-VM and per-subGroup series (downsampled by step and aggregation) suitable for charting.\nBacked by Tumblebug's own store (agentless) or CB-Dragonfly historical API (dragonfly).",
                 "consumes": [
                     "application/json"
                 ],
@@ -5679,10 +6344,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Get Data Disk",
-                "operationId": "GetDataDisk",
+                "summary": "Get historical monitoring data of MCIS with time range, step and aggregation",
+                "operationId": "GetMonitorHistory",
                 "parameters": [
                     {
                         "type": "string",
@@ -5694,17 +6359,82 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Data Disk ID",
-                        "name": "dataDiskId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "cpu",
+                            "mem",
+                            "disk",
+                            "net"
+                        ],
+                        "type": "string",
+                        "description": "Metric type",
+                        "name": "metric",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "agentless",
+                            "dragonfly"
+                        ],
+                        "type": "string",
+                        "description": "Source of monitoring data (default: agentless if enabled, otherwise dragonfly)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "2024-03-01T11:00:00Z",
+                        "description": "Start time of the range (RFC3339, default: 1 hour before end)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "2024-03-01T12:00:00Z",
+                        "description": "End time of the range (RFC3339, default: now)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Step in seconds (default: 60)",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "avg",
+                            "min",
+                            "max",
+                            "sum",
+                            "last"
+                        ],
+                        "type": "string",
+                        "description": "Aggregation in each step (default: avg)",
+                        "name": "aggregation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1",
+                        "description": "subGroupId to query only VMs in the subGroup",
+                        "name": "subGroupId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
+                            "$ref": "#/definitions/mcis.MonitoringHistoryResponse"
                         }
                     },
                     "404": {
@@ -5720,9 +6450,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ns/{nsId}/monitoring/status/mcis/{mcisId}/vm/{vmId}": {
             "put": {
-                "description": "Upsize Data Disk",
+                "description": "Set monitoring agent (CB-Dragonfly agent) installation status installed (for Windows VM only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5730,20 +6462,11 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Upsize Data Disk",
-                "operationId": "PutDataDisk",
+                "summary": "Set monitoring agent (CB-Dragonfly agent) installation status installed (for Windows VM only)",
+                "operationId": "PutMonitorAgentStatusInstalled",
                 "parameters": [
-                    {
-                        "description": "Request body to upsize the dataDisk",
-                        "name": "dataDiskUpsizeReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskUpsizeReq"
-                        }
-                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -5754,8 +6477,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "DataDisk ID",
-                        "name": "dataDiskId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "vm01",
+                        "description": "VM ID",
+                        "name": "vmId",
                         "in": "path",
                         "required": true
                     }
@@ -5764,7 +6496,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
+                            "$ref": "#/definitions/mcis.TbVmInfo"
                         }
                     },
                     "404": {
@@ -5780,9 +6512,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Data Disk",
+            }
+        },
+        "/ns/{nsId}/monitoring/uninstall/mcis/{mcisId}": {
+            "post": {
+                "description": "Uninstall monitoring agent (CB-Dragonfly agent) from VMs in MCIS.\nInstallMonAgent option of the MCIS will be set to \"no\" to prevent automatic reinstallation.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5790,10 +6524,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Data Disk management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Delete Data Disk",
-                "operationId": "DelDataDisk",
+                "summary": "Uninstall monitoring agent (CB-Dragonfly agent) from MCIS",
+                "operationId": "PostUninstallMonitorAgentFromMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -5805,17 +6539,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Data Disk ID",
-                        "name": "dataDiskId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Details for an MCIS object",
+                        "name": "mcisInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisCmdReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.AgentInstallContentWrapper"
                         }
                     },
                     "404": {
@@ -5823,13 +6567,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/resources/fetchImages": {
+        "/ns/{nsId}/monitoring/upgrade/mcis/{mcisId}": {
             "post": {
-                "description": "Fetch images",
+                "description": "Upgrade monitoring agent (CB-Dragonfly agent) of VMs in MCIS (VMs with installed, unhealthy or unknown agent status)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5837,10 +6587,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Resource monitor (for developer)"
                 ],
-                "summary": "Fetch images",
-                "operationId": "FetchImages",
+                "summary": "Upgrade monitoring agent (CB-Dragonfly agent) of MCIS",
+                "operationId": "PostUpgradeMonitorAgentToMcis",
                 "parameters": [
                     {
                         "type": "string",
@@ -5849,13 +6599,30 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Details for an MCIS object",
+                        "name": "mcisInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisCmdReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.AgentInstallContentWrapper"
                         }
                     },
                     "404": {
@@ -5873,9 +6640,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/fetchSpecs": {
-            "post": {
-                "description": "Fetch specs",
+        "/ns/{nsId}/policy/mcis": {
+            "get": {
+                "description": "List all MCIS policies",
                 "consumes": [
                     "application/json"
                 ],
@@ -5883,14 +6650,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "Fetch specs",
-                "operationId": "FetchSpecs",
+                "summary": "List all MCIS policies",
+                "operationId": "GetAllMcisPolicy",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
@@ -5901,7 +6668,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcis.RestGetAllMcisPolicyResponse"
                         }
                     },
                     "404": {
@@ -5917,11 +6684,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/resources/filterSpecsByRange": {
-            "post": {
-                "description": "Filter specs by range",
+            },
+            "delete": {
+                "description": "Delete all MCIS policies",
                 "consumes": [
                     "application/json"
                 ],
@@ -5929,33 +6694,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "Filter specs by range",
-                "operationId": "FilterSpecsByRange",
+                "summary": "Delete all MCIS policies",
+                "operationId": "DelAllMcisPolicy",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Filter for range-filtering specs",
-                        "name": "specRangeFilter",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.FilterSpecsByRangeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.RestFilterSpecsResponse"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -5963,19 +6720,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/resources/image": {
+        "/ns/{nsId}/policy/mcis/{mcisId}": {
             "get": {
-                "description": "List all images or images' ID",
+                "description": "Get MCIS Policy",
                 "consumes": [
                     "application/json"
                 ],
@@ -5983,10 +6734,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "List all images or images' ID",
-                "operationId": "GetAllImage",
+                "summary": "Get MCIS Policy",
+                "operationId": "GetMcisPolicy",
                 "parameters": [
                     {
                         "type": "string",
@@ -5996,48 +6747,20 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field key for filtering (ex:guestOS)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: Ubuntu18.04)",
-                        "name": "filterVal",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllImageResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/mcis.McisPolicyInfo"
                         }
                     },
                     "404": {
@@ -6055,7 +6778,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Register image",
+                "description": "Create MCIS Automation policy",
                 "consumes": [
                     "application/json"
                 ],
@@ -6063,59 +6786,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "Register image",
-                "operationId": "PostImage",
+                "summary": "Create MCIS Automation policy",
+                "operationId": "PostMcisPolicy",
                 "parameters": [
-                    {
-                        "enum": [
-                            "registerWithInfo",
-                            "registerWithId"
-                        ],
-                        "type": "string",
-                        "description": "registeringMethod",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Specify details of a image object (cspImageName, guestOS, description, ...) manually",
-                        "name": "imageInfo",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbImageInfo"
-                        }
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Specify name, connectionName and cspImageId to register an image object automatically",
-                        "name": "imageId",
+                        "description": "Details for an MCIS automation policy request",
+                        "name": "mcisPolicyReq",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbImageReq"
+                            "$ref": "#/definitions/mcis.McisPolicyReq"
                         }
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Force update to existing image object",
-                        "name": "update",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbImageInfo"
+                            "$ref": "#/definitions/mcis.McisPolicyInfo"
                         }
                     },
                     "404": {
@@ -6133,7 +6839,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete all images",
+                "description": "Delete MCIS Policy",
                 "consumes": [
                     "application/json"
                 ],
@@ -6141,10 +6847,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "Delete all images",
-                "operationId": "DelAllImage",
+                "summary": "Delete MCIS Policy",
+                "operationId": "DelMcisPolicy",
                 "parameters": [
                     {
                         "type": "string",
@@ -6156,17 +6862,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -6178,9 +6885,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/image/{imageId}": {
-            "get": {
-                "description": "Get image",
+        "/ns/{nsId}/policy/mcis/{mcisId}/simulate": {
+            "post": {
+                "description": "Simulate MCIS Automation policy with a given metric time series (synthetic or recorded).\nReturns the sequence of policy states and actions that the controller would take, without executing anything.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6188,10 +6895,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Auto control policy management (WIP)"
                 ],
-                "summary": "Get image",
-                "operationId": "GetImage",
+                "summary": "Simulate MCIS Automation policy",
+                "operationId": "PostMcisPolicySimulation",
                 "parameters": [
                     {
                         "type": "string",
@@ -6203,77 +6910,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
-                        "name": "imageId",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbImageInfo"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update image",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Infra resource] MCIR Image management"
-                ],
-                "summary": "Update image",
-                "operationId": "PutImage",
-                "parameters": [
                     {
-                        "description": "Details for an image object",
-                        "name": "imageInfo",
+                        "description": "MCIS automation policy and metric time series for simulation",
+                        "name": "mcisPolicySimulationReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbImageInfo"
+                            "$ref": "#/definitions/mcis.McisPolicySimulationReq"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "default": "ns01",
-                        "description": "Namespace ID",
-                        "name": "nsId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
-                        "name": "imageId",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbImageInfo"
+                            "$ref": "#/definitions/mcis.McisPolicySimulationResult"
                         }
                     },
                     "404": {
@@ -6289,56 +6946,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete image",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Infra resource] MCIR Image management"
-                ],
-                "summary": "Delete image",
-                "operationId": "DelImage",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "ns01",
-                        "description": "Namespace ID",
-                        "name": "nsId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
-                        "name": "imageId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    }
-                }
             }
         },
-        "/ns/{nsId}/resources/searchImage": {
+        "/ns/{nsId}/registerCspVm": {
             "post": {
-                "description": "Search image",
+                "description": "Register existing VM in a CSP to Cloud-Barista MCIS",
                 "consumes": [
                     "application/json"
                 ],
@@ -6346,10 +6958,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Image management"
+                    "[Infra service] MCIS Provisioning management"
                 ],
-                "summary": "Search image",
-                "operationId": "SearchImage",
+                "summary": "Register existing VM in a CSP to Cloud-Barista MCIS",
+                "operationId": "PostRegisterCSPNativeVM",
                 "parameters": [
                     {
                         "type": "string",
@@ -6360,12 +6972,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Keywords",
-                        "name": "keywords",
+                        "description": "Details for an MCIS object with existing CSP VM ID",
+                        "name": "mcisReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.RestSearchImageRequest"
+                            "$ref": "#/definitions/mcis.TbMcisReq"
                         }
                     }
                 ],
@@ -6373,7 +6985,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.RestGetAllImageResponse"
+                            "$ref": "#/definitions/mcis.TbMcisInfo"
                         }
                     },
                     "404": {
@@ -6391,9 +7003,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/securityGroup": {
+        "/ns/{nsId}/resources/customImage": {
             "get": {
-                "description": "List all Security Groups or Security Groups' ID",
+                "description": "List all customImages or customImages' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -6401,10 +7013,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Security group management"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "List all Security Groups or Security Groups' ID",
-                "operationId": "GetAllSecurityGroup",
+                "summary": "List all customImages or customImages' ID",
+                "operationId": "GetAllCustomImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -6425,13 +7037,13 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Field key for filtering (ex: systemLabel)",
+                        "description": "Field key for filtering (ex:guestOS)",
                         "name": "filterKey",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: Registered from CSP resource)",
+                        "description": "Field value for filtering (ex: Ubuntu18.04)",
                         "name": "filterVal",
                         "in": "query"
                     }
@@ -6448,7 +7060,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllSecurityGroupResponse"
+                                            "$ref": "#/definitions/mcir.RestGetAllCustomImageResponse"
                                         },
                                         "[ID]": {
                                             "$ref": "#/definitions/common.IdList"
@@ -6473,7 +7085,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create Security Group",
+                "description": "Register existing Custom Image in a CSP (option=register)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6481,10 +7093,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Security group management"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "Create Security Group",
-                "operationId": "PostSecurityGroup",
+                "summary": "Register existing Custom Image in a CSP",
+                "operationId": "PostCustomImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -6499,17 +7111,18 @@ const docTemplate = `{
                             "register"
                         ],
                         "type": "string",
-                        "description": "Option: [required params for register] connectionName, name, vNetId, cspSecurityGroupId",
+                        "description": "Option: ",
                         "name": "option",
-                        "in": "query"
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "description": "Details for an securityGroup object",
-                        "name": "securityGroupReq",
+                        "description": "Request to Register existing Custom Image in a CSP",
+                        "name": "customImageRegisterReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSecurityGroupReq"
+                            "$ref": "#/definitions/mcir.TbCustomImageReq"
                         }
                     }
                 ],
@@ -6517,7 +7130,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
+                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
                         }
                     },
                     "404": {
@@ -6535,7 +7148,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete all Security Groups",
+                "description": "Delete all customImages",
                 "consumes": [
                     "application/json"
                 ],
@@ -6543,10 +7156,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Security group management"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "Delete all Security Groups",
-                "operationId": "DelAllSecurityGroup",
+                "summary": "Delete all customImages",
+                "operationId": "DelAllCustomImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -6580,9 +7193,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/securityGroup/{securityGroupId}": {
+        "/ns/{nsId}/resources/customImage/{customImageId}": {
             "get": {
-                "description": "Get Security Group",
+                "description": "Get customImage",
                 "consumes": [
                     "application/json"
                 ],
@@ -6590,10 +7203,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Security group management"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "Get Security Group",
-                "operationId": "GetSecurityGroup",
+                "summary": "Get customImage",
+                "operationId": "GetCustomImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -6605,8 +7218,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Security Group ID",
-                        "name": "securityGroupId",
+                        "description": "customImage ID",
+                        "name": "customImageId",
                         "in": "path",
                         "required": true
                     }
@@ -6615,7 +7228,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
+                            "$ref": "#/definitions/mcir.TbCustomImageInfo"
                         }
                     },
                     "404": {
@@ -6633,7 +7246,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete Security Group",
+                "description": "Delete customImage",
                 "consumes": [
                     "application/json"
                 ],
@@ -6641,10 +7254,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Security group management"
+                    "[Infra resource] Snapshot and Custom Image Management"
                 ],
-                "summary": "Delete Security Group",
-                "operationId": "DelSecurityGroup",
+                "summary": "Delete customImage",
+                "operationId": "DelCustomImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -6656,120 +7269,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Security Group ID",
-                        "name": "securityGroupId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    }
-                }
-            }
-        },
-        "/ns/{nsId}/resources/securityGroup/{securityGroupId}/rules": {
-            "post": {
-                "description": "Create FirewallRules",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Infra resource] MCIR Security group management"
-                ],
-                "summary": "Create FirewallRules",
-                "operationId": "PostFirewallRules",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "ns01",
-                        "description": "Namespace ID",
-                        "name": "nsId",
+                        "description": "customImage ID",
+                        "name": "customImageId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "FirewallRules to create",
-                        "name": "firewallRuleReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbFirewallRulesWrapper"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete FirewallRules",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Infra resource] MCIR Security group management"
-                ],
-                "summary": "Delete FirewallRules",
-                "operationId": "DelFirewallRules",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "ns01",
-                        "description": "Namespace ID",
-                        "name": "nsId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "FirewallRules to delete",
-                        "name": "firewallRuleReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbFirewallRulesWrapper"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
-                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -6780,9 +7291,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/spec": {
+        "/ns/{nsId}/resources/dataDisk": {
             "get": {
-                "description": "List all specs or specs' ID",
+                "description": "List all Data Disks or Data Disks' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -6790,14 +7301,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "List all specs or specs' ID",
-                "operationId": "GetAllSpec",
+                "summary": "List all Data Disks or Data Disks' ID",
+                "operationId": "GetAllDataDisk",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
@@ -6814,13 +7325,13 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Field key for filtering (ex: providerName)",
+                        "description": "Field key for filtering (ex: systemLabel)",
                         "name": "filterKey",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: aws)",
+                        "description": "Field value for filtering (ex: Registered from CSP resource)",
                         "name": "filterVal",
                         "in": "query"
                     }
@@ -6837,7 +7348,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllSpecResponse"
+                                            "$ref": "#/definitions/mcir.RestGetAllDataDiskResponse"
                                         },
                                         "[ID]": {
                                             "$ref": "#/definitions/common.IdList"
@@ -6862,7 +7373,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Register spec",
+                "description": "Create Data Disk",
                 "consumes": [
                     "application/json"
                 ],
@@ -6870,59 +7381,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Register spec",
-                "operationId": "PostSpec",
+                "summary": "Create Data Disk",
+                "operationId": "PostDataDisk",
                 "parameters": [
-                    {
-                        "enum": [
-                            "registerWithInfo",
-                            "registerWithCspSpecName"
-                        ],
-                        "type": "string",
-                        "description": "registeringMethod",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Specify details of a spec object (vCPU, memoryGiB, ...) manually",
-                        "name": "specInfo",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecInfo"
-                        }
+                        "enum": [
+                            "register"
+                        ],
+                        "type": "string",
+                        "description": "Option: ",
+                        "name": "option",
+                        "in": "query"
                     },
                     {
-                        "description": "Specify name, connectionName and cspSpecName to register a spec object automatically",
-                        "name": "specName",
+                        "description": "Details for an Data Disk object",
+                        "name": "dataDiskInfo",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecReq"
+                            "$ref": "#/definitions/mcir.TbDataDiskReq"
                         }
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Force update to existing spec object",
-                        "name": "update",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecInfo"
+                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
                         }
                     },
                     "404": {
@@ -6940,7 +7435,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete all specs",
+                "description": "Delete all Data Disks",
                 "consumes": [
                     "application/json"
                 ],
@@ -6948,10 +7443,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Delete all specs",
-                "operationId": "DelAllSpec",
+                "summary": "Delete all Data Disks",
+                "operationId": "DelAllDataDisk",
                 "parameters": [
                     {
                         "type": "string",
@@ -6985,9 +7480,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/spec/{specId}": {
+        "/ns/{nsId}/resources/dataDisk/{dataDiskId}": {
             "get": {
-                "description": "Get spec",
+                "description": "Get Data Disk",
                 "consumes": [
                     "application/json"
                 ],
@@ -6995,14 +7490,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Get spec",
-                "operationId": "GetSpec",
+                "summary": "Get Data Disk",
+                "operationId": "GetDataDisk",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
@@ -7010,8 +7505,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
-                        "name": "specId",
+                        "description": "Data Disk ID",
+                        "name": "dataDiskId",
                         "in": "path",
                         "required": true
                     }
@@ -7020,7 +7515,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecInfo"
+                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
                         }
                     },
                     "404": {
@@ -7038,7 +7533,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update spec",
+                "description": "Upsize Data Disk",
                 "consumes": [
                     "application/json"
                 ],
@@ -7046,23 +7541,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Update spec",
-                "operationId": "PutSpec",
+                "summary": "Upsize Data Disk",
+                "operationId": "PutDataDisk",
                 "parameters": [
                     {
-                        "description": "Details for an spec object",
-                        "name": "specInfo",
+                        "description": "Request body to upsize the dataDisk",
+                        "name": "dataDiskUpsizeReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecInfo"
+                            "$ref": "#/definitions/mcir.TbDataDiskUpsizeReq"
                         }
                     },
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
@@ -7070,8 +7565,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
-                        "name": "specId",
+                        "description": "DataDisk ID",
+                        "name": "dataDiskId",
                         "in": "path",
                         "required": true
                     }
@@ -7080,7 +7575,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSpecInfo"
+                            "$ref": "#/definitions/mcir.TbDataDiskInfo"
                         }
                     },
                     "404": {
@@ -7098,7 +7593,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete spec",
+                "description": "Delete Data Disk",
                 "consumes": [
                     "application/json"
                 ],
@@ -7106,14 +7601,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Spec management"
+                    "[Infra resource] MCIR Data Disk management"
                 ],
-                "summary": "Delete spec",
-                "operationId": "DelSpec",
+                "summary": "Delete Data Disk",
+                "operationId": "DelDataDisk",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "system-purpose-common-ns",
+                        "default": "ns01",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
@@ -7121,8 +7616,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
-                        "name": "specId",
+                        "description": "Data Disk ID",
+                        "name": "dataDiskId",
                         "in": "path",
                         "required": true
                     }
@@ -7143,9 +7638,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/sshKey": {
-            "get": {
-                "description": "List all SSH Keys or SSH Keys' ID",
+        "/ns/{nsId}/resources/fetchImages": {
+            "post": {
+                "description": "Fetch images",
                 "consumes": [
                     "application/json"
                 ],
@@ -7153,10 +7648,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "List all SSH Keys or SSH Keys' ID",
-                "operationId": "GetAllSshKey",
+                "summary": "Fetch images",
+                "operationId": "FetchImages",
                 "parameters": [
                     {
                         "type": "string",
@@ -7165,49 +7660,13 @@ const docTemplate = `{
                         "name": "nsId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field key for filtering (ex: systemLabel)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field value for filtering (ex: Registered from CSP resource)",
-                        "name": "filterVal",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllSshKeyResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -7223,9 +7682,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ns/{nsId}/resources/fetchSpecs": {
             "post": {
-                "description": "Create SSH Key",
+                "description": "Fetch specs",
                 "consumes": [
                     "application/json"
                 ],
@@ -7233,43 +7694,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Create SSH Key",
-                "operationId": "PostSshKey",
+                "summary": "Fetch specs",
+                "operationId": "FetchSpecs",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "ns01",
+                        "default": "system-purpose-common-ns",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "register"
-                        ],
-                        "type": "string",
-                        "description": "Option: [required params for register] connectionName, name, cspSshKeyId, fingerprint, username, publicKey, privateKey",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Details for an SSH Key object",
-                        "name": "sshKeyInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbSshKeyReq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -7285,9 +7728,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete all SSH Keys",
+            }
+        },
+        "/ns/{nsId}/resources/filterSpecsByRange": {
+            "post": {
+                "description": "Filter specs by range",
                 "consumes": [
                     "application/json"
                 ],
@@ -7295,32 +7740,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Delete all SSH Keys",
-                "operationId": "DelAllSshKey",
+                "summary": "Filter specs by range",
+                "operationId": "FilterSpecsByRange",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "ns01",
+                        "default": "system-purpose-common-ns",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
+                        "description": "Filter for range-filtering specs",
+                        "name": "specRangeFilter",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.FilterSpecsByRangeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/mcir.RestFilterSpecsResponse"
                         }
                     },
                     "404": {
@@ -7328,13 +7774,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
         },
-        "/ns/{nsId}/resources/sshKey/{sshKeyId}": {
+        "/ns/{nsId}/resources/image": {
             "get": {
-                "description": "Get SSH Key",
+                "description": "List all images or images' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -7342,10 +7794,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Get SSH Key",
-                "operationId": "GetSshKey",
+                "summary": "List all images or images' ID",
+                "operationId": "GetAllImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7355,19 +7807,48 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "enum": [
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "SSH Key ID",
-                        "name": "sshKeyId",
-                        "in": "path",
-                        "required": true
+                        "description": "Field key for filtering (ex:guestOS)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: Ubuntu18.04)",
+                        "name": "filterVal",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllImageResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -7384,8 +7865,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update SSH Key",
+            "post": {
+                "description": "Register image",
                 "consumes": [
                     "application/json"
                 ],
@@ -7393,41 +7874,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Update SSH Key",
-                "operationId": "PutSshKey",
+                "summary": "Register image",
+                "operationId": "PostImage",
                 "parameters": [
                     {
-                        "description": "Details for an SSH Key object",
-                        "name": "sshKeyInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
-                        }
+                        "enum": [
+                            "registerWithInfo",
+                            "registerWithId"
+                        ],
+                        "type": "string",
+                        "description": "registeringMethod",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "default": "ns01",
+                        "default": "system-purpose-common-ns",
                         "description": "Namespace ID",
                         "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "SshKey ID",
-                        "name": "sshKeyId",
-                        "in": "path",
-                        "required": true
+                        "description": "Specify details of a image object (cspImageName, guestOS, description, ...) manually",
+                        "name": "imageInfo",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbImageInfo"
+                        }
+                    },
+                    {
+                        "description": "Specify name, connectionName and cspImageId to register an image object automatically",
+                        "name": "imageId",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbImageReq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Force update to existing image object",
+                        "name": "update",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                            "$ref": "#/definitions/mcir.TbImageInfo"
                         }
                     },
                     "404": {
@@ -7445,7 +7944,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete SSH Key",
+                "description": "Delete all images",
                 "consumes": [
                     "application/json"
                 ],
@@ -7453,10 +7952,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Access key management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Delete SSH Key",
-                "operationId": "DelSshKey",
+                "summary": "Delete all images",
+                "operationId": "DelAllImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7468,17 +7967,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "SSH Key ID",
-                        "name": "sshKeyId",
-                        "in": "path",
-                        "required": true
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -7490,9 +7989,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/vNet": {
+        "/ns/{nsId}/resources/image/{imageId}": {
             "get": {
-                "description": "List all VNets or VNets' ID",
+                "description": "Get image",
                 "consumes": [
                     "application/json"
                 ],
@@ -7500,10 +7999,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "List all VNets or VNets' ID",
-                "operationId": "GetAllVNet",
+                "summary": "Get image",
+                "operationId": "GetImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7513,48 +8012,19 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "id"
-                        ],
-                        "type": "string",
-                        "description": "Option",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Field key for filtering (ex: cspVNetName)",
-                        "name": "filterKey",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
-                        "name": "filterVal",
-                        "in": "query"
+                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Different return structures by the given option param",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/mcir.JSONResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[DEFAULT]": {
-                                            "$ref": "#/definitions/mcir.RestGetAllVNetResponse"
-                                        },
-                                        "[ID]": {
-                                            "$ref": "#/definitions/common.IdList"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/mcir.TbImageInfo"
                         }
                     },
                     "404": {
@@ -7571,8 +8041,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create VNet",
+            "put": {
+                "description": "Update image",
                 "consumes": [
                     "application/json"
                 ],
@@ -7580,11 +8050,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Create VNet",
-                "operationId": "PostVNet",
+                "summary": "Update image",
+                "operationId": "PutImage",
                 "parameters": [
+                    {
+                        "description": "Details for an image object",
+                        "name": "imageInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbImageInfo"
+                        }
+                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -7594,29 +8073,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "register"
-                        ],
                         "type": "string",
-                        "description": "Option: [required params for register] connectionName, name, cspVNetId",
-                        "name": "option",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Details for an VNet object",
-                        "name": "vNetReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcir.TbVNetReq"
-                        }
+                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbVNetInfo"
+                            "$ref": "#/definitions/mcir.TbImageInfo"
                         }
                     },
                     "404": {
@@ -7634,7 +8102,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete all VNets",
+                "description": "Delete image",
                 "consumes": [
                     "application/json"
                 ],
@@ -7642,10 +8110,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Delete all VNets",
-                "operationId": "DelAllVNet",
+                "summary": "Delete image",
+                "operationId": "DelImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7657,17 +8125,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Delete resources containing matched ID-substring only",
-                        "name": "match",
-                        "in": "query"
+                        "description": "Image ID ({providerName}+{regionName}+{imageName})",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -7679,9 +8147,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ns/{nsId}/resources/vNet/{vNetId}": {
-            "get": {
-                "description": "Get VNet",
+        "/ns/{nsId}/resources/searchImage": {
+            "post": {
+                "description": "Search image",
                 "consumes": [
                     "application/json"
                 ],
@@ -7689,10 +8157,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Image management"
                 ],
-                "summary": "Get VNet",
-                "operationId": "GetVNet",
+                "summary": "Search image",
+                "operationId": "SearchImage",
                 "parameters": [
                     {
                         "type": "string",
@@ -7703,18 +8171,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "VNet ID",
-                        "name": "vNetId",
-                        "in": "path",
-                        "required": true
+                        "description": "Keywords",
+                        "name": "keywords",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.RestSearchImageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbVNetInfo"
+                            "$ref": "#/definitions/mcir.RestGetAllImageResponse"
                         }
                     },
                     "404": {
@@ -7730,9 +8200,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete VNet",
+            }
+        },
+        "/ns/{nsId}/resources/securityGroup": {
+            "get": {
+                "description": "List all Security Groups or Security Groups' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -7740,10 +8212,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Delete VNet",
-                "operationId": "DelVNet",
+                "summary": "List all Security Groups or Security Groups' ID",
+                "operationId": "GetAllSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -7754,18 +8226,47 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "id"
+                        ],
                         "type": "string",
-                        "description": "VNet ID",
-                        "name": "vNetId",
-                        "in": "path",
-                        "required": true
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field key for filtering (ex: systemLabel)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: Registered from CSP resource)",
+                        "name": "filterVal",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllSecurityGroupResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -7773,13 +8274,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/resources/vNet/{vNetId}/subnet": {
+            },
             "post": {
-                "description": "Create Subnet",
+                "description": "Create Security Group",
                 "consumes": [
                     "application/json"
                 ],
@@ -7787,10 +8292,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Create Subnet",
-                "operationId": "PostSubnet",
+                "summary": "Create Security Group",
+                "operationId": "PostSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -7801,19 +8306,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "enum": [
+                            "register"
+                        ],
                         "type": "string",
-                        "description": "VNet ID",
-                        "name": "vNetId",
-                        "in": "path",
-                        "required": true
+                        "description": "Option: [required params for register] connectionName, name, vNetId, cspSecurityGroupId",
+                        "name": "option",
+                        "in": "query"
                     },
                     {
-                        "description": "Details for an Subnet object",
-                        "name": "subnetReq",
+                        "description": "Details for an securityGroup object",
+                        "name": "securityGroupReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSubnetReq"
+                            "$ref": "#/definitions/mcir.TbSecurityGroupReq"
                         }
                     }
                 ],
@@ -7821,7 +8328,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcir.TbSubnetInfo"
+                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
                         }
                     },
                     "404": {
@@ -7837,11 +8344,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ns/{nsId}/resources/vNet/{vNetId}/subnet/{subnetId}": {
+            },
             "delete": {
-                "description": "Delete Subnet",
+                "description": "Delete all Security Groups",
                 "consumes": [
                     "application/json"
                 ],
@@ -7849,10 +8354,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Network management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Delete Subnet",
-                "operationId": "DelSubnet",
+                "summary": "Delete all Security Groups",
+                "operationId": "DelAllSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -7864,24 +8369,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "VNet ID",
-                        "name": "vNetId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Subnet ID",
-                        "name": "subnetId",
-                        "in": "path",
-                        "required": true
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/common.IdList"
                         }
                     },
                     "404": {
@@ -7893,9 +8391,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/object": {
+        "/ns/{nsId}/resources/securityGroup/{securityGroupId}": {
             "get": {
-                "description": "Get value of an object",
+                "description": "Get Security Group",
                 "consumes": [
                     "application/json"
                 ],
@@ -7903,16 +8401,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Get value of an object",
-                "operationId": "GetObject",
+                "summary": "Get Security Group",
+                "operationId": "GetSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "get object value by key",
-                        "name": "key",
-                        "in": "query",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Security Group ID",
+                        "name": "securityGroupId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -7920,7 +8426,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
                         }
                     },
                     "404": {
@@ -7938,7 +8444,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an object",
+                "description": "Delete Security Group",
                 "consumes": [
                     "application/json"
                 ],
@@ -7946,16 +8452,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Delete an object",
-                "operationId": "DeleteObject",
+                "summary": "Delete Security Group",
+                "operationId": "DelSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "delete object value by key",
-                        "name": "key",
-                        "in": "query",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Security Group ID",
+                        "name": "securityGroupId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -7971,19 +8485,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/objects": {
-            "get": {
-                "description": "List all objects for a given key",
+        "/ns/{nsId}/resources/securityGroup/{securityGroupId}/rules": {
+            "post": {
+                "description": "Create FirewallRules",
                 "consumes": [
                     "application/json"
                 ],
@@ -7991,24 +8499,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "List all objects for a given key",
-                "operationId": "GetObjects",
+                "summary": "Create FirewallRules",
+                "operationId": "PostFirewallRules",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "retrieve objects by key",
-                        "name": "key",
-                        "in": "query",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "FirewallRules to create",
+                        "name": "firewallRuleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbFirewallRulesWrapper"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
                         }
                     },
                     "404": {
@@ -8026,7 +8544,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete child objects along with the given object",
+                "description": "Delete FirewallRules",
                 "consumes": [
                     "application/json"
                 ],
@@ -8034,24 +8552,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Security group management"
                 ],
-                "summary": "Delete child objects along with the given object",
-                "operationId": "DeleteObjects",
+                "summary": "Delete FirewallRules",
+                "operationId": "DelFirewallRules",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Delete child objects based on the given key string",
-                        "name": "key",
-                        "in": "query",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "FirewallRules to delete",
+                        "name": "firewallRuleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbFirewallRulesWrapper"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
                         }
                     },
                     "404": {
@@ -8059,19 +8587,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/provider": {
+        "/ns/{nsId}/resources/spec": {
             "get": {
-                "description": "List all registered Providers",
+                "description": "List all specs or specs' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -8079,15 +8601,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Multi-Cloud environment configuration"
+                    "[Infra resource] MCIR Spec management"
+                ],
+                "summary": "List all specs or specs' ID",
+                "operationId": "GetAllSpec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "system-purpose-common-ns",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field key for filtering (ex: providerName)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: aws)",
+                        "name": "filterVal",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all registered Providers",
-                "operationId": "GetProviderList",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/common.IdList"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllSpecResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -8103,11 +8671,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/provider/{providerName}/region/{regionName}": {
-            "get": {
-                "description": "Get registered region info",
+            },
+            "post": {
+                "description": "Register spec",
                 "consumes": [
                     "application/json"
                 ],
@@ -8115,31 +8681,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Multi-Cloud environment configuration"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Get registered region info",
-                "operationId": "GetRegion",
+                "summary": "Register spec",
+                "operationId": "PostSpec",
                 "parameters": [
                     {
+                        "enum": [
+                            "registerWithInfo",
+                            "registerWithCspSpecName"
+                        ],
                         "type": "string",
-                        "description": "Name of the CSP to retrieve",
-                        "name": "providerName",
-                        "in": "path",
+                        "description": "registeringMethod",
+                        "name": "action",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Name of region to retrieve",
-                        "name": "regionName",
+                        "default": "system-purpose-common-ns",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Specify details of a spec object (vCPU, memoryGiB, ...) manually",
+                        "name": "specInfo",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbSpecInfo"
+                        }
+                    },
+                    {
+                        "description": "Specify name, connectionName and cspSpecName to register a spec object automatically",
+                        "name": "specName",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbSpecReq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Force update to existing spec object",
+                        "name": "update",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.RegionDetail"
+                            "$ref": "#/definitions/mcir.TbSpecInfo"
                         }
                     },
                     "404": {
@@ -8155,11 +8749,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/readyz": {
-            "get": {
-                "description": "Check Tumblebug is ready",
+            },
+            "delete": {
+                "description": "Delete all specs",
                 "consumes": [
                     "application/json"
                 ],
@@ -8167,29 +8759,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Check Tumblebug is ready",
-                "operationId": "GetReadyz",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    }
-                }
-            }
+                "summary": "Delete all specs",
+                "operationId": "DelAllSpec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.IdList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
         },
-        "/region": {
+        "/ns/{nsId}/resources/spec/{specId}": {
             "get": {
-                "description": "List all registered regions",
+                "description": "Get spec",
                 "consumes": [
                     "application/json"
                 ],
@@ -8197,15 +8806,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Multi-Cloud environment configuration"
+                    "[Infra resource] MCIR Spec management"
+                ],
+                "summary": "Get spec",
+                "operationId": "GetSpec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "system-purpose-common-ns",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
+                        "name": "specId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all registered regions",
-                "operationId": "GetRegionList",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.RegionList"
+                            "$ref": "#/definitions/mcir.TbSpecInfo"
                         }
                     },
                     "404": {
@@ -8221,11 +8847,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/registerCspResources": {
-            "post": {
-                "description": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) to CB-Tumblebug",
+            },
+            "put": {
+                "description": "Update spec",
                 "consumes": [
                     "application/json"
                 ],
@@ -8233,47 +8857,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) to CB-Tumblebug",
-                "operationId": "RegisterCspNativeResources",
+                "summary": "Update spec",
+                "operationId": "PutSpec",
                 "parameters": [
                     {
-                        "description": "Specify connectionName, NS Id, and MCIS Name",
-                        "name": "Request",
+                        "description": "Details for an spec object",
+                        "name": "specInfo",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/common.RestRegisterCspNativeResourcesRequest"
+                            "$ref": "#/definitions/mcir.TbSpecInfo"
                         }
                     },
                     {
-                        "enum": [
-                            "onlyVm",
-                            "exceptVm"
-                        ],
                         "type": "string",
-                        "description": "Option to specify resourceType",
-                        "name": "option",
-                        "in": "query"
+                        "default": "system-purpose-common-ns",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "y",
-                            "n"
-                        ],
                         "type": "string",
-                        "default": "y",
-                        "description": "Flag to show VMs in a collective MCIS form (y,n)",
-                        "name": "mcisFlag",
-                        "in": "query"
+                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
+                        "name": "specId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.RegisterResourceResult"
+                            "$ref": "#/definitions/mcir.TbSpecInfo"
                         }
                     },
                     "404": {
@@ -8289,11 +8907,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/registerCspResourcesAll": {
-            "post": {
-                "description": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) from all Clouds to CB-Tumblebug",
+            },
+            "delete": {
+                "description": "Delete spec",
                 "consumes": [
                     "application/json"
                 ],
@@ -8301,47 +8917,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] System management"
+                    "[Infra resource] MCIR Spec management"
                 ],
-                "summary": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) from all Clouds to CB-Tumblebug",
-                "operationId": "RegisterCspNativeResourcesAll",
+                "summary": "Delete spec",
+                "operationId": "DelSpec",
                 "parameters": [
                     {
-                        "description": "Specify NS Id and MCIS Name",
-                        "name": "Request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/common.RestRegisterCspNativeResourcesRequestAll"
-                        }
-                    },
-                    {
-                        "enum": [
-                            "onlyVm",
-                            "exceptVm"
-                        ],
                         "type": "string",
-                        "description": "Option to specify resourceType",
-                        "name": "option",
-                        "in": "query"
+                        "default": "system-purpose-common-ns",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "y",
-                            "n"
-                        ],
                         "type": "string",
-                        "default": "y",
-                        "description": "Flag to show VMs in a collective MCIS form (y,n)",
-                        "name": "mcisFlag",
-                        "in": "query"
+                        "description": "Spec ID ({providerName}+{regionName}+{specName})",
+                        "name": "specId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcis.RegisterResourceAllResult"
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
                     "404": {
@@ -8349,19 +8950,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             }
         },
-        "/request/{reqId}": {
+        "/ns/{nsId}/resources/sshKey": {
             "get": {
-                "description": "Get details of a specific request",
+                "description": "List all SSH Keys or SSH Keys' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -8369,24 +8964,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Request tracking"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "Get request details",
-                "operationId": "GetRequest",
+                "summary": "List all SSH Keys or SSH Keys' ID",
+                "operationId": "GetAllSshKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Request ID acquired from X-Request-ID header",
-                        "name": "reqId",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Option",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field key for filtering (ex: systemLabel)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: Registered from CSP resource)",
+                        "name": "filterVal",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/common.RequestDetails"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllSshKeyResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -8403,8 +9035,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete details of a specific request",
+            "post": {
+                "description": "Create SSH Key",
                 "consumes": [
                     "application/json"
                 ],
@@ -8412,32 +9044,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Request tracking"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "Delete a specific request's details",
-                "operationId": "DeleteRequest",
+                "summary": "Create SSH Key",
+                "operationId": "PostSshKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Request ID to delete",
-                        "name": "reqId",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "register"
+                        ],
+                        "type": "string",
+                        "description": "Option: [required params for register] connectionName, name, cspSshKeyId, fingerprint, username, publicKey, privateKey",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Details for an SSH Key object",
+                        "name": "sshKeyInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbSshKeyReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
                         }
-                    }
-                }
-            }
-        },
-        "/requests": {
-            "get": {
-                "description": "Get details of all requests with optional filters.",
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all SSH Keys",
                 "consumes": [
                     "application/json"
                 ],
@@ -8445,39 +9106,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Admin] Request tracking"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "Get all requests",
-                "operationId": "GetAllRequests",
+                "summary": "Delete all SSH Keys",
+                "operationId": "DelAllSshKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by request status (Handling, Error, Success)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by HTTP method (GET, POST, etc.)",
-                        "name": "method",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by request URL",
-                        "name": "url",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by time in minutes from now (to get recent requests)",
-                        "name": "time",
-                        "in": "query"
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Option to save the results to a file (set 'true' to activate)",
-                        "name": "savefile",
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
                         "in": "query"
                     }
                 ],
@@ -8485,33 +9131,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/common.RequestDetails"
-                                }
-                            }
+                            "$ref": "#/definitions/common.IdList"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete details of all requests",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "[Admin] Request tracking"
-                ],
-                "summary": "Delete all requests' details",
-                "operationId": "DeleteAllRequests",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8519,20 +9143,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/stream-response/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}": {
-            "put": {
-                "description": "(To be provided) Update a site-to-site VPN",
+        "/ns/{nsId}/resources/sshKey/{sshKeyId}": {
+            "get": {
+                "description": "Get SSH Key",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/x-json-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "[VPN] Site-to-site VPN (under development)"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "(To be provided) Update a site-to-site VPN",
-                "operationId": "PutSiteToSiteVpn",
+                "summary": "Get SSH Key",
+                "operationId": "GetSshKey",
                 "parameters": [
                     {
                         "type": "string",
@@ -8544,39 +9168,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "vpn01",
-                        "description": "VPN ID",
-                        "name": "vpnId",
+                        "description": "SSH Key ID",
+                        "name": "sshKeyId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Resources info for VPN tunnel configuration between GCP and AWS",
-                        "name": "vpnReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RestPostVpnGcpToAwsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8586,29 +9192,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             },
-            "post": {
-                "description": "Create a site-to-site VPN (Currently, GCP-AWS is supported)",
+            "put": {
+                "description": "Update SSH Key",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/x-json-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "[VPN] Site-to-site VPN (under development)"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "Create a site-to-site VPN (Currently, GCP-AWS is supported)",
-                "operationId": "PostSiteToSiteVpn",
+                "summary": "Update SSH Key",
+                "operationId": "PutSshKey",
                 "parameters": [
+                    {
+                        "description": "Details for an SSH Key object",
+                        "name": "sshKeyInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                        }
+                    },
                     {
                         "type": "string",
                         "default": "ns01",
@@ -8619,39 +9228,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "vpn01",
-                        "description": "VPN ID",
-                        "name": "vpnId",
+                        "description": "SshKey ID",
+                        "name": "sshKeyId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Sites info for VPN configuration",
-                        "name": "vpnReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RestPostVpnRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSshKeyInfo"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8661,28 +9252,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "description": "Delete SSH Key",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/x-json-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "[VPN] Site-to-site VPN (under development)"
+                    "[Infra resource] MCIR Access key management"
                 ],
-                "summary": "Delete a site-to-site VPN (Currently, GCP-AWS is supported)",
-                "operationId": "DeleteSiteToSiteVpn",
+                "summary": "Delete SSH Key",
+                "operationId": "DelSshKey",
                 "parameters": [
                     {
                         "type": "string",
@@ -8694,17 +9279,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "mcis01",
-                        "description": "MCIS ID",
-                        "name": "mcisId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "vpn01",
-                        "description": "VPN ID",
-                        "name": "vpnId",
+                        "description": "SSH Key ID",
+                        "name": "sshKeyId",
                         "in": "path",
                         "required": true
                     }
@@ -8716,20 +9292,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8737,9 +9301,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/systemMcis": {
-            "post": {
-                "description": "Create System MCIS Dynamically for Special Purpose",
+        "/ns/{nsId}/resources/vNet": {
+            "get": {
+                "description": "List all VNets or VNets' ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -8747,26 +9311,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra service] MCIS Provisioning management"
+                    "[Infra resource] MCIR Network management"
                 ],
-                "summary": "Create System MCIS Dynamically for Special Purpose in NS:system-purpose-common-ns",
-                "operationId": "PostSystemMcis",
+                "summary": "List all VNets or VNets' ID",
+                "operationId": "GetAllVNet",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "enum": [
-                            "probe"
+                            "id"
                         ],
                         "type": "string",
-                        "description": "Option for the purpose of system MCIS",
+                        "description": "Option",
                         "name": "option",
                         "in": "query"
-                    }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field key for filtering (ex: cspVNetName)",
+                        "name": "filterKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field value for filtering (ex: ns01-alibaba-ap-northeast-1-vpc)",
+                        "name": "filterVal",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Different return structures by the given option param",
                         "schema": {
-                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/mcir.JSONResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[DEFAULT]": {
+                                            "$ref": "#/definitions/mcir.RestGetAllVNetResponse"
+                                        },
+                                        "[ID]": {
+                                            "$ref": "#/definitions/common.IdList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
@@ -8782,11 +9381,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/util/net/design": {
+            },
             "post": {
-                "description": "Design a hierarchical network configuration of a VPC network or multi-cloud network consisting of multiple VPC networks",
+                "description": "Create VNet",
                 "consumes": [
                     "application/json"
                 ],
@@ -8794,30 +9391,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Utility] Multi-cloud network design"
+                    "[Infra resource] MCIR Network management"
                 ],
-                "summary": "Design a multi-cloud network configuration",
-                "operationId": "PostUtilToDesignNetwork",
+                "summary": "Create VNet",
+                "operationId": "PostVNet",
                 "parameters": [
                     {
-                        "description": "A root/main network CIDR block and subnetting rules",
-                        "name": "subnettingReq",
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "register"
+                        ],
+                        "type": "string",
+                        "description": "Option: [required params for register] connectionName, name, cspVNetId",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Details for an VNet object",
+                        "name": "vNetReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/netutil.RestPostUtilToDesignNetworkRequest"
+                            "$ref": "#/definitions/mcir.TbVNetReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/netutil.RestPostUtilToDesignNetworkReponse"
+                            "$ref": "#/definitions/mcir.TbVNetInfo"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8829,11 +9443,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/util/net/validate": {
-            "post": {
-                "description": "Validate a hierarchical configuration of a VPC network or multi-cloud network consisting of multiple VPC networks",
+            },
+            "delete": {
+                "description": "Delete all VNets",
                 "consumes": [
                     "application/json"
                 ],
@@ -8841,30 +9453,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Utility] Multi-cloud network design"
+                    "[Infra resource] MCIR Network management"
                 ],
-                "summary": "Validate a multi-cloud network configuration",
-                "operationId": "PostUtilToValidateNetwork",
+                "summary": "Delete all VNets",
+                "operationId": "DelAllVNet",
                 "parameters": [
                     {
-                        "description": "A hierarchical network configuration",
-                        "name": "subnettingReq",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "Delete resources containing matched ID-substring only",
+                        "name": "match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/netutil.RestPostUtilToValidateNetworkRequest"
+                            "$ref": "#/definitions/common.IdList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
                         }
                     }
+                }
+            }
+        },
+        "/ns/{nsId}/resources/vNet/{vNetId}": {
+            "get": {
+                "description": "Get VNet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra resource] MCIR Network management"
+                ],
+                "summary": "Get VNet",
+                "operationId": "GetVNet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "VNet ID",
+                        "name": "vNetId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbVNetInfo"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
@@ -8876,11 +9541,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/{nsId}/checkResource/{resourceType}/{resourceId}": {
-            "get": {
-                "description": "Check resources' existence",
+            },
+            "delete": {
+                "description": "Delete VNet",
                 "consumes": [
                     "application/json"
                 ],
@@ -8888,10 +9551,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Infra resource] MCIR Common"
+                    "[Infra resource] MCIR Network management"
                 ],
-                "summary": "Check resources' existence",
-                "operationId": "CheckResource",
+                "summary": "Delete VNet",
+                "operationId": "DelVNet",
                 "parameters": [
                     {
                         "type": "string",
@@ -8903,24 +9566,73 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Resource Type",
-                        "name": "resourceType",
+                        "description": "VNet ID",
+                        "name": "vNetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/resources/vNet/{vNetId}/subnet": {
+            "post": {
+                "description": "Create Subnet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra resource] MCIR Network management"
+                ],
+                "summary": "Create Subnet",
+                "operationId": "PostSubnet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Resource ID",
-                        "name": "resourceId",
+                        "description": "VNet ID",
+                        "name": "vNetId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Details for an Subnet object",
+                        "name": "subnetReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcir.TbSubnetReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/common.SimpleMsg"
+                            "$ref": "#/definitions/mcir.TbSubnetInfo"
                         }
                     },
                     "404": {
@@ -8928,392 +9640,5176 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.SimpleMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "common.CSPDetail": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "driver": {
-                    "type": "string"
-                },
-                "links": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+        },
+        "/ns/{nsId}/resources/vNet/{vNetId}/subnet/{subnetId}": {
+            "delete": {
+                "description": "Delete Subnet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra resource] MCIR Network management"
+                ],
+                "summary": "Delete Subnet",
+                "operationId": "DelSubnet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "VNet ID",
+                        "name": "vNetId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subnet ID",
+                        "name": "subnetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/rightSizing/mcis/{mcisId}": {
+            "post": {
+                "description": "Recommend smaller (downsize) or larger (upsize) specs for each subGroup by the CPU and memory utilization (95th percentile) of running VMs\nin a monitoring window (monitoring history, or on-demand monitoring data if no history) and specs of the same connection.\nEstimated monthly savings are calculated from CostPerHour (SpotCostPerHour for spot VMs) x 730h x the number of running VMs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Recommend right-sized specs for subGroups of MCIS",
+                "operationId": "PostRightSizing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Monitoring window and utilization thresholds",
+                        "name": "rightSizingReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RightSizingReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RightSizingResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/rightSizing/mcis/{mcisId}/apply": {
+            "post": {
+                "description": "Start a rolling spec change of a subGroup to the recommended spec (or targetSpecId) in background.\nRunning VMs are replaced one by one: a VM with the new spec is added to the subGroup, and the old VM is terminated after the new VM is running.\nThe change stops at the first failure (the old VM is kept). Check the progress by GET /ns/{nsId}/specChange/{changeId}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Apply right-sizing to a subGroup of MCIS by a rolling spec change",
+                "operationId": "PostApplyRightSizing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SubGroup (required) and target spec (optional)",
+                        "name": "rightSizingApplyReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RightSizingApplyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecChangeInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/script": {
+            "get": {
+                "description": "List the latest versions of scripts in the script library of a namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List scripts in the script library",
+                "operationId": "GetAllScript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a script (version 1) in the script library of a namespace.\nParameters are exported to the script as environment variables.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Create a script in the script library",
+                "operationId": "PostScript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Script",
+                        "name": "scriptReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/script/{scriptName}": {
+            "get": {
+                "description": "Get a script (the latest version by default)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get a script in the script library",
+                "operationId": "GetScript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "setweb",
+                        "description": "Script name",
+                        "name": "scriptName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version of the script (the latest version if omitted)",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a script. A new version is added, and previous versions are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Update a script in the script library",
+                "operationId": "PutScript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "setweb",
+                        "description": "Script name",
+                        "name": "scriptName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Script",
+                        "name": "scriptReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a script with all versions (execution records are kept)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Delete a script in the script library",
+                "operationId": "DelScript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "setweb",
+                        "description": "Script name",
+                        "name": "scriptName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/script/{scriptName}/version": {
+            "get": {
+                "description": "List all versions of a script in the script library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List versions of a script",
+                "operationId": "GetAllScriptVersion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "setweb",
+                        "description": "Script name",
+                        "name": "scriptName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/scriptExecution": {
+            "get": {
+                "description": "List records of script executions in a namespace (the latest first)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List script executions",
+                "operationId": "GetAllScriptExecution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "setweb",
+                        "description": "List only executions of the script",
+                        "name": "scriptName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "List only executions to the MCIS",
+                        "name": "mcisId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptExecutionInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/scriptExecution/{executionId}": {
+            "get": {
+                "description": "Get a record of a script execution with the results of each VM",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get a script execution",
+                "operationId": "GetScriptExecution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Script execution ID",
+                        "name": "executionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.ScriptExecutionInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/secret": {
+            "get": {
+                "description": "List namespace secrets (keys and descriptions only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Namespace] Namespace management"
+                ],
+                "summary": "List namespace secrets",
+                "operationId": "GetAllSecret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SecretInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/secret/{secretKey}": {
+            "put": {
+                "description": "Create or update a namespace secret. The secret can be used in remote commands by $$Func(GetSecret(key=...)).\nThe value is never returned by the API and is masked in logs and command results,\nbut it is stored in plaintext in the key-value store of CB-Tumblebug (protect access to the store).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Namespace] Namespace management"
+                ],
+                "summary": "Create or update a namespace secret",
+                "operationId": "PutSecret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "repoToken",
+                        "description": "Secret key",
+                        "name": "secretKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value and description of the secret",
+                        "name": "secretReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SecretReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SecretInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a namespace secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Namespace] Namespace management"
+                ],
+                "summary": "Delete a namespace secret",
+                "operationId": "DelSecret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "repoToken",
+                        "description": "Secret key",
+                        "name": "secretKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/specChange": {
+            "get": {
+                "description": "List rolling spec changes in a namespace (the latest first)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "List spec changes",
+                "operationId": "GetAllSpecChange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "List only spec changes of the MCIS",
+                        "name": "mcisId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecChangeInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/specChange/{changeId}": {
+            "get": {
+                "description": "Get a rolling spec change with the states of VM replacements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Get a spec change",
+                "operationId": "GetSpecChange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec change ID",
+                        "name": "changeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecChangeInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/terminalSession": {
+            "get": {
+                "description": "List web terminal sessions in a namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List web terminal sessions",
+                "operationId": "GetAllTerminalSession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "List only sessions of the MCIS",
+                        "name": "mcisId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List only sessions requested by the user",
+                        "name": "requestedBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TerminalSessionInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/terminalSession/{sessionId}": {
+            "get": {
+                "description": "Get a web terminal session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get a web terminal session",
+                "operationId": "GetTerminalSession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Terminal session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TerminalSessionInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/terminalSession/{sessionId}/recording": {
+            "get": {
+                "description": "Get the recording of a web terminal session (asciicast v2, playable with asciinema)",
+                "produces": [
+                    "application/x-asciicast"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get the recording of a web terminal session",
+                "operationId": "GetTerminalSessionRecording",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Terminal session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/transferFile/mcis/{mcisId}": {
+            "get": {
+                "description": "Download a file from VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP through bastion nodes.\nReturns a zip archive with {vmId}/{file name} entries and results.json (per-VM results with SHA-256 checksums).\nMax file size is 100 MiB for each VM.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Download a file from specified MCIS",
+                "operationId": "GetFileFromMcis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "/home/cb-user/app.conf",
+                        "description": "Source file path on VMs (relative path is from the home directory)",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SSH user name (default: user name of the VM SSH key)",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1",
+                        "description": "subGroupId to download the file only from VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "vmId to download the file only from a VM in MCIS",
+                        "name": "vmId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a file to VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP through bastion nodes.\nReturns per-VM results with SHA-256 checksums (verified by sha256sum in each VM). Max file size is 100 MiB.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Upload a file to specified MCIS",
+                "operationId": "PostFileToMcis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "/home/cb-user/app.conf",
+                        "description": "Destination file path on VMs (relative path is from the home directory)",
+                        "name": "path",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0644",
+                        "description": "File mode in octal (e.g., 0644, 0755)",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "SSH user name (default: user name of the VM SSH key)",
+                        "name": "userName",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1",
+                        "description": "subGroupId to upload the file only to VMs in subGroup of MCIS",
+                        "name": "subGroupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "g1-1",
+                        "description": "vmId to upload the file only to a VM in MCIS",
+                        "name": "vmId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.McisFileTransferResults"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/workflow": {
+            "get": {
+                "description": "List workflows in a namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List workflows",
+                "operationId": "GetAllWorkflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a workflow (DAG of steps). Each step targets VMs (subGroupId, vmId, or label) with commands or a script of the script library.\nOutputs of a step are captured as variables, which can be referenced by $$Var(stepId.outputName) in the steps depending on it.\nA variable in a command is quoted for shell unless escape=none is given: $$Var(stepId.outputName, escape=none).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Create a workflow",
+                "operationId": "PostWorkflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workflow",
+                        "name": "workflowReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/workflow/{workflowName}": {
+            "get": {
+                "description": "Get a workflow",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get a workflow",
+                "operationId": "GetWorkflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ceph",
+                        "description": "Workflow name",
+                        "name": "workflowName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update steps of a workflow",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Update a workflow",
+                "operationId": "PutWorkflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ceph",
+                        "description": "Workflow name",
+                        "name": "workflowName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workflow",
+                        "name": "workflowReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a workflow (records of runs are kept)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Delete a workflow",
+                "operationId": "DelWorkflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ceph",
+                        "description": "Workflow name",
+                        "name": "workflowName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/workflowRun": {
+            "get": {
+                "description": "List workflow runs in a namespace (the latest first)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "List workflow runs",
+                "operationId": "GetAllWorkflowRun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ceph",
+                        "description": "List only runs of the workflow",
+                        "name": "workflowName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "List only runs to the MCIS",
+                        "name": "mcisId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowRunInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/workflowRun/mcis/{mcisId}": {
+            "post": {
+                "description": "Start a run of a workflow to MCIS (asynchronous). Steps are executed in the order of dependencies\n(independent steps in parallel), and a step is skipped if a step that it depends on did not succeed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Run a workflow to specified MCIS",
+                "operationId": "PostWorkflowRunMcis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workflow to run",
+                        "name": "workflowRunReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowRunReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowRunInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/ns/{nsId}/workflowRun/{runId}": {
+            "get": {
+                "description": "Get a workflow run with the states, outputs and results of steps",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Get a workflow run",
+                "operationId": "GetWorkflowRun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workflow run ID",
+                        "name": "runId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.WorkflowRunInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a finished workflow run",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Remote command"
+                ],
+                "summary": "Delete a workflow run",
+                "operationId": "DelWorkflowRun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workflow run ID",
+                        "name": "runId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/object": {
+            "get": {
+                "description": "Get value of an object",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Get value of an object",
+                "operationId": "GetObject",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "get object value by key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an object",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Delete an object",
+                "operationId": "DeleteObject",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "delete object value by key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/objects": {
+            "get": {
+                "description": "List all objects for a given key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "List all objects for a given key",
+                "operationId": "GetObjects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retrieve objects by key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete child objects along with the given object",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Delete child objects along with the given object",
+                "operationId": "DeleteObjects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delete child objects based on the given key string",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider": {
+            "get": {
+                "description": "List all registered Providers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Multi-Cloud environment configuration"
+                ],
+                "summary": "List all registered Providers",
+                "operationId": "GetProviderList",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.IdList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/provider/{providerName}/region/{regionName}": {
+            "get": {
+                "description": "Get registered region info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Multi-Cloud environment configuration"
+                ],
+                "summary": "Get registered region info",
+                "operationId": "GetRegion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the CSP to retrieve",
+                        "name": "providerName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of region to retrieve",
+                        "name": "regionName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.RegionDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Check Tumblebug is ready",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Check Tumblebug is ready",
+                "operationId": "GetReadyz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/region": {
+            "get": {
+                "description": "List all registered regions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Multi-Cloud environment configuration"
+                ],
+                "summary": "List all registered regions",
+                "operationId": "GetRegionList",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.RegionList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/registerCspResources": {
+            "post": {
+                "description": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) to CB-Tumblebug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) to CB-Tumblebug",
+                "operationId": "RegisterCspNativeResources",
+                "parameters": [
+                    {
+                        "description": "Specify connectionName, NS Id, and MCIS Name",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.RestRegisterCspNativeResourcesRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "onlyVm",
+                            "exceptVm"
+                        ],
+                        "type": "string",
+                        "description": "Option to specify resourceType",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "y",
+                            "n"
+                        ],
+                        "type": "string",
+                        "default": "y",
+                        "description": "Flag to show VMs in a collective MCIS form (y,n)",
+                        "name": "mcisFlag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RegisterResourceResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/registerCspResourcesAll": {
+            "post": {
+                "description": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) from all Clouds to CB-Tumblebug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] System management"
+                ],
+                "summary": "Register CSP Native Resources (vNet, securityGroup, sshKey, vm) from all Clouds to CB-Tumblebug",
+                "operationId": "RegisterCspNativeResourcesAll",
+                "parameters": [
+                    {
+                        "description": "Specify NS Id and MCIS Name",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.RestRegisterCspNativeResourcesRequestAll"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "onlyVm",
+                            "exceptVm"
+                        ],
+                        "type": "string",
+                        "description": "Option to specify resourceType",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "y",
+                            "n"
+                        ],
+                        "type": "string",
+                        "default": "y",
+                        "description": "Flag to show VMs in a collective MCIS form (y,n)",
+                        "name": "mcisFlag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.RegisterResourceAllResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/request/{reqId}": {
+            "get": {
+                "description": "Get details of a specific request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Request tracking"
+                ],
+                "summary": "Get request details",
+                "operationId": "GetRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID acquired from X-Request-ID header",
+                        "name": "reqId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.RequestDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete details of a specific request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Request tracking"
+                ],
+                "summary": "Delete a specific request's details",
+                "operationId": "DeleteRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID to delete",
+                        "name": "reqId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests": {
+            "get": {
+                "description": "Get details of all requests with optional filters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Request tracking"
+                ],
+                "summary": "Get all requests",
+                "operationId": "GetAllRequests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by request status (Handling, Error, Success)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by HTTP method (GET, POST, etc.)",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by request URL",
+                        "name": "url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by time in minutes from now (to get recent requests)",
+                        "name": "time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Option to save the results to a file (set 'true' to activate)",
+                        "name": "savefile",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/common.RequestDetails"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete details of all requests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Admin] Request tracking"
+                ],
+                "summary": "Delete all requests' details",
+                "operationId": "DeleteAllRequests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/specAvailability": {
+            "get": {
+                "description": "List availability scores of specs with recent provisioning failures (reason: capacity, quota, unsupported, other) and successes.\nThe score (1 is available) is decreased by failures and recovered over time. Specs with low scores are filtered out (availabilityScore filter, default \u003e= 0.3)\nor down-ranked (availability priority) in recommendation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "List availabilities of specs from provisioning results",
+                "operationId": "GetAllSpecAvailability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "aws-ap-northeast-2",
+                        "description": "List only specs of the connection",
+                        "name": "connectionName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecAvailabilityInfoList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all recorded provisioning results (all specs are regarded as available)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Delete all availabilities of specs",
+                "operationId": "DelAllSpecAvailability",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/specBenchmark": {
+            "get": {
+                "description": "List benchmark results (recent samples per metric) of specs and scores aggregated from them.\nScore of each metric is relative to the median of benchmarked specs (100), and the overall score is applied to\nevaluationScore01 of the spec (evaluationScore02 ~ 10 for cpus, cpum, memR, memW, fioR, fioW, dbR, dbW, rtt) for the performance priority in recommendation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "List benchmark results of specs",
+                "operationId": "GetAllSpecBenchmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ap-northeast-2",
+                        "description": "List only specs in the region",
+                        "name": "regionName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecBenchmarkHistoryList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete benchmark results of all specs (scores already applied to specs are kept until specs are reloaded)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Delete benchmark results of all specs",
+                "operationId": "DelAllSpecBenchmark",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/specBenchmark/{specId}": {
+            "get": {
+                "description": "Get benchmark results (recent samples per metric) of a spec and scores aggregated from them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Performance benchmarking (WIP)"
+                ],
+                "summary": "Get benchmark results of a spec",
+                "operationId": "GetSpecBenchmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "aws+ap-northeast-2+t2.small",
+                        "description": "Spec ID",
+                        "name": "specId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.SpecBenchmarkHistory"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream-response/ns/{nsId}/mcis/{mcisId}/vpn/{vpnId}": {
+            "put": {
+                "description": "(To be provided) Update a site-to-site VPN",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/x-json-stream"
+                ],
+                "tags": [
+                    "[VPN] Site-to-site VPN (under development)"
+                ],
+                "summary": "(To be provided) Update a site-to-site VPN",
+                "operationId": "PutSiteToSiteVpn",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "vpn01",
+                        "description": "VPN ID",
+                        "name": "vpnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resources info for VPN tunnel configuration between GCP and AWS",
+                        "name": "vpnReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RestPostVpnGcpToAwsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/x-json-stream"
+                ],
+                "tags": [
+                    "[VPN] Site-to-site VPN (under development)"
+                ],
+                "summary": "Create a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "operationId": "PostSiteToSiteVpn",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "vpn01",
+                        "description": "VPN ID",
+                        "name": "vpnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sites info for VPN configuration",
+                        "name": "vpnReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RestPostVpnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/x-json-stream"
+                ],
+                "tags": [
+                    "[VPN] Site-to-site VPN (under development)"
+                ],
+                "summary": "Delete a site-to-site VPN (Currently, GCP-AWS is supported)",
+                "operationId": "DeleteSiteToSiteVpn",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "mcis01",
+                        "description": "MCIS ID",
+                        "name": "mcisId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "vpn01",
+                        "description": "VPN ID",
+                        "name": "vpnId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/systemMcis": {
+            "post": {
+                "description": "Create System MCIS Dynamically for Special Purpose",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra service] MCIS Provisioning management"
+                ],
+                "summary": "Create System MCIS Dynamically for Special Purpose in NS:system-purpose-common-ns",
+                "operationId": "PostSystemMcis",
+                "parameters": [
+                    {
+                        "enum": [
+                            "probe"
+                        ],
+                        "type": "string",
+                        "description": "Option for the purpose of system MCIS",
+                        "name": "option",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcis.TbMcisInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/util/net/design": {
+            "post": {
+                "description": "Design a hierarchical network configuration of a VPC network or multi-cloud network consisting of multiple VPC networks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Utility] Multi-cloud network design"
+                ],
+                "summary": "Design a multi-cloud network configuration",
+                "operationId": "PostUtilToDesignNetwork",
+                "parameters": [
+                    {
+                        "description": "A root/main network CIDR block and subnetting rules",
+                        "name": "subnettingReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/netutil.RestPostUtilToDesignNetworkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/netutil.RestPostUtilToDesignNetworkReponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/util/net/validate": {
+            "post": {
+                "description": "Validate a hierarchical configuration of a VPC network or multi-cloud network consisting of multiple VPC networks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Utility] Multi-cloud network design"
+                ],
+                "summary": "Validate a multi-cloud network configuration",
+                "operationId": "PostUtilToValidateNetwork",
+                "parameters": [
+                    {
+                        "description": "A hierarchical network configuration",
+                        "name": "subnettingReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/netutil.RestPostUtilToValidateNetworkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/{nsId}/checkResource/{resourceType}/{resourceId}": {
+            "get": {
+                "description": "Check resources' existence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Infra resource] MCIR Common"
+                ],
+                "summary": "Check resources' existence",
+                "operationId": "CheckResource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ns01",
+                        "description": "Namespace ID",
+                        "name": "nsId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Type",
+                        "name": "resourceType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.SimpleMsg"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "common.CSPDetail": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "driver": {
+                    "type": "string"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "regions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/common.RegionDetail"
+                    }
+                }
+            }
+        },
+        "common.CloudInfo": {
+            "type": "object",
+            "properties": {
+                "csps": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/common.CSPDetail"
+                    }
+                }
+            }
+        },
+        "common.ConfigInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "SPIDER_REST_URL"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "SPIDER_REST_URL"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "http://localhost:1024/spider"
+                }
+            }
+        },
+        "common.ConfigReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "SPIDER_REST_URL"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "http://localhost:1024/spider"
+                }
+            }
+        },
+        "common.ConnConfig": {
+            "type": "object",
+            "properties": {
+                "configName": {
+                    "type": "string"
+                },
+                "credentialHolder": {
+                    "type": "string"
+                },
+                "credentialName": {
+                    "type": "string"
+                },
+                "driverName": {
+                    "type": "string"
+                },
+                "providerName": {
+                    "type": "string"
+                },
+                "regionDetail": {
+                    "$ref": "#/definitions/common.RegionDetail"
+                },
+                "regionRepresentative": {
+                    "type": "boolean"
+                },
+                "regionZoneInfo": {
+                    "$ref": "#/definitions/common.RegionZoneInfo"
+                },
+                "regionZoneInfoName": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "common.ConnConfigList": {
+            "type": "object",
+            "properties": {
+                "connectionconfig": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.ConnConfig"
+                    }
+                }
+            }
+        },
+        "common.CredentialInfo": {
+            "type": "object",
+            "properties": {
+                "credentialHolder": {
+                    "type": "string"
+                },
+                "credentialName": {
+                    "type": "string"
+                },
+                "keyValueInfoList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "providerName": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.CredentialReq": {
+            "type": "object",
+            "properties": {
+                "credentialHolder": {
+                    "type": "string"
+                },
+                "keyValueInfoList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "providerName": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.IID": {
+            "type": "object",
+            "properties": {
+                "nameId": {
+                    "description": "NameID by user",
+                    "type": "string"
+                },
+                "systemId": {
+                    "description": "SystemID by CloudOS",
+                    "type": "string"
+                }
+            }
+        },
+        "common.IdList": {
+            "type": "object",
+            "properties": {
+                "output": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.JSONResult": {
+            "type": "object"
+        },
+        "common.K8sClusterDetail": {
+            "type": "object",
+            "properties": {
+                "node_images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterNodeImageDetail"
+                    }
+                },
+                "nodegroups_with_cluster": {
+                    "type": "boolean"
+                },
+                "root_disks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterRootDiskDetail"
+                    }
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterVersionDetail"
+                    }
+                }
+            }
+        },
+        "common.K8sClusterInfo": {
+            "type": "object",
+            "properties": {
+                "k8s_cluster": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/common.K8sClusterDetail"
+                    }
+                }
+            }
+        },
+        "common.K8sClusterNodeImageDetail": {
+            "type": "object",
+            "properties": {
+                "availables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterNodeImageDetailAvailable"
+                    }
+                },
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.K8sClusterNodeImageDetailAvailable": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.K8sClusterRootDiskDetail": {
+            "type": "object",
+            "properties": {
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "$ref": "#/definitions/common.K8sClusterRootDiskDetailSize"
+                },
+                "type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterRootDiskDetailType"
+                    }
+                }
+            }
+        },
+        "common.K8sClusterRootDiskDetailSize": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                }
+            }
+        },
+        "common.K8sClusterRootDiskDetailType": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.K8sClusterVersionDetail": {
+            "type": "object",
+            "properties": {
+                "availables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.K8sClusterVersionDetailAvailable"
+                    }
+                },
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.K8sClusterVersionDetailAvailable": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.KeyValue": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.Location": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "common.NsInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Description for this namespace"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ns01"
+                }
+            }
+        },
+        "common.NsReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Description for this namespace"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ns01"
+                }
+            }
+        },
+        "common.PowerModel": {
+            "type": "object",
+            "properties": {
+                "pue": {
+                    "type": "number"
+                },
+                "wattsPerAccelerator": {
+                    "type": "number"
+                },
+                "wattsPerMemoryGiB": {
+                    "type": "number"
+                },
+                "wattsPerVCPU": {
+                    "type": "number"
+                }
+            }
+        },
+        "common.RegionDetail": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/common.Location"
+                },
+                "regionId": {
+                    "type": "string"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.RegionList": {
+            "type": "object",
+            "properties": {
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.SpiderRegionZoneInfo"
+                    }
+                }
+            }
+        },
+        "common.RegionZoneInfo": {
+            "type": "object",
+            "properties": {
+                "assignedRegion": {
+                    "type": "string"
+                },
+                "assignedZone": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.RequestDetails": {
+            "type": "object",
+            "properties": {
+                "endTime": {
+                    "description": "The time when the request was fully processed.",
+                    "type": "string"
+                },
+                "errorResponse": {
+                    "description": "A message describing any error that occurred during request processing.",
+                    "type": "string"
+                },
+                "requestInfo": {
+                    "description": "Extracted information about the request.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.RequestInfo"
+                        }
+                    ]
+                },
+                "responseData": {
+                    "description": "The data sent back in response to the request."
+                },
+                "startTime": {
+                    "description": "The time when the request was received by the server.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "The current status of the request (e.g., \"Handling\", \"Error\", \"Success\").",
+                    "type": "string"
+                }
+            }
+        },
+        "common.RequestInfo": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Optional: request body"
+                },
+                "header": {
+                    "description": "Key-value pairs of the request headers.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "description": "HTTP method (GET, POST, etc.), indicating the request's action type.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "The URL the request is made to.",
+                    "type": "string"
+                }
+            }
+        },
+        "common.RestGetAllConfigResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "Name string     ` + "`" + `json:\"name\"` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.ConfigInfo"
+                    }
+                }
+            }
+        },
+        "common.RestGetAllNsResponse": {
+            "type": "object",
+            "properties": {
+                "ns": {
+                    "description": "Name string     ` + "`" + `json:\"name\"` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.NsInfo"
+                    }
+                }
+            }
+        },
+        "common.RestInspectResourcesRequest": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "enum": [
+                        "vNet",
+                        "securityGroup",
+                        "sshKey",
+                        "vm"
+                    ],
+                    "example": "vNet"
+                }
+            }
+        },
+        "common.RestRegisterCspNativeResourcesRequest": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1"
+                },
+                "mcisName": {
+                    "type": "string",
+                    "example": "csp"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                }
+            }
+        },
+        "common.RestRegisterCspNativeResourcesRequestAll": {
+            "type": "object",
+            "properties": {
+                "mcisName": {
+                    "type": "string",
+                    "example": "csp"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                }
+            }
+        },
+        "common.SecretInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "token for the artifact repository"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "repoToken"
+                },
+                "updatedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "common.SecretInfoList": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.SecretInfo"
+                    }
+                }
+            }
+        },
+        "common.SecretReq": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "token for the artifact repository"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "my-token"
+                }
+            }
+        },
+        "common.SimpleMsg": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Any message"
+                }
+            }
+        },
+        "common.SpiderRegionZoneInfo": {
+            "type": "object",
+            "properties": {
+                "availableZoneList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keyValueInfoList": {
+                    "description": "ex) { {region, us-east1}, {zone, us-east1-c} }",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "providerName": {
+                    "description": "ex) \"GCP\"",
+                    "type": "string"
+                },
+                "regionName": {
+                    "description": "ex) \"region01\"",
+                    "type": "string"
+                }
+            }
+        },
+        "common.TbConnectionName": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.BastionNode": {
+            "type": "object",
+            "properties": {
+                "mcisId": {
+                    "type": "string"
+                },
+                "vmId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.CustomImageStatus": {
+            "type": "string",
+            "enum": [
+                "Available",
+                "Unavailable"
+            ],
+            "x-enum-varnames": [
+                "MyImageAvailable",
+                "MyImageUnavailable"
+            ]
+        },
+        "mcir.DiskStatus": {
+            "type": "string",
+            "enum": [
+                "Creating",
+                "Available",
+                "Attached",
+                "Deleting",
+                "Error"
+            ],
+            "x-enum-varnames": [
+                "DiskCreating",
+                "DiskAvailable",
+                "DiskAttached",
+                "DiskDeleting",
+                "DiskError"
+            ]
+        },
+        "mcir.FilterSpecsByRangeRequest": {
+            "type": "object",
+            "properties": {
+                "acceleratorCount": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "acceleratorMemoryGB": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "acceleratorModel": {
+                    "type": "string"
+                },
+                "acceleratorType": {
+                    "type": "string"
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "costPerHour": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "cspSpecName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "evaluationScore01": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore02": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore03": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore04": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore05": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore06": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore07": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore08": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore09": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationScore10": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "evaluationStatus": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maxTotalStorageTiB": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "memoryGiB": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "netBwGbps": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "osType": {
+                    "type": "string"
+                },
+                "providerName": {
+                    "type": "string"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "spotCostPerHour": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "storageGiB": {
+                    "$ref": "#/definitions/mcir.Range"
+                },
+                "vCPU": {
+                    "$ref": "#/definitions/mcir.Range"
+                }
+            }
+        },
+        "mcir.JSONResult": {
+            "type": "object"
+        },
+        "mcir.Range": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "mcir.RestFilterSpecsResponse": {
+            "type": "object",
+            "properties": {
+                "spec": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbSpecInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllCustomImageResponse": {
+            "type": "object",
+            "properties": {
+                "customImage": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbCustomImageInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllDataDiskResponse": {
+            "type": "object",
+            "properties": {
+                "dataDisk": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbDataDiskInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllImageResponse": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbImageInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllSecurityGroupResponse": {
+            "type": "object",
+            "properties": {
+                "securityGroup": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllSpecResponse": {
+            "type": "object",
+            "properties": {
+                "spec": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbSpecInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllSshKeyResponse": {
+            "type": "object",
+            "properties": {
+                "sshKey": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestGetAllVNetResponse": {
+            "type": "object",
+            "properties": {
+                "vNet": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbVNetInfo"
+                    }
+                }
+            }
+        },
+        "mcir.RestLookupImageRequest": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspImageId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.RestLookupSpecRequest": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSpecName": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.RestSearchImageRequest": {
+            "type": "object",
+            "properties": {
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mcir.SpiderGpuInfo": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "string"
+                },
+                "mem": {
+                    "type": "string"
+                },
+                "mfr": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.SpiderImageInfo": {
+            "type": "object",
+            "properties": {
+                "guestOS": {
+                    "description": "Windows7, Ubuntu etc.",
+                    "type": "string"
+                },
+                "iid": {
+                    "description": "Fields for response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/common.IID"
+                        }
+                    ]
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "description": "Fields for request",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "available, unavailable",
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.SpiderImageList": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.SpiderImageInfo"
+                    }
+                }
+            }
+        },
+        "mcir.SpiderSpecInfo": {
+            "type": "object",
+            "properties": {
+                "gpu": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.SpiderGpuInfo"
+                    }
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "mem": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "vcpu": {
+                    "$ref": "#/definitions/mcir.SpiderVCpuInfo"
+                }
+            }
+        },
+        "mcir.SpiderSpecList": {
+            "type": "object",
+            "properties": {
+                "vmspec": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.SpiderSpecInfo"
+                    }
+                }
+            }
+        },
+        "mcir.SpiderVCpuInfo": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "description": "GHz",
+                    "type": "string"
+                },
+                "count": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbAttachDetachDataDiskReq": {
+            "type": "object",
+            "required": [
+                "dataDiskId"
+            ],
+            "properties": {
+                "dataDiskId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbCustomImageInfo": {
+            "type": "object",
+            "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1"
+                },
+                "creationDate": {
+                    "type": "string",
+                    "example": "2022-10-18T08:12:48Z"
+                },
+                "cspCustomImageId": {
+                    "type": "string",
+                    "example": "ami-06eb41e14121c550a"
+                },
+                "cspCustomImageName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-snapshot"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "guestOS": {
+                    "description": "Windows7, Ubuntu etc.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-snapshot"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-snapshot"
+                },
+                "namespace": {
+                    "description": "required to save in RDB",
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "sourceVmId": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-1"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcir.CustomImageStatus"
+                        }
+                    ],
+                    "example": "Available"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                }
+            }
+        },
+        "mcir.TbCustomImageReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspCustomImageId": {
+                    "description": "This field is for 'Register existing custom image'",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sourceVmId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbDataDiskInfo": {
+            "type": "object",
+            "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1"
+                },
+                "createdTime": {
+                    "type": "string",
+                    "example": "2022-10-12T05:09:51.05Z"
+                },
+                "cspDataDiskId": {
+                    "type": "string",
+                    "example": "vol-0d397c3239629bd43"
+                },
+                "cspDataDiskName": {
+                    "type": "string",
+                    "example": "ns01-aws-ap-southeast-1-datadisk"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Available"
+                },
+                "diskSize": {
+                    "type": "string",
+                    "example": "77"
+                },
+                "diskType": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-datadisk"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-datadisk"
+                },
+                "status": {
+                    "description": "Available, Unavailable, Attached, ...",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcir.DiskStatus"
+                        }
+                    ],
+                    "example": "Available"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                },
+                "systemMessage": {
+                    "description": "Latest system message such as error message",
+                    "type": "string",
+                    "example": "Failed because ..."
+                }
+            }
+        },
+        "mcir.TbDataDiskReq": {
+            "type": "object",
+            "required": [
+                "connectionName",
+                "diskSize",
+                "name"
+            ],
+            "properties": {
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1"
+                },
+                "cspDataDiskId": {
+                    "description": "Fields for \"Register existing dataDisk\" feature\nCspDataDiskId is required to register object from CSP (option=register)",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "diskSize": {
+                    "type": "string",
+                    "default": "100",
+                    "example": "77"
+                },
+                "diskType": {
+                    "type": "string",
+                    "example": "default"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-datadisk"
+                }
+            }
+        },
+        "mcir.TbDataDiskUpsizeReq": {
+            "type": "object",
+            "required": [
+                "diskSize"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "diskSize": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbDataDiskVmReq": {
+            "type": "object",
+            "required": [
+                "diskSize",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "diskSize": {
+                    "type": "string",
+                    "default": "100",
+                    "example": "77"
+                },
+                "diskType": {
+                    "type": "string",
+                    "example": "default"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-datadisk"
+                }
+            }
+        },
+        "mcir.TbFirewallRuleInfo": {
+            "type": "object",
+            "required": [
+                "direction",
+                "fromPort",
+                "ipprotocol",
+                "toPort"
+            ],
+            "properties": {
+                "cidr": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "` + "`" + `json:\"direction\"` + "`" + `",
+                    "type": "string"
+                },
+                "fromPort": {
+                    "description": "` + "`" + `json:\"fromPort\"` + "`" + `",
+                    "type": "string"
+                },
+                "ipprotocol": {
+                    "description": "` + "`" + `json:\"ipProtocol\"` + "`" + `",
+                    "type": "string"
+                },
+                "toPort": {
+                    "description": "` + "`" + `json:\"toPort\"` + "`" + `",
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbFirewallRulesWrapper": {
+            "type": "object",
+            "properties": {
+                "firewallRules": {
+                    "description": "validate:\"required\"` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
+                    }
+                }
+            }
+        },
+        "mcir.TbImageInfo": {
+            "type": "object",
+            "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "cspImageId": {
+                    "type": "string"
+                },
+                "cspImageName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "guestOS": {
+                    "description": "Windows7, Ubuntu etc.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "required to save in RDB",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "available, unavailable",
+                    "type": "string"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                }
+            }
+        },
+        "mcir.TbImageReq": {
+            "type": "object",
+            "required": [
+                "connectionName",
+                "cspImageId",
+                "name"
+            ],
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspImageId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbSecurityGroupInfo": {
+            "type": "object",
+            "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSecurityGroupId": {
+                    "type": "string"
+                },
+                "cspSecurityGroupName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "firewallRules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                },
+                "vNetId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbSecurityGroupReq": {
+            "type": "object",
+            "required": [
+                "connectionName",
+                "name",
+                "vNetId"
+            ],
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSecurityGroupId": {
+                    "description": "CspSecurityGroupId is required to register object from CSP (option=register)",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "firewallRules": {
+                    "description": "validate:\"required\"` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "vNetId": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbSpecInfo": {
+            "type": "object",
+            "properties": {
+                "acceleratorCount": {
+                    "type": "integer"
+                },
+                "acceleratorMemoryGB": {
+                    "type": "number"
+                },
+                "acceleratorModel": {
+                    "type": "string"
+                },
+                "acceleratorType": {
+                    "type": "string"
+                },
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "costPerHour": {
+                    "type": "number"
+                },
+                "cspSpecName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "evaluationScore01": {
+                    "type": "number"
+                },
+                "evaluationScore02": {
+                    "type": "number"
+                },
+                "evaluationScore03": {
+                    "type": "number"
+                },
+                "evaluationScore04": {
+                    "type": "number"
+                },
+                "evaluationScore05": {
+                    "type": "number"
+                },
+                "evaluationScore06": {
+                    "type": "number"
+                },
+                "evaluationScore07": {
+                    "type": "number"
+                },
+                "evaluationScore08": {
+                    "type": "number"
+                },
+                "evaluationScore09": {
+                    "type": "number"
+                },
+                "evaluationScore10": {
+                    "type": "number"
+                },
+                "evaluationStatus": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "maxTotalStorageTiB": {
+                    "type": "integer"
+                },
+                "memoryGiB": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "required to save in RDB",
+                    "type": "string"
+                },
+                "netBwGbps": {
+                    "type": "integer"
+                },
+                "orderInFilteredResult": {
+                    "type": "integer"
+                },
+                "osType": {
+                    "type": "string"
                 },
-                "regions": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/common.RegionDetail"
-                    }
+                "providerName": {
+                    "type": "string"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "rootDiskSize": {
+                    "type": "string"
+                },
+                "rootDiskType": {
+                    "type": "string"
+                },
+                "spotCostPerHour": {
+                    "type": "number"
+                },
+                "storageGiB": {
+                    "type": "integer"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                },
+                "vCPU": {
+                    "type": "integer"
                 }
             }
         },
-        "common.CloudInfo": {
+        "mcir.TbSpecReq": {
             "type": "object",
+            "required": [
+                "connectionName",
+                "cspSpecName",
+                "name"
+            ],
             "properties": {
-                "csps": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/common.CSPDetail"
-                    }
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSpecName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "common.ConfigInfo": {
+        "mcir.TbSshKeyInfo": {
             "type": "object",
             "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSshKeyId": {
+                    "description": "CspSshKeyId used for CSP-native identifier (either Name or ID)",
+                    "type": "string"
+                },
+                "cspSshKeyName": {
+                    "description": "CspSshKeyName used for CB-Spider identifier",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
                 "id": {
-                    "type": "string",
-                    "example": "SPIDER_REST_URL"
+                    "type": "string"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
                 },
                 "name": {
-                    "type": "string",
-                    "example": "SPIDER_REST_URL"
+                    "type": "string"
                 },
-                "value": {
+                "privateKey": {
+                    "type": "string"
+                },
+                "publicKey": {
+                    "type": "string"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
                     "type": "string",
-                    "example": "http://localhost:1024/spider"
+                    "example": "Managed by CB-Tumblebug"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "verifiedUsername": {
+                    "type": "string"
                 }
             }
         },
-        "common.ConfigReq": {
+        "mcir.TbSshKeyReq": {
+            "type": "object",
+            "required": [
+                "connectionName",
+                "name"
+            ],
+            "properties": {
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspSshKeyId": {
+                    "description": "Fields for \"Register existing SSH keys\" feature\nCspSshKeyId is required to register object from CSP (option=register)",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "privateKey": {
+                    "type": "string"
+                },
+                "publicKey": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "verifiedUsername": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbSubnetInfo": {
             "type": "object",
+            "required": [
+                "ipv4_CIDR",
+                "name"
+            ],
             "properties": {
+                "bastionNodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.BastionNode"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idFromCsp": {
+                    "type": "string"
+                },
+                "ipv4_CIDR": {
+                    "type": "string"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
                 "name": {
-                    "type": "string",
-                    "example": "SPIDER_REST_URL"
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbSubnetReq": {
+            "type": "object",
+            "required": [
+                "ipv4_CIDR",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "idFromCsp": {
+                    "type": "string"
+                },
+                "ipv4_CIDR": {
+                    "type": "string"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcir.TbVNetInfo": {
+            "type": "object",
+            "properties": {
+                "associatedObjectList": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cidrBlock": {
+                    "type": "string"
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "cspVNetId": {
+                    "type": "string"
+                },
+                "cspVNetName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "keyValueList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.KeyValue"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subnetInfoList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbSubnetInfo"
+                    }
                 },
-                "value": {
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
                     "type": "string",
-                    "example": "http://localhost:1024/spider"
+                    "example": "Managed by CB-Tumblebug"
                 }
             }
         },
-        "common.ConnConfig": {
+        "mcir.TbVNetReq": {
             "type": "object",
+            "required": [
+                "connectionName",
+                "name"
+            ],
             "properties": {
-                "configName": {
-                    "type": "string"
-                },
-                "credentialHolder": {
+                "cidrBlock": {
                     "type": "string"
                 },
-                "credentialName": {
+                "connectionName": {
                     "type": "string"
                 },
-                "driverName": {
+                "cspVNetId": {
                     "type": "string"
                 },
-                "providerName": {
+                "description": {
                     "type": "string"
                 },
-                "regionDetail": {
-                    "$ref": "#/definitions/common.RegionDetail"
-                },
-                "regionRepresentative": {
-                    "type": "boolean"
-                },
-                "regionZoneInfo": {
-                    "$ref": "#/definitions/common.RegionZoneInfo"
-                },
-                "regionZoneInfoName": {
+                "name": {
                     "type": "string"
                 },
-                "verified": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "common.ConnConfigList": {
-            "type": "object",
-            "properties": {
-                "connectionconfig": {
+                "subnetInfoList": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.ConnConfig"
+                        "$ref": "#/definitions/mcir.TbSubnetReq"
                     }
                 }
             }
         },
-        "common.CredentialInfo": {
+        "mcis.AgentInstallContent": {
             "type": "object",
             "properties": {
-                "credentialHolder": {
+                "mcisId": {
                     "type": "string"
                 },
-                "credentialName": {
+                "result": {
                     "type": "string"
                 },
-                "keyValueInfoList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.KeyValue"
-                    }
+                "vmId": {
+                    "type": "string"
                 },
-                "providerName": {
+                "vmIp": {
                     "type": "string"
                 }
             }
         },
-        "common.CredentialReq": {
+        "mcis.AgentInstallContentWrapper": {
             "type": "object",
             "properties": {
-                "credentialHolder": {
-                    "type": "string"
-                },
-                "keyValueInfoList": {
+                "resultArray": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.AgentInstallContent"
                     }
-                },
-                "providerName": {
-                    "type": "string"
                 }
             }
         },
-        "common.IID": {
+        "mcis.AgentlessMonitoringInfo": {
             "type": "object",
             "properties": {
-                "nameId": {
-                    "description": "NameID by user",
-                    "type": "string"
+                "intervalSec": {
+                    "type": "integer",
+                    "example": 60
                 },
-                "systemId": {
-                    "description": "SystemID by CloudOS",
-                    "type": "string"
+                "lastCollected": {
+                    "type": "string",
+                    "example": "2024-03-01T12:00:00Z"
+                },
+                "maxSamples": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
                 }
             }
         },
-        "common.IdList": {
+        "mcis.AgentlessMonitoringReq": {
             "type": "object",
             "properties": {
-                "output": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "intervalSec": {
+                    "description": "IntervalSec is the sampling interval in seconds (min 10, default 60)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "maxSamples": {
+                    "description": "MaxSamples is the number of samples to keep for each VM and metric (default 360)",
+                    "type": "integer",
+                    "example": 360
+                },
+                "userName": {
+                    "description": "UserName is the SSH user name for VMs (optional, the VM default user will be used)",
+                    "type": "string",
+                    "example": "cb-user"
                 }
             }
         },
-        "common.JSONResult": {
-            "type": "object"
-        },
-        "common.K8sClusterDetail": {
+        "mcis.AutoAction": {
             "type": "object",
             "properties": {
-                "node_images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.K8sClusterNodeImageDetail"
-                    }
+                "actionType": {
+                    "type": "string",
+                    "enum": [
+                        "ScaleOut",
+                        "ScaleIn"
+                    ],
+                    "example": "ScaleOut"
                 },
-                "nodegroups_with_cluster": {
-                    "type": "boolean"
+                "placementAlgo": {
+                    "type": "string",
+                    "example": "random"
                 },
-                "root_disks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.K8sClusterRootDiskDetail"
-                    }
+                "postCommand": {
+                    "description": "PostCommand is field for providing command to VMs after its creation. example:\"wget https://raw.githubusercontent.com/cloud-barista/cb-tumblebug/main/scripts/setweb.sh -O ~/setweb.sh; chmod +x ~/setweb.sh; sudo ~/setweb.sh\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.McisCmdReq"
+                        }
+                    ]
                 },
-                "versions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.K8sClusterVersionDetail"
-                    }
-                }
-            }
-        },
-        "common.K8sClusterInfo": {
-            "type": "object",
-            "properties": {
-                "k8s_cluster": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/common.K8sClusterDetail"
-                    }
+                "vmDynamicReq": {
+                    "$ref": "#/definitions/mcis.TbVmDynamicReq"
                 }
             }
         },
-        "common.K8sClusterNodeImageDetail": {
+        "mcis.AutoCondition": {
             "type": "object",
             "properties": {
-                "availables": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.K8sClusterNodeImageDetailAvailable"
-                    }
+                "evaluationPeriod": {
+                    "type": "string",
+                    "example": "10"
                 },
-                "region": {
+                "evaluationValue": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "metric": {
+                    "type": "string",
+                    "example": "cpu"
+                },
+                "operand": {
+                    "type": "string",
+                    "example": "80"
+                },
+                "operator": {
+                    "type": "string",
+                    "enum": [
+                        "\u003c",
+                        "\u003c=",
+                        "\u003e",
+                        "\u003e="
+                    ],
+                    "example": "\u003e="
                 }
             }
         },
-        "common.K8sClusterNodeImageDetailAvailable": {
+        "mcis.BenchmarkInfo": {
             "type": "object",
             "properties": {
-                "id": {
+                "desc": {
                     "type": "string"
                 },
-                "name": {
+                "elapsed": {
                     "type": "string"
-                }
-            }
-        },
-        "common.K8sClusterRootDiskDetail": {
-            "type": "object",
-            "properties": {
-                "region": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 },
-                "size": {
-                    "$ref": "#/definitions/common.K8sClusterRootDiskDetailSize"
+                "regionName": {
+                    "type": "string"
                 },
-                "type": {
+                "result": {
+                    "type": "string"
+                },
+                "resultarray": {
+                    "description": "struct-element cycle ?",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.K8sClusterRootDiskDetailType"
+                        "$ref": "#/definitions/mcis.BenchmarkInfo"
                     }
+                },
+                "specid": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "common.K8sClusterRootDiskDetailSize": {
+        "mcis.BenchmarkInfoArray": {
             "type": "object",
             "properties": {
-                "max": {
-                    "type": "integer"
-                },
-                "min": {
-                    "type": "integer"
+                "resultarray": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.BenchmarkInfo"
+                    }
                 }
             }
         },
-        "common.K8sClusterRootDiskDetailType": {
+        "mcis.BenchmarkSuite": {
             "type": "object",
+            "required": [
+                "metrics",
+                "name",
+                "runCommand",
+                "type"
+            ],
             "properties": {
-                "id": {
-                    "type": "string"
+                "builtIn": {
+                    "description": "BuiltIn is true for suites shipped with CB-Tumblebug (cannot be changed or deleted)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "description": {
+                    "type": "string",
+                    "example": "CPU benchmark by sysbench (events per second with all cores)"
+                },
+                "installCommand": {
+                    "description": "InstallCommand is executed in each VM before running the suite (should be idempotent)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "command -v sysbench || sudo apt-get install -y sysbench"
+                    ]
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.BenchmarkSuiteMetric"
+                    }
                 },
                 "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "common.K8sClusterVersionDetail": {
-            "type": "object",
-            "properties": {
-                "availables": {
+                    "type": "string",
+                    "example": "sysbench-cpu"
+                },
+                "runCommand": {
+                    "description": "RunCommand is executed to run the benchmark, and the output of the last command is parsed.\nBuilt-in functions of remote commands ($$Func) are available, and {{targetIp}}, {{targetPublicIp}},\n{{targetPrivateIp}}, {{targetVmId}} are replaced with those of the target VM in a pair suite\n({{targetIp}} is the private IP if the VMs are in the same vNet, the public IP otherwise).",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.K8sClusterVersionDetailAvailable"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "sysbench cpu --threads=$(nproc) --time=10 run"
+                    ]
                 },
-                "region": {
+                "serverCommand": {
+                    "description": "ServerCommand is executed in each target VM before running a pair suite (e.g., starting a server in background)",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
-                }
-            }
-        },
-        "common.K8sClusterVersionDetailAvailable": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
+                    },
+                    "example": [
+                        "pgrep -x iperf3 || iperf3 -s -D"
+                    ]
                 },
-                "name": {
-                    "type": "string"
+                "timeoutSec": {
+                    "description": "TimeoutSec is the timeout of each command in seconds",
+                    "type": "integer",
+                    "default": 600,
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "vm",
+                        "pair"
+                    ],
+                    "example": "vm"
                 }
             }
         },
-        "common.KeyValue": {
+        "mcis.BenchmarkSuiteList": {
             "type": "object",
             "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "string"
+                "benchmarkSuite": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.BenchmarkSuite"
+                    }
                 }
             }
         },
-        "common.Location": {
+        "mcis.BenchmarkSuiteMetric": {
             "type": "object",
+            "required": [
+                "name",
+                "pattern"
+            ],
             "properties": {
-                "display": {
-                    "type": "string"
+                "lowerIsBetter": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "latitude": {
-                    "type": "number"
+                "name": {
+                    "type": "string",
+                    "example": "eventsPerSec"
                 },
-                "longitude": {
-                    "type": "number"
+                "pattern": {
+                    "description": "Pattern is the regular expression to get the value (the first capture group) from the output",
+                    "type": "string",
+                    "example": "events per second:\\s*([0-9.]+)"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "events/sec"
                 }
             }
         },
-        "common.NsInfo": {
+        "mcis.BenchmarkSuiteMetricResult": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Description for this namespace"
+                "lowerIsBetter": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "id": {
+                "name": {
                     "type": "string",
-                    "example": "ns01"
+                    "example": "eventsPerSec"
                 },
-                "name": {
+                "unit": {
                     "type": "string",
-                    "example": "ns01"
+                    "example": "events/sec"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 1234.5
                 }
             }
         },
-        "common.NsReq": {
+        "mcis.BenchmarkSuiteResult": {
             "type": "object",
             "properties": {
-                "description": {
+                "elapsedSec": {
+                    "type": "number",
+                    "example": 35.2
+                },
+                "mcisId": {
                     "type": "string",
-                    "example": "Description for this namespace"
+                    "example": "mcis01"
                 },
-                "name": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.BenchmarkSuiteVmResult"
+                    }
+                },
+                "startedAt": {
                     "type": "string",
-                    "example": "ns01"
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "suite": {
+                    "type": "string",
+                    "example": "sysbench-cpu"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "vm"
                 }
             }
         },
-        "common.RegionDetail": {
+        "mcis.BenchmarkSuiteRunReq": {
             "type": "object",
+            "required": [
+                "suite"
+            ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "skipInstall": {
+                    "description": "SkipInstall is to skip the install command (if the tools are already installed)",
+                    "type": "boolean",
+                    "example": false
                 },
-                "location": {
-                    "$ref": "#/definitions/common.Location"
+                "subGroupId": {
+                    "description": "SubGroupId is to run the suite only in VMs of the subGroup (all VMs in MCIS if omitted)",
+                    "type": "string",
+                    "example": "g1"
                 },
-                "regionId": {
-                    "type": "string"
+                "suite": {
+                    "type": "string",
+                    "example": "sysbench-cpu"
                 },
-                "regionName": {
-                    "type": "string"
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
                 },
-                "zones": {
+                "vmIds": {
+                    "description": "VmIds is to run the suite only in the VMs (all VMs in MCIS if omitted)",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -9321,2219 +14817,2579 @@ const docTemplate = `{
                 }
             }
         },
-        "common.RegionList": {
+        "mcis.BenchmarkSuiteVmResult": {
             "type": "object",
             "properties": {
-                "region": {
+                "elapsedSec": {
+                    "type": "number",
+                    "example": 10.5
+                },
+                "error": {
+                    "type": "string"
+                },
+                "metrics": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.SpiderRegionZoneInfo"
+                        "$ref": "#/definitions/mcis.BenchmarkSuiteMetricResult"
                     }
-                }
-            }
-        },
-        "common.RegionZoneInfo": {
-            "type": "object",
-            "properties": {
-                "assignedRegion": {
-                    "type": "string"
-                },
-                "assignedZone": {
-                    "type": "string"
-                }
-            }
-        },
-        "common.RequestDetails": {
-            "type": "object",
-            "properties": {
-                "endTime": {
-                    "description": "The time when the request was fully processed.",
-                    "type": "string"
                 },
-                "errorResponse": {
-                    "description": "A message describing any error that occurred during request processing.",
+                "output": {
+                    "description": "Output is the (tail of) output of the last run command",
                     "type": "string"
                 },
-                "requestInfo": {
-                    "description": "Extracted information about the request.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/common.RequestInfo"
-                        }
-                    ]
-                },
-                "responseData": {
-                    "description": "The data sent back in response to the request."
+                "specId": {
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
                 },
-                "startTime": {
-                    "description": "The time when the request was received by the server.",
-                    "type": "string"
+                "targetVmId": {
+                    "description": "TargetVmId is the target VM of a pair suite",
+                    "type": "string",
+                    "example": "g2-1"
                 },
-                "status": {
-                    "description": "The current status of the request (e.g., \"Handling\", \"Error\", \"Success\").",
-                    "type": "string"
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
                 }
             }
         },
-        "common.RequestInfo": {
+        "mcis.CarbonIntensityReq": {
             "type": "object",
+            "required": [
+                "providerName",
+                "regionName"
+            ],
             "properties": {
-                "body": {
-                    "description": "Optional: request body"
-                },
-                "header": {
-                    "description": "Key-value pairs of the request headers.",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "intensity": {
+                    "type": "number",
+                    "example": 10
                 },
-                "method": {
-                    "description": "HTTP method (GET, POST, etc.), indicating the request's action type.",
-                    "type": "string"
+                "providerName": {
+                    "type": "string",
+                    "example": "aws"
                 },
-                "url": {
-                    "description": "The URL the request is made to.",
-                    "type": "string"
+                "regionName": {
+                    "type": "string",
+                    "example": "eu-north-1"
                 }
             }
         },
-        "common.RestGetAllConfigResponse": {
+        "mcis.CarbonIntensityUpdateReq": {
             "type": "object",
+            "required": [
+                "carbonIntensity"
+            ],
             "properties": {
-                "config": {
-                    "description": "Name string     ` + "`" + `json:\"name\"` + "`" + `",
+                "carbonIntensity": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.ConfigInfo"
+                        "$ref": "#/definitions/mcis.CarbonIntensityReq"
                     }
                 }
             }
         },
-        "common.RestGetAllNsResponse": {
+        "mcis.CheckMcisDynamicReqInfo": {
             "type": "object",
+            "required": [
+                "reqCheck"
+            ],
             "properties": {
-                "ns": {
-                    "description": "Name string     ` + "`" + `json:\"name\"` + "`" + `",
+                "reqCheck": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.NsInfo"
+                        "$ref": "#/definitions/mcis.CheckVmDynamicReqInfo"
                     }
                 }
             }
         },
-        "common.RestInspectResourcesRequest": {
+        "mcis.CheckVmDynamicReqInfo": {
             "type": "object",
             "properties": {
-                "connectionName": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1"
+                "connectionConfigCandidates": {
+                    "description": "ConnectionConfigCandidates will provide ConnectionConfig options",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "resourceType": {
-                    "type": "string",
-                    "enum": [
-                        "vNet",
-                        "securityGroup",
-                        "sshKey",
-                        "vm"
-                    ],
-                    "example": "vNet"
-                }
-            }
-        },
-        "common.RestRegisterCspNativeResourcesRequest": {
-            "type": "object",
-            "properties": {
-                "connectionName": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1"
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcir.TbImageInfo"
+                    }
                 },
-                "mcisName": {
-                    "type": "string",
-                    "example": "csp"
+                "region": {
+                    "$ref": "#/definitions/common.RegionDetail"
                 },
-                "nsId": {
+                "spec": {
+                    "$ref": "#/definitions/mcir.TbSpecInfo"
+                },
+                "systemMessage": {
+                    "description": "Latest system message such as error message",
                     "type": "string",
-                    "example": "ns01"
+                    "example": "Failed because ..."
                 }
             }
         },
-        "common.RestRegisterCspNativeResourcesRequestAll": {
+        "mcis.CriterionScore": {
             "type": "object",
             "properties": {
-                "mcisName": {
+                "metric": {
                     "type": "string",
-                    "example": "csp"
+                    "example": "cost"
                 },
-                "nsId": {
-                    "type": "string",
-                    "example": "ns01"
+                "normalizedScore": {
+                    "description": "NormalizedScore is the score normalized into [0, 1] among the filtered specs (1 is the best)",
+                    "type": "number",
+                    "example": 0.95
+                },
+                "value": {
+                    "description": "Value is the evaluated value of the spec in the criterion (e.g., cost per hour, distance in km, sum of latencies in ms, gCO2eq per hour)",
+                    "type": "number",
+                    "example": 0.0116
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "weightedScore": {
+                    "description": "WeightedScore is NormalizedScore * Weight",
+                    "type": "number",
+                    "example": 0.285
                 }
             }
         },
-        "common.SimpleMsg": {
+        "mcis.DeploymentPlan": {
             "type": "object",
             "properties": {
-                "message": {
+                "filter": {
+                    "$ref": "#/definitions/mcis.FilterInfo"
+                },
+                "limit": {
                     "type": "string",
-                    "example": "Any message"
+                    "enum": [
+                        "1",
+                        "2",
+                        "30"
+                    ],
+                    "example": "5"
+                },
+                "priority": {
+                    "$ref": "#/definitions/mcis.PriorityInfo"
                 }
             }
         },
-        "common.SpiderRegionZoneInfo": {
+        "mcis.FilterCondition": {
             "type": "object",
             "properties": {
-                "availableZoneList": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "keyValueInfoList": {
-                    "description": "ex) { {region, us-east1}, {zone, us-east1-c} }",
+                "condition": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.Operation"
                     }
                 },
-                "providerName": {
-                    "description": "ex) \"GCP\"",
-                    "type": "string"
-                },
-                "regionName": {
-                    "description": "ex) \"region01\"",
-                    "type": "string"
+                "metric": {
+                    "type": "string",
+                    "enum": [
+                        "vCPU",
+                        "memoryGiB",
+                        "costPerHour",
+                        "availabilityScore"
+                    ],
+                    "example": "vCPU"
                 }
             }
         },
-        "common.TbConnectionName": {
+        "mcis.FilterInfo": {
             "type": "object",
             "properties": {
-                "connectionName": {
-                    "type": "string"
+                "policy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.FilterCondition"
+                    }
                 }
             }
         },
-        "mcir.BastionNode": {
+        "mcis.InspectResource": {
             "type": "object",
             "properties": {
-                "mcisId": {
+                "connectionName": {
                     "type": "string"
                 },
-                "vmId": {
+                "resourceOverview": {
+                    "$ref": "#/definitions/mcis.resourceCountOverview"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "resources": {
+                    "$ref": "#/definitions/mcis.resourcesByManageType"
+                },
+                "systemMessage": {
                     "type": "string"
                 }
             }
         },
-        "mcir.CustomImageStatus": {
-            "type": "string",
-            "enum": [
-                "Available",
-                "Unavailable"
-            ],
-            "x-enum-varnames": [
-                "MyImageAvailable",
-                "MyImageUnavailable"
-            ]
-        },
-        "mcir.DiskStatus": {
-            "type": "string",
-            "enum": [
-                "Creating",
-                "Available",
-                "Attached",
-                "Deleting",
-                "Error"
-            ],
-            "x-enum-varnames": [
-                "DiskCreating",
-                "DiskAvailable",
-                "DiskAttached",
-                "DiskDeleting",
-                "DiskError"
-            ]
-        },
-        "mcir.FilterSpecsByRangeRequest": {
+        "mcis.InspectResourceAllResult": {
             "type": "object",
             "properties": {
-                "acceleratorCount": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "acceleratorMemoryGB": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "acceleratorModel": {
-                    "type": "string"
-                },
-                "acceleratorType": {
-                    "type": "string"
-                },
-                "connectionName": {
-                    "type": "string"
-                },
-                "costPerHour": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "cspSpecName": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "evaluationScore01": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore02": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore03": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore04": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore05": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore06": {
-                    "$ref": "#/definitions/mcir.Range"
-                },
-                "evaluationScore07": {
-                    "$ref": "#/definitions/mcir.Range"
+                "availableConnection": {
+                    "type": "integer"
                 },
-                "evaluationScore08": {
-                    "$ref": "#/definitions/mcir.Range"
+                "cspOnlyOverview": {
+                    "$ref": "#/definitions/mcis.inspectOverview"
                 },
-                "evaluationScore09": {
-                    "$ref": "#/definitions/mcir.Range"
+                "elapsedTime": {
+                    "type": "integer"
                 },
-                "evaluationScore10": {
-                    "$ref": "#/definitions/mcir.Range"
+                "inspectResult": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.InspectResourceResult"
+                    }
                 },
-                "evaluationStatus": {
-                    "type": "string"
+                "registeredConnection": {
+                    "type": "integer"
                 },
-                "id": {
+                "tumblebugOverview": {
+                    "$ref": "#/definitions/mcis.inspectOverview"
+                }
+            }
+        },
+        "mcis.InspectResourceResult": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
                     "type": "string"
                 },
-                "maxTotalStorageTiB": {
-                    "$ref": "#/definitions/mcir.Range"
+                "cspOnlyOverview": {
+                    "$ref": "#/definitions/mcis.inspectOverview"
                 },
-                "memoryGiB": {
-                    "$ref": "#/definitions/mcir.Range"
+                "elapsedTime": {
+                    "type": "integer"
                 },
-                "name": {
+                "systemMessage": {
                     "type": "string"
                 },
-                "netBwGbps": {
-                    "$ref": "#/definitions/mcir.Range"
+                "tumblebugOverview": {
+                    "$ref": "#/definitions/mcis.inspectOverview"
+                }
+            }
+        },
+        "mcis.JSONResult": {
+            "type": "object"
+        },
+        "mcis.LatencyImportReq": {
+            "type": "object",
+            "required": [
+                "latency"
+            ],
+            "properties": {
+                "latency": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.LatencyReq"
+                    }
+                }
+            }
+        },
+        "mcis.LatencyInfo": {
+            "type": "object",
+            "properties": {
+                "dest": {
+                    "type": "string",
+                    "example": "gcp-asia-northeast3"
                 },
-                "osType": {
-                    "type": "string"
+                "latencyMs": {
+                    "type": "number",
+                    "example": 5.3
                 },
-                "providerName": {
-                    "type": "string"
+                "measuredAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "regionName": {
-                    "type": "string"
+                "sampleCount": {
+                    "description": "SampleCount is the number of samples measured (the latency is the moving average of recent samples)",
+                    "type": "integer",
+                    "example": 3
                 },
-                "storageGiB": {
-                    "$ref": "#/definitions/mcir.Range"
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "benchmark",
+                        "import",
+                        "static"
+                    ],
+                    "example": "benchmark"
                 },
-                "vCPU": {
-                    "$ref": "#/definitions/mcir.Range"
+                "src": {
+                    "description": "Src and Dest are regions in the form of {providerName}-{regionName} (latency is symmetric)",
+                    "type": "string",
+                    "example": "aws-ap-northeast-2"
                 }
             }
         },
-        "mcir.JSONResult": {
-            "type": "object"
+        "mcis.LatencyInfoList": {
+            "type": "object",
+            "properties": {
+                "latency": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.LatencyInfo"
+                    }
+                }
+            }
         },
-        "mcir.Range": {
+        "mcis.LatencyReq": {
             "type": "object",
+            "required": [
+                "dest",
+                "src"
+            ],
             "properties": {
-                "max": {
-                    "type": "number"
+                "dest": {
+                    "type": "string",
+                    "example": "gcp-asia-northeast3"
                 },
-                "min": {
-                    "type": "number"
+                "latencyMs": {
+                    "type": "number",
+                    "example": 5.3
+                },
+                "measuredAt": {
+                    "description": "MeasuredAt is the time of measurement (now if omitted)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "src": {
+                    "type": "string",
+                    "example": "aws-ap-northeast-2"
                 }
             }
         },
-        "mcir.RestFilterSpecsResponse": {
+        "mcis.McNlbInfo": {
             "type": "object",
             "properties": {
-                "spec": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcir.TbSpecInfo"
-                    }
+                "deploymentLog": {
+                    "$ref": "#/definitions/mcis.McisSshCmdResult"
+                },
+                "mcNlbHostInfo": {
+                    "$ref": "#/definitions/mcis.TbMcisInfo"
+                },
+                "mcisAccessInfo": {
+                    "$ref": "#/definitions/mcis.McisAccessInfo"
                 }
             }
         },
-        "mcir.RestGetAllCustomImageResponse": {
+        "mcis.McisAccessInfo": {
             "type": "object",
             "properties": {
-                "customImage": {
+                "mcisId": {
+                    "type": "string"
+                },
+                "mcisNlbListener": {
+                    "$ref": "#/definitions/mcis.McisAccessInfo"
+                },
+                "mcisSubGroupAccessInfo": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbCustomImageInfo"
+                        "$ref": "#/definitions/mcis.McisSubGroupAccessInfo"
                     }
                 }
             }
         },
-        "mcir.RestGetAllDataDiskResponse": {
+        "mcis.McisCmdDryRunResult": {
             "type": "object",
             "properties": {
-                "dataDisk": {
+                "command": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbDataDiskInfo"
+                        "type": "string"
                     }
+                },
+                "vmId": {
+                    "type": "string"
+                },
+                "vmIndex": {
+                    "type": "integer"
                 }
             }
         },
-        "mcir.RestGetAllImageResponse": {
+        "mcis.McisCmdDryRunResults": {
             "type": "object",
             "properties": {
-                "image": {
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbImageInfo"
+                        "$ref": "#/definitions/mcis.McisCmdDryRunResult"
                     }
                 }
             }
         },
-        "mcir.RestGetAllSecurityGroupResponse": {
+        "mcis.McisCmdJobInfo": {
             "type": "object",
             "properties": {
-                "securityGroup": {
+                "command": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbSecurityGroupInfo"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "mcir.RestGetAllSpecResponse": {
-            "type": "object",
-            "properties": {
-                "spec": {
+                },
+                "createdTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "finishedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:01:00Z"
+                },
+                "jobId": {
+                    "type": "string",
+                    "example": "cq2a3b4c5d6e7f8g9h0i"
+                },
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "option": {
+                    "$ref": "#/definitions/mcis.SshCmdOption"
+                },
+                "results": {
+                    "description": "Results is available when the job is finished",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbSpecInfo"
+                        "$ref": "#/definitions/mcis.SshCmdResult"
                     }
-                }
-            }
-        },
-        "mcir.RestGetAllSshKeyResponse": {
-            "type": "object",
-            "properties": {
-                "sshKey": {
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Running"
+                },
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "targetVmList": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbSshKeyInfo"
+                        "type": "string"
+                    }
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
+                },
+                "vmErr": {
+                    "description": "VmErr is error message for each VM (SshCmdResult.Err is not kept in the persisted results)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
+                },
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
                 }
             }
         },
-        "mcir.RestGetAllVNetResponse": {
+        "mcis.McisCmdJobInfoList": {
             "type": "object",
             "properties": {
-                "vNet": {
+                "jobs": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbVNetInfo"
+                        "$ref": "#/definitions/mcis.McisCmdJobInfo"
                     }
                 }
             }
         },
-        "mcir.RestLookupImageRequest": {
+        "mcis.McisCmdJobOutput": {
             "type": "object",
             "properties": {
-                "connectionName": {
+                "cmdIndex": {
+                    "type": "integer"
+                },
+                "jobId": {
                     "type": "string"
                 },
-                "cspImageId": {
+                "line": {
+                    "type": "string"
+                },
+                "stream": {
+                    "type": "string",
+                    "enum": [
+                        "stdout",
+                        "stderr",
+                        "vmDone",
+                        "jobDone"
+                    ]
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "vmId": {
                     "type": "string"
                 }
             }
         },
-        "mcir.RestLookupSpecRequest": {
+        "mcis.McisCmdReq": {
             "type": "object",
+            "required": [
+                "command"
+            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "client_ip=$(echo $SSH_CLIENT | awk '{print $1}'); echo SSH client IP is: $client_ip"
+                    ]
                 },
-                "cspSpecName": {
-                    "type": "string"
+                "continueOnError": {
+                    "description": "ContinueOnError executes the remaining commands after a failed command (stops at the first failure by default)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "env": {
+                    "description": "Env is environment variables for the commands",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "retry": {
+                    "description": "Retry is the number of retries of a failed command",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 10,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "retryIntervalSec": {
+                    "description": "RetryIntervalSec is the interval between retries in seconds",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 3600,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "sudo": {
+                    "description": "Sudo executes the commands with sudo (requires passwordless sudo)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "timeoutSec": {
+                    "description": "TimeoutSec is the timeout of each command in seconds (0: no timeout)",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
+                },
+                "workDir": {
+                    "description": "WorkDir is the working directory for the commands",
+                    "type": "string",
+                    "example": "/home/cb-user"
                 }
             }
         },
-        "mcir.RestSearchImageRequest": {
+        "mcis.McisConnectionConfigCandidatesReq": {
             "type": "object",
+            "required": [
+                "commonSpec"
+            ],
             "properties": {
-                "keywords": {
+                "commonSpec": {
+                    "description": "CommonSpec is field for id of a spec in common namespace",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
+                    },
+                    "example": [
+                        "aws+ap-northeast-2+t2.small",
+                        "gcp+us-west1+g1-small"
+                    ]
                 }
             }
         },
-        "mcir.SpiderGpuInfo": {
+        "mcis.McisFileTransferResult": {
             "type": "object",
             "properties": {
-                "count": {
+                "checksum": {
+                    "description": "Checksum is SHA-256 of the transferred content (calculated by CB-Tumblebug)",
                     "type": "string"
                 },
-                "mem": {
+                "error": {
                     "type": "string"
                 },
-                "mfr": {
+                "mcisId": {
                     "type": "string"
                 },
-                "model": {
-                    "type": "string"
-                }
-            }
-        },
-        "mcir.SpiderImageInfo": {
-            "type": "object",
-            "properties": {
-                "guestOS": {
-                    "description": "Windows7, Ubuntu etc.",
+                "path": {
+                    "type": "string",
+                    "example": "/home/cb-user/app.conf"
+                },
+                "remoteChecksum": {
+                    "description": "RemoteChecksum is SHA-256 of the file on the VM (calculated by sha256sum in the VM)",
                     "type": "string"
                 },
-                "iid": {
-                    "description": "Fields for response",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/common.IID"
-                        }
-                    ]
+                "size": {
+                    "type": "integer",
+                    "example": 1024
                 },
-                "keyValueList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.KeyValue"
-                    }
+                "verified": {
+                    "description": "Verified is true if Checksum and RemoteChecksum are the same",
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "Fields for request",
+                "vmId": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "available, unavailable",
+                "vmIp": {
                     "type": "string"
                 }
             }
         },
-        "mcir.SpiderImageList": {
+        "mcis.McisFileTransferResults": {
             "type": "object",
             "properties": {
-                "image": {
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.SpiderImageInfo"
+                        "$ref": "#/definitions/mcis.McisFileTransferResult"
                     }
                 }
             }
         },
-        "mcir.SpiderSpecInfo": {
+        "mcis.McisPlacementReq": {
             "type": "object",
+            "required": [
+                "name",
+                "subGroups"
+            ],
             "properties": {
-                "gpu": {
+                "constraint": {
+                    "$ref": "#/definitions/mcis.PlacementConstraint"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Made in CB-TB"
+                },
+                "installMonAgent": {
+                    "type": "string",
+                    "default": "no",
+                    "enum": [
+                        "yes",
+                        "no"
+                    ],
+                    "example": "no"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "DynamicVM"
+                },
+                "name": {
+                    "description": "Name is the name of MCIS to be used in the generated TbMcisDynamicReq",
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "subGroups": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.SpiderGpuInfo"
+                        "$ref": "#/definitions/mcis.PlacementSubGroupReq"
                     }
+                }
+            }
+        },
+        "mcis.McisPlacementResult": {
+            "type": "object",
+            "properties": {
+                "distinctProviders": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "maxLatencyMs": {
+                    "type": "number",
+                    "example": 32
+                },
+                "mcisDynamicReq": {
+                    "description": "McisDynamicReq is the request to create MCIS with the placement (for POST /ns/{nsId}/mcisDynamic)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.TbMcisDynamicReq"
+                        }
+                    ]
                 },
-                "keyValueList": {
+                "optimal": {
+                    "description": "Optimal is false if the search stopped at the limit before exploring all the candidates,\nor if candidate specs of subGroups were pruned to limit the search space",
+                    "type": "boolean",
+                    "example": true
+                },
+                "placement": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.SubGroupPlacement"
                     }
                 },
-                "mem": {
+                "score": {
+                    "description": "Score is the average score of subGroups weighted by subGroupSize",
+                    "type": "number",
+                    "example": 0.85
+                },
+                "totalCostPerHour": {
+                    "type": "number",
+                    "example": 0.35
+                }
+            }
+        },
+        "mcis.McisPolicyInfo": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "description": "MCIS Id (generated ID by the Name)",
                     "type": "string"
                 },
-                "name": {
+                "Name": {
+                    "description": "MCIS Name (for request)",
                     "type": "string"
                 },
-                "region": {
+                "actionLog": {
                     "type": "string"
                 },
-                "vcpu": {
-                    "$ref": "#/definitions/mcir.SpiderVCpuInfo"
+                "description": {
+                    "type": "string",
+                    "example": "Description"
+                },
+                "policy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.Policy"
+                    }
                 }
             }
         },
-        "mcir.SpiderSpecList": {
+        "mcis.McisPolicyReq": {
             "type": "object",
             "properties": {
-                "vmspec": {
+                "description": {
+                    "type": "string",
+                    "example": "Description"
+                },
+                "policy": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.SpiderSpecInfo"
+                        "$ref": "#/definitions/mcis.Policy"
                     }
                 }
             }
         },
-        "mcir.SpiderVCpuInfo": {
+        "mcis.McisPolicySimulationMetric": {
             "type": "object",
             "properties": {
-                "clock": {
-                    "description": "GHz",
-                    "type": "string"
+                "metric": {
+                    "description": "Metric is the name of the metric (should match autoCondition.metric of the policy)",
+                    "type": "string",
+                    "example": "cpu"
                 },
-                "count": {
-                    "type": "string"
+                "values": {
+                    "description": "Values is the MCIS average value of the metric for each controller tick (synthetic or recorded)",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        10,
+                        35,
+                        82,
+                        91,
+                        88,
+                        40
+                    ]
                 }
             }
         },
-        "mcir.TbAttachDetachDataDiskReq": {
+        "mcis.McisPolicySimulationReq": {
             "type": "object",
-            "required": [
-                "dataDiskId"
-            ],
             "properties": {
-                "dataDiskId": {
-                    "type": "string"
+                "mcisPolicyReq": {
+                    "$ref": "#/definitions/mcis.McisPolicyReq"
+                },
+                "metricSeries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.McisPolicySimulationMetric"
+                    }
                 }
             }
         },
-        "mcir.TbCustomImageInfo": {
+        "mcis.McisPolicySimulationResult": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "actionCount": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "connectionName": {
+                "mcisId": {
                     "type": "string",
-                    "example": "aws-ap-southeast-1"
+                    "example": "mcis01"
                 },
-                "creationDate": {
+                "nsId": {
                     "type": "string",
-                    "example": "2022-10-18T08:12:48Z"
+                    "example": "ns01"
                 },
-                "cspCustomImageId": {
-                    "type": "string",
-                    "example": "ami-06eb41e14121c550a"
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.McisPolicySimulationStep"
+                    }
                 },
-                "cspCustomImageName": {
+                "totalTicks": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "mcis.McisPolicySimulationStep": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the action the controller would take in this tick (not executed)",
                     "type": "string",
-                    "example": "aws-ap-southeast-1-snapshot"
+                    "example": "ScaleOut"
                 },
-                "description": {
+                "actionDetail": {
                     "type": "string"
                 },
-                "guestOS": {
-                    "description": "Windows7, Ubuntu etc.",
-                    "type": "string"
+                "evaluatedAverage": {
+                    "description": "EvaluatedAverage is the average for the evaluation period (empty if not enough values)",
+                    "type": "string",
+                    "example": "87.666667"
                 },
-                "id": {
+                "metric": {
                     "type": "string",
-                    "example": "aws-ap-southeast-1-snapshot"
+                    "example": "cpu"
                 },
-                "isAutoGenerated": {
-                    "type": "boolean"
+                "policyIndex": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "keyValueList": {
+                "statusFlow": {
+                    "description": "StatusFlow is the sequence of statuses the policy went through in this tick",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
-                    }
-                },
-                "name": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1-snapshot"
-                },
-                "namespace": {
-                    "description": "required to save in RDB",
-                    "type": "string",
-                    "example": "ns01"
-                },
-                "sourceVmId": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1-1"
+                        "type": "string"
+                    },
+                    "example": [
+                        "Ready",
+                        "Checking",
+                        "Detected"
+                    ]
                 },
-                "status": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/mcir.CustomImageStatus"
-                        }
-                    ],
-                    "example": "Available"
+                "tick": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                "value": {
+                    "description": "Value is the metric value consumed in this tick (empty if the policy was not measuring)",
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
+                    "example": "91.000000"
                 }
             }
         },
-        "mcir.TbCustomImageReq": {
+        "mcis.McisSshCmdResult": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspCustomImageId": {
-                    "description": "This field is for 'Register existing custom image'",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "sourceVmId": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.SshCmdResult"
+                    }
                 }
             }
         },
-        "mcir.TbDataDiskInfo": {
+        "mcis.McisStatusInfo": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "connectionName": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1"
-                },
-                "createdTime": {
-                    "type": "string",
-                    "example": "2022-10-12T05:09:51.05Z"
-                },
-                "cspDataDiskId": {
-                    "type": "string",
-                    "example": "vol-0d397c3239629bd43"
+                "id": {
+                    "type": "string"
                 },
-                "cspDataDiskName": {
+                "installMonAgent": {
+                    "description": "InstallMonAgent Option for CB-Dragonfly agent installation ([yes/no] default:yes)",
                     "type": "string",
-                    "example": "ns01-aws-ap-southeast-1-datadisk"
+                    "example": "[yes, no]"
                 },
-                "description": {
+                "label": {
+                    "description": "Label is for describing the mcis in a keyword (any string can be used)",
                     "type": "string",
-                    "example": "Available"
+                    "example": "User custom label"
                 },
-                "diskSize": {
+                "masterIp": {
                     "type": "string",
-                    "example": "77"
+                    "example": "32.201.134.113"
                 },
-                "diskType": {
-                    "type": "string",
-                    "example": "standard"
+                "masterSSHPort": {
+                    "type": "string"
                 },
-                "id": {
+                "masterVmId": {
                     "type": "string",
-                    "example": "aws-ap-southeast-1-datadisk"
-                },
-                "isAutoGenerated": {
-                    "type": "boolean"
-                },
-                "keyValueList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.KeyValue"
-                    }
+                    "example": "vm-asiaeast1-cb-01"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1-datadisk"
-                },
-                "status": {
-                    "description": "Available, Unavailable, Attached, ...",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/mcir.DiskStatus"
-                        }
-                    ],
-                    "example": "Available"
-                },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
-                    "type": "string",
-                    "example": "Managed by CB-Tumblebug"
-                },
-                "systemMessage": {
-                    "description": "Latest system message such as error message",
-                    "type": "string",
-                    "example": "Failed because ..."
-                }
-            }
-        },
-        "mcir.TbDataDiskReq": {
-            "type": "object",
-            "required": [
-                "connectionName",
-                "diskSize",
-                "name"
-            ],
-            "properties": {
-                "connectionName": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1"
-                },
-                "cspDataDiskId": {
-                    "description": "Fields for \"Register existing dataDisk\" feature\nCspDataDiskId is required to register object from CSP (option=register)",
                     "type": "string"
                 },
-                "description": {
+                "status": {
                     "type": "string"
                 },
-                "diskSize": {
-                    "type": "string",
-                    "default": "100",
-                    "example": "77"
+                "statusCount": {
+                    "$ref": "#/definitions/mcis.StatusCountInfo"
                 },
-                "diskType": {
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the mcis in a keyword (any string can be used) for special System purpose",
                     "type": "string",
-                    "example": "default"
+                    "example": "Managed by CB-Tumblebug"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1-datadisk"
+                "targetAction": {
+                    "type": "string"
+                },
+                "targetStatus": {
+                    "type": "string"
+                },
+                "vm": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.TbVmStatusInfo"
+                    }
                 }
             }
         },
-        "mcir.TbDataDiskUpsizeReq": {
+        "mcis.McisSubGroupAccessInfo": {
             "type": "object",
-            "required": [
-                "diskSize"
-            ],
             "properties": {
-                "description": {
+                "bastionVmId": {
                     "type": "string"
                 },
-                "diskSize": {
+                "mcisVmAccessInfo": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.McisVmAccessInfo"
+                    }
+                },
+                "nlbListener": {
+                    "$ref": "#/definitions/mcis.TbNLBListenerInfo"
+                },
+                "subGroupId": {
                     "type": "string"
                 }
             }
         },
-        "mcir.TbDataDiskVmReq": {
+        "mcis.McisVmAccessInfo": {
             "type": "object",
-            "required": [
-                "diskSize",
-                "name"
-            ],
             "properties": {
-                "description": {
+                "privateIP": {
                     "type": "string"
                 },
-                "diskSize": {
-                    "type": "string",
-                    "default": "100",
-                    "example": "77"
+                "privateKey": {
+                    "type": "string"
                 },
-                "diskType": {
-                    "type": "string",
-                    "example": "default"
+                "publicIP": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "aws-ap-southeast-1-datadisk"
+                "sshPort": {
+                    "type": "string"
+                },
+                "vmId": {
+                    "type": "string"
+                },
+                "vmUserAccount": {
+                    "type": "string"
+                },
+                "vmUserPassword": {
+                    "type": "string"
                 }
             }
         },
-        "mcir.TbFirewallRuleInfo": {
+        "mcis.MonMetricSample": {
             "type": "object",
-            "required": [
-                "direction",
-                "fromPort",
-                "ipprotocol",
-                "toPort"
-            ],
             "properties": {
-                "cidr": {
+                "timestamp": {
                     "type": "string"
                 },
-                "direction": {
-                    "description": "` + "`" + `json:\"direction\"` + "`" + `",
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "mcis.MonResultSimple": {
+            "type": "object",
+            "properties": {
+                "err": {
                     "type": "string"
                 },
-                "fromPort": {
-                    "description": "` + "`" + `json:\"fromPort\"` + "`" + `",
+                "metric": {
                     "type": "string"
                 },
-                "ipprotocol": {
-                    "description": "` + "`" + `json:\"ipProtocol\"` + "`" + `",
+                "value": {
                     "type": "string"
                 },
-                "toPort": {
-                    "description": "` + "`" + `json:\"toPort\"` + "`" + `",
+                "vmId": {
                     "type": "string"
                 }
             }
         },
-        "mcir.TbFirewallRulesWrapper": {
+        "mcis.MonResultSimpleResponse": {
             "type": "object",
             "properties": {
-                "firewallRules": {
-                    "description": "validate:\"required\"` + "`" + `",
+                "mcisId": {
+                    "type": "string"
+                },
+                "mcisMonitoring": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
+                        "$ref": "#/definitions/mcis.MonResultSimple"
                     }
+                },
+                "nsId": {
+                    "type": "string"
                 }
             }
         },
-        "mcir.TbImageInfo": {
+        "mcis.MonitoringHistoryResponse": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
+                "aggregation": {
+                    "type": "string",
+                    "example": "avg"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2024-03-01T12:00:00Z"
+                },
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "metric": {
+                    "type": "string",
+                    "example": "cpu"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "agentless"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2024-03-01T11:00:00Z"
+                },
+                "stepSec": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "subGroupSeries": {
+                    "description": "SubGroupSeries is the series of each subGroup (aggregated from VM series in each step with the same aggregation, last is treated as avg)",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/mcis.MonitoringSeries"
                     }
                 },
-                "connectionName": {
-                    "type": "string"
-                },
-                "creationDate": {
+                "vmSeries": {
+                    "description": "VmSeries is the series of each VM",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.MonitoringSeries"
+                    }
+                }
+            }
+        },
+        "mcis.MonitoringSeries": {
+            "type": "object",
+            "properties": {
+                "err": {
                     "type": "string"
                 },
-                "cspImageId": {
-                    "type": "string"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.MonMetricSample"
+                    }
                 },
-                "cspImageName": {
-                    "type": "string"
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
                 },
-                "description": {
-                    "type": "string"
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
+                }
+            }
+        },
+        "mcis.NLBListenerReq": {
+            "type": "object",
+            "properties": {
+                "port": {
+                    "description": "1-65535",
+                    "type": "string",
+                    "example": "80"
                 },
-                "guestOS": {
-                    "description": "Windows7, Ubuntu etc.",
-                    "type": "string"
+                "protocol": {
+                    "description": "TCP|UDP",
+                    "type": "string",
+                    "example": "TCP"
+                }
+            }
+        },
+        "mcis.NetworkMeshLink": {
+            "type": "object",
+            "properties": {
+                "dest": {
+                    "type": "string",
+                    "example": "g2-1"
                 },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "isAutoGenerated": {
-                    "type": "boolean"
-                },
-                "keyValueList": {
+                "issues": {
+                    "description": "Issues is the list of problems of the link (unreachable, packetLoss, highLatency, highJitter, lowThroughput)",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "type": "string"
                     }
                 },
-                "name": {
-                    "type": "string"
+                "jitterMs": {
+                    "type": "number",
+                    "example": 0.8
                 },
-                "namespace": {
-                    "description": "required to save in RDB",
-                    "type": "string"
+                "latencyMs": {
+                    "type": "number",
+                    "example": 32.5
                 },
-                "status": {
-                    "description": "available, unavailable",
-                    "type": "string"
+                "packetLoss": {
+                    "type": "number",
+                    "example": 0
                 },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                "src": {
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
+                    "example": "g1-1"
+                },
+                "throughputMbps": {
+                    "type": "number",
+                    "example": 920.4
                 }
             }
         },
-        "mcir.TbImageReq": {
+        "mcis.NetworkMeshNode": {
             "type": "object",
-            "required": [
-                "connectionName",
-                "cspImageId",
-                "name"
-            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
+                "region": {
+                    "description": "Region is {providerName}-{regionName} of the VM",
+                    "type": "string",
+                    "example": "aws-ap-northeast-2"
                 },
-                "cspImageId": {
-                    "type": "string"
+                "specId": {
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
                 },
-                "description": {
-                    "type": "string"
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
+                }
+            }
+        },
+        "mcis.NetworkMeshReq": {
+            "type": "object",
+            "properties": {
+                "skipInstall": {
+                    "description": "SkipInstall is to skip installation of benchmark tools (if already installed)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "subGroupRepresentative": {
+                    "description": "SubGroupRepresentative is to measure only between representatives (the first VM) of subGroups",
+                    "type": "boolean",
+                    "default": false,
+                    "example": true
+                },
+                "throughput": {
+                    "description": "Throughput is to measure throughput by iperf3 (TCP port 5201 should be allowed), in addition to latency by ping",
+                    "type": "boolean",
+                    "default": false,
+                    "example": true
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
+                },
+                "vmIds": {
+                    "description": "VmIds is to measure only between the VMs (all VMs in MCIS if omitted)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "worstLinks": {
+                    "description": "WorstLinks is the number of worst links to highlight (default: 5)",
+                    "type": "integer",
+                    "default": 5,
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 5
                 }
             }
         },
-        "mcir.TbSecurityGroupInfo": {
+        "mcis.NetworkMeshResult": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
+                "elapsedSec": {
+                    "type": "number",
+                    "example": 120.5
+                },
+                "jitterMs": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
                     }
                 },
-                "connectionName": {
-                    "type": "string"
+                "latencyMs": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
                 },
-                "cspSecurityGroupId": {
-                    "type": "string"
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.NetworkMeshLink"
+                    }
                 },
-                "cspSecurityGroupName": {
-                    "type": "string"
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
                 },
-                "description": {
-                    "type": "string"
+                "medianLatencyMs": {
+                    "type": "number",
+                    "example": 45.1
                 },
-                "firewallRules": {
+                "medianThroughputMbps": {
+                    "type": "number",
+                    "example": 850.3
+                },
+                "nodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
+                        "$ref": "#/definitions/mcis.NetworkMeshNode"
                     }
                 },
-                "id": {
-                    "type": "string"
+                "packetLoss": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
                 },
-                "isAutoGenerated": {
-                    "type": "boolean"
+                "startedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "keyValueList": {
+                "throughputMbps": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
                     }
                 },
-                "name": {
-                    "type": "string"
-                },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                "worstLinks": {
+                    "description": "WorstLinks is the list of links with issues first (and the highest latency)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.NetworkMeshLink"
+                    }
+                }
+            }
+        },
+        "mcis.Operation": {
+            "type": "object",
+            "properties": {
+                "operand": {
+                    "description": "10, 70, 80, 98, ...",
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
+                    "enum": [
+                        "4",
+                        "8",
+                        ".."
+                    ],
+                    "example": "4"
                 },
-                "vNetId": {
-                    "type": "string"
+                "operator": {
+                    "description": "\u003e=, \u003c=, ==",
+                    "type": "string",
+                    "enum": [
+                        "\u003e=",
+                        "\u003c=",
+                        "=="
+                    ],
+                    "example": "\u003c="
                 }
             }
         },
-        "mcir.TbSecurityGroupReq": {
+        "mcis.ParameterKeyVal": {
             "type": "object",
-            "required": [
-                "connectionName",
-                "name",
-                "vNetId"
-            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspSecurityGroupId": {
-                    "description": "CspSecurityGroupId is required to register object from CSP (option=register)",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "key": {
+                    "description": "coordinate, market (for cost: on-demand or spot)",
+                    "type": "string",
+                    "enum": [
+                        "coordinateClose",
+                        "coordinateWithin",
+                        "coordinateFair",
+                        "market"
+                    ],
+                    "example": "coordinateClose"
                 },
-                "firewallRules": {
-                    "description": "validate:\"required\"` + "`" + `",
+                "val": {
+                    "description": "[\"Latitude,Longitude\",\"12,543\",..,\"31,433\"]",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbFirewallRuleInfo"
-                    }
-                },
-                "name": {
-                    "type": "string"
-                },
-                "vNetId": {
-                    "type": "string"
+                        "type": "string"
+                    },
+                    "example": [
+                        "44.146838/-116.411403"
+                    ]
                 }
             }
         },
-        "mcir.TbSpecInfo": {
+        "mcis.PlacementConstraint": {
             "type": "object",
             "properties": {
-                "acceleratorCount": {
-                    "type": "integer"
+                "allowedRegions": {
+                    "description": "AllowedRegions is the regions allowed for all subGroups (data residency), in the form of {providerName}+{regionName} or {regionName}",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aws+ap-northeast-2",
+                        "gcp+asia-northeast3"
+                    ]
                 },
-                "acceleratorMemoryGB": {
-                    "type": "number"
+                "maxCostPerHour": {
+                    "description": "MaxCostPerHour is the total budget per hour of all VMs in MCIS (0: no constraint)",
+                    "type": "number",
+                    "example": 2.5
                 },
-                "acceleratorModel": {
-                    "type": "string"
+                "maxLatencyMs": {
+                    "description": "MaxLatencyMs is the maximum latency between any two regions of subGroups from the latency store (0: no constraint)",
+                    "type": "number",
+                    "example": 150
                 },
-                "acceleratorType": {
-                    "type": "string"
+                "minDistinctProviders": {
+                    "description": "MinDistinctProviders is the minimum number of distinct cloud providers in MCIS (0: no constraint)",
+                    "type": "integer",
+                    "example": 2
                 },
-                "associatedObjectList": {
+                "providerQuota": {
+                    "description": "ProviderQuota is the maximum number of VMs for each provider",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "aws": 4,
+                        "azure": 2
+                    }
+                }
+            }
+        },
+        "mcis.PlacementSubGroupReq": {
+            "type": "object",
+            "required": [
+                "commonImage",
+                "name"
+            ],
+            "properties": {
+                "allowedRegions": {
+                    "description": "AllowedRegions is the regions allowed for the subGroup (data residency) in addition to the constraint of MCIS",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
-                },
-                "connectionName": {
-                    "type": "string"
-                },
-                "costPerHour": {
-                    "type": "number"
+                    },
+                    "example": [
+                        "aws+ap-northeast-2",
+                        "ap-northeast-1"
+                    ]
                 },
-                "cspSpecName": {
-                    "type": "string"
+                "commonImage": {
+                    "description": "CommonImage is the OS type (resolved for the region of the selected spec) or id of an image in common namespace",
+                    "type": "string",
+                    "example": "ubuntu22.04"
                 },
                 "description": {
-                    "type": "string"
-                },
-                "evaluationScore01": {
-                    "type": "number"
-                },
-                "evaluationScore02": {
-                    "type": "number"
-                },
-                "evaluationScore03": {
-                    "type": "number"
-                },
-                "evaluationScore04": {
-                    "type": "number"
-                },
-                "evaluationScore05": {
-                    "type": "number"
-                },
-                "evaluationScore06": {
-                    "type": "number"
-                },
-                "evaluationScore07": {
-                    "type": "number"
-                },
-                "evaluationScore08": {
-                    "type": "number"
-                },
-                "evaluationScore09": {
-                    "type": "number"
-                },
-                "evaluationScore10": {
-                    "type": "number"
-                },
-                "evaluationStatus": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "isAutoGenerated": {
-                    "type": "boolean"
+                    "type": "string",
+                    "example": "Description"
                 },
-                "maxTotalStorageTiB": {
-                    "type": "integer"
+                "filter": {
+                    "description": "Filter is the spec requirements of the subGroup (same as the filter of mcisRecommendVm)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.FilterInfo"
+                        }
+                    ]
                 },
-                "memoryGiB": {
-                    "type": "number"
+                "label": {
+                    "type": "string",
+                    "example": "web"
                 },
                 "name": {
-                    "type": "string"
-                },
-                "namespace": {
-                    "description": "required to save in RDB",
-                    "type": "string"
-                },
-                "netBwGbps": {
-                    "type": "integer"
-                },
-                "orderInFilteredResult": {
-                    "type": "integer"
-                },
-                "osType": {
-                    "type": "string"
-                },
-                "providerName": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "g1"
                 },
-                "regionName": {
-                    "type": "string"
+                "priority": {
+                    "description": "Priority is the preference of specs of the subGroup (same as the priority of mcisRecommendVm, cost if omitted)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.PriorityInfo"
+                        }
+                    ]
                 },
                 "rootDiskSize": {
-                    "type": "string"
-                },
-                "rootDiskType": {
-                    "type": "string"
-                },
-                "storageGiB": {
-                    "type": "integer"
+                    "type": "string",
+                    "default": "default",
+                    "example": "default"
                 },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                "rootDiskType": {
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
+                    "default": "default",
+                    "example": "default"
                 },
-                "vCPU": {
-                    "type": "integer"
+                "subGroupSize": {
+                    "type": "string",
+                    "default": "1",
+                    "example": "3"
                 }
             }
         },
-        "mcir.TbSpecReq": {
+        "mcis.Policy": {
             "type": "object",
-            "required": [
-                "connectionName",
-                "cspSpecName",
-                "name"
-            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspSpecName": {
-                    "type": "string"
+                "autoAction": {
+                    "$ref": "#/definitions/mcis.AutoAction"
                 },
-                "description": {
-                    "type": "string"
+                "autoCondition": {
+                    "$ref": "#/definitions/mcis.AutoCondition"
                 },
-                "name": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "mcir.TbSshKeyInfo": {
+        "mcis.PriorityCondition": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
+                "metric": {
+                    "type": "string",
+                    "enum": [
+                        "location",
+                        "cost",
+                        "random",
+                        "performance",
+                        "latency",
+                        "availability",
+                        "carbon"
+                    ],
+                    "example": "location"
+                },
+                "parameter": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/mcis.ParameterKeyVal"
                     }
                 },
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspSshKeyId": {
-                    "description": "CspSshKeyId used for CSP-native identifier (either Name or ID)",
-                    "type": "string"
-                },
-                "cspSshKeyName": {
-                    "description": "CspSshKeyName used for CB-Spider identifier",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "fingerprint": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "isAutoGenerated": {
-                    "type": "boolean"
-                },
-                "keyValueList": {
+                "weight": {
+                    "description": "relative weight of the metric (1 if omitted)",
+                    "type": "string",
+                    "enum": [
+                        "0.1",
+                        "0.2",
+                        "..."
+                    ],
+                    "example": "0.3"
+                }
+            }
+        },
+        "mcis.PriorityInfo": {
+            "type": "object",
+            "properties": {
+                "policy": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.PriorityCondition"
                     }
+                }
+            }
+        },
+        "mcis.ProvisioningFailure": {
+            "type": "object",
+            "properties": {
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "privateKey": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "InsufficientInstanceCapacity: ..."
                 },
-                "publicKey": {
-                    "type": "string"
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
                 },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                "occurredAt": {
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "username": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "capacity",
+                        "quota",
+                        "unsupported",
+                        "other"
+                    ],
+                    "example": "capacity"
                 },
-                "verifiedUsername": {
-                    "type": "string"
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
                 }
             }
         },
-        "mcir.TbSshKeyReq": {
+        "mcis.RegionCarbonIntensity": {
             "type": "object",
-            "required": [
-                "connectionName",
-                "name"
-            ],
             "properties": {
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspSshKeyId": {
-                    "description": "Fields for \"Register existing SSH keys\" feature\nCspSshKeyId is required to register object from CSP (option=register)",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "fingerprint": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "intensity": {
+                    "description": "Intensity is the carbon intensity of electricity (gCO2eq/kWh)",
+                    "type": "number",
+                    "example": 10
                 },
-                "privateKey": {
-                    "type": "string"
+                "providerName": {
+                    "type": "string",
+                    "example": "aws"
                 },
-                "publicKey": {
-                    "type": "string"
+                "regionName": {
+                    "type": "string",
+                    "example": "eu-north-1"
                 },
-                "username": {
-                    "type": "string"
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "file",
+                        "api",
+                        "default"
+                    ],
+                    "example": "file"
                 },
-                "verifiedUsername": {
-                    "type": "string"
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "mcir.TbSubnetInfo": {
+        "mcis.RegionCarbonIntensityList": {
             "type": "object",
-            "required": [
-                "ipv4_CIDR",
-                "name"
-            ],
             "properties": {
-                "bastionNodes": {
+                "carbonIntensity": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.BastionNode"
+                        "$ref": "#/definitions/mcis.RegionCarbonIntensity"
                     }
                 },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "idFromCsp": {
-                    "type": "string"
+                "default": {
+                    "description": "Default is the carbon intensity (gCO2eq/kWh) for regions not listed",
+                    "type": "number",
+                    "example": 475
                 },
-                "ipv4_CIDR": {
+                "power": {
+                    "$ref": "#/definitions/common.PowerModel"
+                }
+            }
+        },
+        "mcis.RegionInfo": {
+            "type": "object",
+            "properties": {
+                "region": {
                     "type": "string"
                 },
-                "keyValueList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.KeyValue"
-                    }
-                },
-                "name": {
+                "zone": {
                     "type": "string"
                 }
             }
         },
-        "mcir.TbSubnetReq": {
+        "mcis.RegisterResourceAllResult": {
             "type": "object",
-            "required": [
-                "ipv4_CIDR",
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "availableConnection": {
+                    "type": "integer"
                 },
-                "idFromCsp": {
-                    "type": "string"
+                "elapsedTime": {
+                    "type": "integer"
                 },
-                "ipv4_CIDR": {
-                    "type": "string"
+                "registerationOverview": {
+                    "$ref": "#/definitions/mcis.registerationOverview"
                 },
-                "keyValueList": {
+                "registerationResult": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.RegisterResourceResult"
                     }
                 },
-                "name": {
-                    "type": "string"
+                "registeredConnection": {
+                    "type": "integer"
                 }
             }
         },
-        "mcir.TbVNetInfo": {
+        "mcis.RegisterResourceResult": {
             "type": "object",
             "properties": {
-                "associatedObjectList": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "cidrBlock": {
-                    "type": "string"
-                },
                 "connectionName": {
                     "type": "string"
                 },
-                "cspVNetId": {
-                    "type": "string"
+                "elapsedTime": {
+                    "type": "integer"
                 },
-                "cspVNetName": {
-                    "type": "string"
+                "registerationOutputs": {
+                    "$ref": "#/definitions/common.IdList"
                 },
-                "description": {
-                    "type": "string"
+                "registerationOverview": {
+                    "$ref": "#/definitions/mcis.registerationOverview"
                 },
-                "id": {
+                "systemMessage": {
                     "type": "string"
-                },
-                "isAutoGenerated": {
-                    "type": "boolean"
-                },
-                "keyValueList": {
+                }
+            }
+        },
+        "mcis.RestGetAllBenchmarkRequest": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcis.RestGetAllK8sClusterResponse": {
+            "type": "object",
+            "properties": {
+                "cluster": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/common.KeyValue"
+                        "$ref": "#/definitions/mcis.TbK8sClusterInfo"
                     }
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "subnetInfoList": {
+                }
+            }
+        },
+        "mcis.RestGetAllMcisPolicyResponse": {
+            "type": "object",
+            "properties": {
+                "mcisPolicy": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbSubnetInfo"
+                        "$ref": "#/definitions/mcis.McisPolicyInfo"
                     }
-                },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
-                    "type": "string",
-                    "example": "Managed by CB-Tumblebug"
                 }
             }
         },
-        "mcir.TbVNetReq": {
+        "mcis.RestGetAllMcisResponse": {
             "type": "object",
-            "required": [
-                "connectionName",
-                "name"
-            ],
             "properties": {
-                "cidrBlock": {
-                    "type": "string"
-                },
-                "connectionName": {
-                    "type": "string"
-                },
-                "cspVNetId": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "subnetInfoList": {
+                "mcis": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcir.TbSubnetReq"
+                        "$ref": "#/definitions/mcis.TbMcisInfo"
                     }
                 }
             }
         },
-        "mcis.AgentInstallContent": {
+        "mcis.RestGetAllMcisStatusResponse": {
             "type": "object",
             "properties": {
-                "mcisId": {
-                    "type": "string"
-                },
-                "result": {
-                    "type": "string"
-                },
-                "vmId": {
-                    "type": "string"
-                },
-                "vmIp": {
-                    "type": "string"
+                "mcis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.McisStatusInfo"
+                    }
                 }
             }
         },
-        "mcis.AgentInstallContentWrapper": {
+        "mcis.RestGetAllNLBResponse": {
             "type": "object",
             "properties": {
-                "resultArray": {
+                "nlb": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.AgentInstallContent"
+                        "$ref": "#/definitions/mcis.TbNLBInfo"
                     }
                 }
             }
         },
-        "mcis.AutoAction": {
+        "mcis.RestGetBenchmarkRequest": {
             "type": "object",
             "properties": {
-                "actionType": {
+                "host": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcis.RightSizingApplyReq": {
+            "type": "object",
+            "properties": {
+                "highUtilization": {
+                    "description": "HighUtilization is the peak utilization (%) over which a spec is regarded as undersized (default: 80)",
+                    "type": "number",
+                    "example": 80
+                },
+                "lowUtilization": {
+                    "description": "LowUtilization is the peak utilization (%) under which a spec is regarded as oversized (default: 30)",
+                    "type": "number",
+                    "example": 30
+                },
+                "source": {
+                    "description": "Source is the source of monitoring data (default: agentless if enabled for the MCIS, otherwise dragonfly)",
                     "type": "string",
                     "enum": [
-                        "ScaleOut",
-                        "ScaleIn"
+                        "agentless",
+                        "dragonfly"
                     ],
-                    "example": "ScaleOut"
+                    "example": "agentless"
                 },
-                "placementAlgo": {
+                "subGroupId": {
+                    "description": "SubGroupId is to get the recommendation only for the subGroup (optional)",
                     "type": "string",
-                    "example": "random"
+                    "example": "g1"
                 },
-                "postCommand": {
-                    "description": "PostCommand is field for providing command to VMs after its creation. example:\"wget https://raw.githubusercontent.com/cloud-barista/cb-tumblebug/main/scripts/setweb.sh -O ~/setweb.sh; chmod +x ~/setweb.sh; sudo ~/setweb.sh\"",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/mcis.McisCmdReq"
-                        }
-                    ]
+                "targetSpecId": {
+                    "description": "TargetSpecId is the spec to change to (optional, the recommended spec of the subGroup is used if empty)",
+                    "type": "string",
+                    "example": "aws-ap-northeast-2-t3-small"
                 },
-                "vmDynamicReq": {
-                    "$ref": "#/definitions/mcis.TbVmDynamicReq"
+                "targetUtilization": {
+                    "description": "TargetUtilization is the peak utilization (%) expected with the recommended spec (default: 60)",
+                    "type": "number",
+                    "example": 60
+                },
+                "windowHours": {
+                    "description": "WindowHours is the monitoring window to evaluate utilization (default: 24)",
+                    "type": "integer",
+                    "example": 24
                 }
             }
         },
-        "mcis.AutoCondition": {
+        "mcis.RightSizingReq": {
             "type": "object",
             "properties": {
-                "evaluationPeriod": {
+                "highUtilization": {
+                    "description": "HighUtilization is the peak utilization (%) over which a spec is regarded as undersized (default: 80)",
+                    "type": "number",
+                    "example": 80
+                },
+                "lowUtilization": {
+                    "description": "LowUtilization is the peak utilization (%) under which a spec is regarded as oversized (default: 30)",
+                    "type": "number",
+                    "example": 30
+                },
+                "source": {
+                    "description": "Source is the source of monitoring data (default: agentless if enabled for the MCIS, otherwise dragonfly)",
                     "type": "string",
-                    "example": "10"
-                },
-                "evaluationValue": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                    "enum": [
+                        "agentless",
+                        "dragonfly"
+                    ],
+                    "example": "agentless"
                 },
-                "metric": {
+                "subGroupId": {
+                    "description": "SubGroupId is to get the recommendation only for the subGroup (optional)",
                     "type": "string",
-                    "example": "cpu"
+                    "example": "g1"
                 },
-                "operand": {
-                    "type": "string",
-                    "example": "80"
+                "targetUtilization": {
+                    "description": "TargetUtilization is the peak utilization (%) expected with the recommended spec (default: 60)",
+                    "type": "number",
+                    "example": 60
                 },
-                "operator": {
-                    "type": "string",
-                    "enum": [
-                        "\u003c",
-                        "\u003c=",
-                        "\u003e",
-                        "\u003e="
-                    ],
-                    "example": "\u003e="
+                "windowHours": {
+                    "description": "WindowHours is the monitoring window to evaluate utilization (default: 24)",
+                    "type": "integer",
+                    "example": 24
                 }
             }
         },
-        "mcis.BenchmarkInfo": {
+        "mcis.RightSizingResult": {
             "type": "object",
             "properties": {
-                "desc": {
-                    "type": "string"
+                "end": {
+                    "type": "string",
+                    "example": "2024-03-02T00:00:00Z"
                 },
-                "elapsed": {
-                    "type": "string"
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
                 },
-                "regionName": {
-                    "type": "string"
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
                 },
-                "result": {
-                    "type": "string"
+                "source": {
+                    "type": "string",
+                    "example": "agentless"
                 },
-                "resultarray": {
-                    "description": "struct-element cycle ?",
+                "start": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "subGroups": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.BenchmarkInfo"
+                        "$ref": "#/definitions/mcis.SubGroupRightSizing"
                     }
                 },
-                "specid": {
-                    "type": "string"
+                "totalEstimatedMonthlySavings": {
+                    "description": "TotalEstimatedMonthlySavings is the sum of savings by all recommendations",
+                    "type": "number",
+                    "example": 56.94
                 },
-                "unit": {
-                    "type": "string"
+                "windowHours": {
+                    "type": "integer",
+                    "example": 24
                 }
             }
         },
-        "mcis.BenchmarkInfoArray": {
+        "mcis.RightSizingSpec": {
             "type": "object",
             "properties": {
-                "resultarray": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.BenchmarkInfo"
-                    }
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-northeast-2"
+                },
+                "costPerHour": {
+                    "description": "CostPerHour is the cost of the spec for the market of VMs (0 if unknown)",
+                    "type": "number",
+                    "example": 0.026
+                },
+                "cspSpecName": {
+                    "type": "string",
+                    "example": "t3.small"
+                },
+                "memoryGiB": {
+                    "type": "number",
+                    "example": 2
+                },
+                "specId": {
+                    "type": "string",
+                    "example": "aws-ap-northeast-2-t3-small"
+                },
+                "vCPU": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "mcis.CheckMcisDynamicReqInfo": {
+        "mcis.ScriptExecReq": {
             "type": "object",
             "required": [
-                "reqCheck"
+                "scriptName"
             ],
             "properties": {
-                "reqCheck": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.CheckVmDynamicReqInfo"
-                    }
-                }
-            }
-        },
-        "mcis.CheckVmDynamicReqInfo": {
-            "type": "object",
-            "properties": {
-                "connectionConfigCandidates": {
-                    "description": "ConnectionConfigCandidates will provide ConnectionConfig options",
-                    "type": "array",
-                    "items": {
+                "continueOnError": {
+                    "description": "ContinueOnError executes the remaining commands after a failed command (stops at the first failure by default)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "env": {
+                    "description": "Env is environment variables for the commands",
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcir.TbImageInfo"
+                "params": {
+                    "description": "Params is values of the script parameters (built-in functions of remote commands can be used)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 },
-                "region": {
-                    "$ref": "#/definitions/common.RegionDetail"
+                "retry": {
+                    "description": "Retry is the number of retries of a failed command",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 10,
+                    "minimum": 0,
+                    "example": 0
                 },
-                "spec": {
-                    "$ref": "#/definitions/mcir.TbSpecInfo"
+                "retryIntervalSec": {
+                    "description": "RetryIntervalSec is the interval between retries in seconds",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 3600,
+                    "minimum": 0,
+                    "example": 5
                 },
-                "systemMessage": {
-                    "description": "Latest system message such as error message",
+                "scriptName": {
                     "type": "string",
-                    "example": "Failed because ..."
-                }
-            }
-        },
-        "mcis.DeploymentPlan": {
-            "type": "object",
-            "properties": {
-                "filter": {
-                    "$ref": "#/definitions/mcis.FilterInfo"
+                    "example": "setweb"
                 },
-                "limit": {
+                "sudo": {
+                    "description": "Sudo executes the commands with sudo (requires passwordless sudo)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "timeoutSec": {
+                    "description": "TimeoutSec is the timeout of each command in seconds (0: no timeout)",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
+                },
+                "userName": {
                     "type": "string",
-                    "enum": [
-                        "1",
-                        "2",
-                        "30"
-                    ],
-                    "example": "5"
+                    "example": "cb-user"
                 },
-                "priority": {
-                    "$ref": "#/definitions/mcis.PriorityInfo"
-                }
-            }
-        },
-        "mcis.FilterCondition": {
-            "type": "object",
-            "properties": {
-                "condition": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.Operation"
-                    }
+                "version": {
+                    "description": "Version of the script to execute (0: the latest version)",
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 0
                 },
-                "metric": {
+                "workDir": {
+                    "description": "WorkDir is the working directory for the commands",
                     "type": "string",
-                    "enum": [
-                        "vCPU",
-                        "memoryGiB",
-                        "costPerHour"
-                    ],
-                    "example": "vCPU"
-                }
-            }
-        },
-        "mcis.FilterInfo": {
-            "type": "object",
-            "properties": {
-                "policy": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.FilterCondition"
-                    }
+                    "example": "/home/cb-user"
                 }
             }
         },
-        "mcis.InspectResource": {
+        "mcis.ScriptExecutionInfo": {
             "type": "object",
             "properties": {
-                "connectionName": {
+                "error": {
+                    "description": "Error is the error of the execution request (e.g., no target VM)",
                     "type": "string"
                 },
-                "resourceOverview": {
-                    "$ref": "#/definitions/mcis.resourceCountOverview"
-                },
-                "resourceType": {
-                    "type": "string"
+                "executionId": {
+                    "type": "string",
+                    "example": "cq2a3b4c5d6e7f8g9h0i"
                 },
-                "resources": {
-                    "$ref": "#/definitions/mcis.resourcesByManageType"
+                "finishedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:01:00Z"
                 },
-                "systemMessage": {
-                    "type": "string"
-                }
-            }
-        },
-        "mcis.InspectResourceAllResult": {
-            "type": "object",
-            "properties": {
-                "availableConnection": {
-                    "type": "integer"
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
                 },
-                "cspOnlyOverview": {
-                    "$ref": "#/definitions/mcis.inspectOverview"
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
                 },
-                "elapsedTime": {
-                    "type": "integer"
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "inspectResult": {
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.InspectResourceResult"
+                        "$ref": "#/definitions/mcis.SshCmdResult"
                     }
                 },
-                "registeredConnection": {
-                    "type": "integer"
+                "scriptName": {
+                    "type": "string",
+                    "example": "setweb"
                 },
-                "tumblebugOverview": {
-                    "$ref": "#/definitions/mcis.inspectOverview"
-                }
-            }
-        },
-        "mcis.InspectResourceResult": {
-            "type": "object",
-            "properties": {
-                "connectionName": {
-                    "type": "string"
+                "startedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "cspOnlyOverview": {
-                    "$ref": "#/definitions/mcis.inspectOverview"
+                "status": {
+                    "type": "string",
+                    "example": "Succeeded"
                 },
-                "elapsedTime": {
-                    "type": "integer"
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
                 },
-                "systemMessage": {
-                    "type": "string"
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
                 },
-                "tumblebugOverview": {
-                    "$ref": "#/definitions/mcis.inspectOverview"
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "vmErr": {
+                    "description": "VmErr is error message for each VM (SshCmdResult.Err is not kept in the record)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
                 }
             }
         },
-        "mcis.JSONResult": {
-            "type": "object"
-        },
-        "mcis.McNlbInfo": {
+        "mcis.ScriptExecutionInfoList": {
             "type": "object",
             "properties": {
-                "deploymentLog": {
-                    "$ref": "#/definitions/mcis.McisSshCmdResult"
-                },
-                "mcNlbHostInfo": {
-                    "$ref": "#/definitions/mcis.TbMcisInfo"
-                },
-                "mcisAccessInfo": {
-                    "$ref": "#/definitions/mcis.McisAccessInfo"
+                "executions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.ScriptExecutionInfo"
+                    }
                 }
             }
         },
-        "mcis.McisAccessInfo": {
+        "mcis.ScriptInfo": {
             "type": "object",
             "properties": {
-                "mcisId": {
+                "content": {
                     "type": "string"
                 },
-                "mcisNlbListener": {
-                    "$ref": "#/definitions/mcis.McisAccessInfo"
+                "createdTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "mcisSubGroupAccessInfo": {
+                "description": {
+                    "type": "string",
+                    "example": "install and start nginx"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "setweb"
+                },
+                "parameters": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.McisSubGroupAccessInfo"
+                        "$ref": "#/definitions/mcis.ScriptParameter"
                     }
+                },
+                "updatedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "mcis.McisCmdReq": {
+        "mcis.ScriptInfoList": {
             "type": "object",
-            "required": [
-                "command"
-            ],
             "properties": {
-                "command": {
+                "script": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "client_ip=$(echo $SSH_CLIENT | awk '{print $1}'); echo SSH client IP is: $client_ip"
-                    ]
-                },
-                "userName": {
-                    "type": "string",
-                    "example": "cb-user"
+                        "$ref": "#/definitions/mcis.ScriptInfo"
+                    }
                 }
             }
         },
-        "mcis.McisConnectionConfigCandidatesReq": {
+        "mcis.ScriptParameter": {
             "type": "object",
             "required": [
-                "commonSpec"
+                "name"
             ],
             "properties": {
-                "commonSpec": {
-                    "description": "CommonSpec is field for id of a spec in common namespace",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "aws+ap-northeast-2+t2.small",
-                        "gcp+us-west1+g1-small"
-                    ]
+                "default": {
+                    "type": "string",
+                    "example": "80"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "port of the web server"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "WEB_PORT"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "mcis.McisPolicyInfo": {
+        "mcis.ScriptReq": {
             "type": "object",
+            "required": [
+                "content"
+            ],
             "properties": {
-                "Id": {
-                    "description": "MCIS Id (generated ID by the Name)",
-                    "type": "string"
-                },
-                "Name": {
-                    "description": "MCIS Name (for request)",
-                    "type": "string"
-                },
-                "actionLog": {
-                    "type": "string"
+                "content": {
+                    "description": "Content is the script to execute in VMs (a shebang line selects the interpreter, bash by default).\nBuilt-in functions of remote commands (e.g., $$Func(GetPublicIPs(separator=' '))) can be used.",
+                    "type": "string",
+                    "example": "#!/bin/bash\nsudo apt-get update \u0026\u0026 sudo apt-get install -y nginx\n"
                 },
                 "description": {
                     "type": "string",
-                    "example": "Description"
+                    "example": "install and start nginx"
                 },
-                "policy": {
+                "name": {
+                    "description": "Name is required to create a script (ignored to update a script)",
+                    "type": "string",
+                    "example": "setweb"
+                },
+                "parameters": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.Policy"
+                        "$ref": "#/definitions/mcis.ScriptParameter"
                     }
                 }
             }
         },
-        "mcis.McisPolicyReq": {
+        "mcis.SpecAvailabilityInfo": {
             "type": "object",
             "properties": {
-                "description": {
+                "availabilityScore": {
+                    "description": "AvailabilityScore is the availability of the spec (1 is available, decreased by recent failures and recovered with decay)",
+                    "type": "number",
+                    "example": 0.85
+                },
+                "connectionName": {
                     "type": "string",
-                    "example": "Description"
+                    "example": "aws-ap-northeast-2"
                 },
-                "policy": {
+                "cspSpecName": {
+                    "type": "string",
+                    "example": "t2.small"
+                },
+                "failureCount": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "failures": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.Policy"
+                        "$ref": "#/definitions/mcis.ProvisioningFailure"
                     }
+                },
+                "lastFailureAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "lastSuccessAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "penalty": {
+                    "description": "Penalty is the accumulated penalty of failures at UpdatedAt (AvailabilityScore = exp(-decayed penalty))",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "specId": {
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
+                },
+                "successCount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "mcis.McisSshCmdResult": {
+        "mcis.SpecAvailabilityInfoList": {
             "type": "object",
             "properties": {
-                "results": {
+                "specAvailability": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.SshCmdResult"
+                        "$ref": "#/definitions/mcis.SpecAvailabilityInfo"
                     }
                 }
             }
         },
-        "mcis.McisStatusInfo": {
+        "mcis.SpecBenchmarkHistory": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "installMonAgent": {
-                    "description": "InstallMonAgent Option for CB-Dragonfly agent installation ([yes/no] default:yes)",
-                    "type": "string",
-                    "example": "[yes, no]"
-                },
-                "label": {
-                    "description": "Label is for describing the mcis in a keyword (any string can be used)",
-                    "type": "string",
-                    "example": "User custom label"
-                },
-                "masterIp": {
-                    "type": "string",
-                    "example": "32.201.134.113"
-                },
-                "masterSSHPort": {
-                    "type": "string"
-                },
-                "masterVmId": {
-                    "type": "string",
-                    "example": "vm-asiaeast1-cb-01"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+                "average": {
+                    "description": "Average is the average of recent samples of each metric",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
                 },
-                "statusCount": {
-                    "$ref": "#/definitions/mcis.StatusCountInfo"
+                "latest": {
+                    "description": "Latest is the latest result of each metric",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.SpecBenchmarkInfo"
+                        }
+                    ]
                 },
-                "systemLabel": {
-                    "description": "SystemLabel is for describing the mcis in a keyword (any string can be used) for special System purpose",
+                "providerName": {
                     "type": "string",
-                    "example": "Managed by CB-Tumblebug"
-                },
-                "targetAction": {
-                    "type": "string"
+                    "example": "aws"
                 },
-                "targetStatus": {
-                    "type": "string"
+                "regionName": {
+                    "type": "string",
+                    "example": "ap-northeast-2"
                 },
-                "vm": {
+                "samples": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.TbVmStatusInfo"
+                        "$ref": "#/definitions/mcis.SpecBenchmarkSample"
+                    }
+                },
+                "score": {
+                    "description": "Score is the score of each metric (100 is the median of benchmarked specs) and the overall performance score (\"overall\")",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
                     }
+                },
+                "seedScore": {
+                    "description": "SeedScore is EvaluationScore01 of the spec before benchmarking (e.g., from assets/cloudspec.csv)",
+                    "type": "number",
+                    "example": 78.43
+                },
+                "specId": {
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "mcis.McisSubGroupAccessInfo": {
+        "mcis.SpecBenchmarkHistoryList": {
             "type": "object",
             "properties": {
-                "bastionVmId": {
-                    "type": "string"
-                },
-                "mcisVmAccessInfo": {
+                "specBenchmark": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.McisVmAccessInfo"
+                        "$ref": "#/definitions/mcis.SpecBenchmarkHistory"
                     }
-                },
-                "nlbListener": {
-                    "$ref": "#/definitions/mcis.TbNLBListenerInfo"
-                },
-                "subGroupId": {
-                    "type": "string"
                 }
             }
         },
-        "mcis.McisVmAccessInfo": {
+        "mcis.SpecBenchmarkInfo": {
             "type": "object",
             "properties": {
-                "privateIP": {
+                "cpum": {
                     "type": "string"
                 },
-                "privateKey": {
+                "cpus": {
                     "type": "string"
                 },
-                "publicIP": {
+                "dbR": {
                     "type": "string"
                 },
-                "sshPort": {
+                "dbW": {
                     "type": "string"
                 },
-                "vmId": {
+                "evaledTime": {
                     "type": "string"
                 },
-                "vmUserAccount": {
+                "fioR": {
                     "type": "string"
                 },
-                "vmUserPassword": {
+                "fioW": {
                     "type": "string"
-                }
-            }
-        },
-        "mcis.MonResultSimple": {
-            "type": "object",
-            "properties": {
-                "err": {
+                },
+                "memR": {
                     "type": "string"
                 },
-                "metric": {
+                "memW": {
                     "type": "string"
                 },
-                "value": {
+                "rtt": {
                     "type": "string"
                 },
-                "vmId": {
+                "specid": {
                     "type": "string"
                 }
             }
         },
-        "mcis.MonResultSimpleResponse": {
+        "mcis.SpecBenchmarkSample": {
             "type": "object",
             "properties": {
                 "mcisId": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "mcis01"
                 },
-                "mcisMonitoring": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.MonResultSimple"
-                    }
+                "measuredAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "metric": {
+                    "type": "string",
+                    "example": "cpus"
                 },
                 "nsId": {
-                    "type": "string"
-                }
-            }
-        },
-        "mcis.NLBListenerReq": {
-            "type": "object",
-            "properties": {
-                "port": {
-                    "description": "1-65535",
                     "type": "string",
-                    "example": "80"
+                    "example": "ns01"
                 },
-                "protocol": {
-                    "description": "TCP|UDP",
+                "unit": {
                     "type": "string",
-                    "example": "TCP"
+                    "example": "sec"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 9.24
                 }
             }
         },
-        "mcis.Operation": {
+        "mcis.SpecChangeInfo": {
             "type": "object",
             "properties": {
-                "operand": {
-                    "description": "10, 70, 80, 98, ...",
+                "changeId": {
                     "type": "string",
-                    "enum": [
-                        "4",
-                        "8",
-                        ".."
-                    ],
-                    "example": "4"
+                    "example": "cq2a3b4c5d6e7f8g9h0i"
                 },
-                "operator": {
-                    "description": "\u003e=, \u003c=, ==",
+                "estimatedMonthlySavings": {
+                    "type": "number",
+                    "example": 56.94
+                },
+                "finishedTime": {
                     "type": "string",
-                    "enum": [
-                        "\u003e=",
-                        "\u003c=",
-                        "=="
-                    ],
-                    "example": "\u003c="
-                }
-            }
-        },
-        "mcis.ParameterKeyVal": {
-            "type": "object",
-            "properties": {
-                "key": {
-                    "description": "coordinate",
+                    "example": "2024-01-01T00:20:00Z"
+                },
+                "fromSpecId": {
                     "type": "string",
-                    "enum": [
-                        "coordinateClose",
-                        "coordinateWithin",
-                        "coordinateFair"
-                    ],
-                    "example": "coordinateClose"
+                    "example": "aws-ap-northeast-2-t3-medium"
                 },
-                "val": {
-                    "description": "[\"Latitude,Longitude\",\"12,543\",..,\"31,433\"]",
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "startedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Running"
+                },
+                "steps": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "44.146838/-116.411403"
-                    ]
+                        "$ref": "#/definitions/mcis.SpecChangeStep"
+                    }
+                },
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "toSpecId": {
+                    "type": "string",
+                    "example": "aws-ap-northeast-2-t3-small"
                 }
             }
         },
-        "mcis.Policy": {
+        "mcis.SpecChangeInfoList": {
             "type": "object",
             "properties": {
-                "autoAction": {
-                    "$ref": "#/definitions/mcis.AutoAction"
-                },
-                "autoCondition": {
-                    "$ref": "#/definitions/mcis.AutoCondition"
-                },
-                "status": {
-                    "type": "string"
+                "specChanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.SpecChangeInfo"
+                    }
                 }
             }
         },
-        "mcis.PriorityCondition": {
+        "mcis.SpecChangeStep": {
             "type": "object",
             "properties": {
-                "metric": {
+                "error": {
+                    "type": "string"
+                },
+                "finishedTime": {
                     "type": "string",
-                    "enum": [
-                        "location",
-                        "cost",
-                        "random",
-                        "performance",
-                        "latency"
-                    ],
-                    "example": "location"
+                    "example": "2024-01-01T00:05:00Z"
                 },
-                "parameter": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.ParameterKeyVal"
-                    }
+                "newVmId": {
+                    "type": "string",
+                    "example": "g1-4"
                 },
-                "weight": {
+                "oldVmId": {
                     "type": "string",
-                    "enum": [
-                        "0.1",
-                        "0.2",
-                        "..."
-                    ],
-                    "example": "0.3"
+                    "example": "g1-1"
+                },
+                "startedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Succeeded"
                 }
             }
         },
-        "mcis.PriorityInfo": {
+        "mcis.SpecRecommendInfo": {
             "type": "object",
             "properties": {
-                "policy": {
+                "acceleratorCount": {
+                    "type": "integer"
+                },
+                "acceleratorMemoryGB": {
+                    "type": "number"
+                },
+                "acceleratorModel": {
+                    "type": "string"
+                },
+                "acceleratorType": {
+                    "type": "string"
+                },
+                "associatedObjectList": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.PriorityCondition"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "mcis.RegionInfo": {
-            "type": "object",
-            "properties": {
-                "region": {
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "costPerHour": {
+                    "type": "number"
+                },
+                "cspSpecName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "evaluationScore01": {
+                    "type": "number"
+                },
+                "evaluationScore02": {
+                    "type": "number"
+                },
+                "evaluationScore03": {
+                    "type": "number"
+                },
+                "evaluationScore04": {
+                    "type": "number"
+                },
+                "evaluationScore05": {
+                    "type": "number"
+                },
+                "evaluationScore06": {
+                    "type": "number"
+                },
+                "evaluationScore07": {
+                    "type": "number"
+                },
+                "evaluationScore08": {
+                    "type": "number"
+                },
+                "evaluationScore09": {
+                    "type": "number"
+                },
+                "evaluationScore10": {
+                    "type": "number"
+                },
+                "evaluationStatus": {
                     "type": "string"
                 },
-                "zone": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "mcis.RegisterResourceAllResult": {
-            "type": "object",
-            "properties": {
-                "availableConnection": {
-                    "type": "integer"
                 },
-                "elapsedTime": {
+                "isAutoGenerated": {
+                    "type": "boolean"
+                },
+                "maxTotalStorageTiB": {
                     "type": "integer"
                 },
-                "registerationOverview": {
-                    "$ref": "#/definitions/mcis.registerationOverview"
+                "memoryGiB": {
+                    "type": "number"
                 },
-                "registerationResult": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.RegisterResourceResult"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "registeredConnection": {
-                    "type": "integer"
-                }
-            }
-        },
-        "mcis.RegisterResourceResult": {
-            "type": "object",
-            "properties": {
-                "connectionName": {
+                "namespace": {
+                    "description": "required to save in RDB",
                     "type": "string"
                 },
-                "elapsedTime": {
+                "netBwGbps": {
                     "type": "integer"
                 },
-                "registerationOutputs": {
-                    "$ref": "#/definitions/common.IdList"
+                "orderInFilteredResult": {
+                    "type": "integer"
                 },
-                "registerationOverview": {
-                    "$ref": "#/definitions/mcis.registerationOverview"
+                "osType": {
+                    "type": "string"
                 },
-                "systemMessage": {
+                "providerName": {
                     "type": "string"
-                }
-            }
-        },
-        "mcis.RestGetAllBenchmarkRequest": {
-            "type": "object",
-            "properties": {
-                "host": {
+                },
+                "regionName": {
                     "type": "string"
-                }
-            }
-        },
-        "mcis.RestGetAllK8sClusterResponse": {
-            "type": "object",
-            "properties": {
-                "cluster": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.TbK8sClusterInfo"
-                    }
-                }
-            }
-        },
-        "mcis.RestGetAllMcisPolicyResponse": {
-            "type": "object",
-            "properties": {
-                "mcisPolicy": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.McisPolicyInfo"
-                    }
-                }
-            }
-        },
-        "mcis.RestGetAllMcisResponse": {
-            "type": "object",
-            "properties": {
-                "mcis": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.TbMcisInfo"
-                    }
-                }
-            }
-        },
-        "mcis.RestGetAllMcisStatusResponse": {
-            "type": "object",
-            "properties": {
-                "mcis": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcis.McisStatusInfo"
-                    }
-                }
-            }
-        },
-        "mcis.RestGetAllNLBResponse": {
-            "type": "object",
-            "properties": {
-                "nlb": {
+                },
+                "rootDiskSize": {
+                    "type": "string"
+                },
+                "rootDiskType": {
+                    "type": "string"
+                },
+                "scores": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcis.TbNLBInfo"
+                        "$ref": "#/definitions/mcis.CriterionScore"
                     }
-                }
-            }
-        },
-        "mcis.RestGetBenchmarkRequest": {
-            "type": "object",
-            "properties": {
-                "host": {
-                    "type": "string"
+                },
+                "spotCostPerHour": {
+                    "type": "number"
+                },
+                "storageGiB": {
+                    "type": "integer"
+                },
+                "systemLabel": {
+                    "description": "SystemLabel is for describing the MCIR in a keyword (any string can be used) for special System purpose",
+                    "type": "string",
+                    "example": "Managed by CB-Tumblebug"
+                },
+                "totalScore": {
+                    "description": "TotalScore is the weighted average of the normalized scores (1 is the best)",
+                    "type": "number",
+                    "example": 0.87
+                },
+                "vCPU": {
+                    "type": "integer"
                 }
             }
         },
@@ -11596,6 +17452,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/common.KeyValue"
                     }
                 },
+                "marketType": {
+                    "description": "Fields for request (spot market, requires a CB-Spider driver supporting spot VMs)",
+                    "type": "string"
+                },
                 "name": {
                     "description": "Fields for request",
                     "type": "string"
@@ -11649,6 +17509,10 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "spotMaxPrice": {
+                    "description": "max price per hour, \"\" means up to the on-demand price",
+                    "type": "string"
+                },
                 "sshaccessPoint": {
                     "type": "string"
                 },
@@ -11685,6 +17549,82 @@ const docTemplate = `{
                 }
             }
         },
+        "mcis.SpotInterruptionInfo": {
+            "type": "object",
+            "properties": {
+                "detectedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Failed to replace because ..."
+                },
+                "replacementVmId": {
+                    "description": "ReplacementVmId is the ID of the VM added to replace the interrupted VM",
+                    "type": "string",
+                    "example": "g1-4"
+                },
+                "status": {
+                    "description": "Status is the status of the VM when the interruption is detected",
+                    "type": "string",
+                    "example": "Terminated"
+                }
+            }
+        },
+        "mcis.SshCmdOption": {
+            "type": "object",
+            "properties": {
+                "continueOnError": {
+                    "description": "ContinueOnError executes the remaining commands after a failed command (stops at the first failure by default)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "env": {
+                    "description": "Env is environment variables for the commands",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "retry": {
+                    "description": "Retry is the number of retries of a failed command",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 10,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "retryIntervalSec": {
+                    "description": "RetryIntervalSec is the interval between retries in seconds",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 3600,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "sudo": {
+                    "description": "Sudo executes the commands with sudo (requires passwordless sudo)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "timeoutSec": {
+                    "description": "TimeoutSec is the timeout of each command in seconds (0: no timeout)",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
+                },
+                "workDir": {
+                    "description": "WorkDir is the working directory for the commands",
+                    "type": "string",
+                    "example": "/home/cb-user"
+                }
+            }
+        },
         "mcis.SshCmdResult": {
             "type": "object",
             "properties": {
@@ -11695,6 +17635,13 @@ const docTemplate = `{
                     }
                 },
                 "err": {},
+                "exitCode": {
+                    "description": "ExitCode is the exit status of each command (-1 if the command did not exit normally, e.g., timeout)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "mcisId": {
                     "type": "string"
                 },
@@ -11718,6 +17665,27 @@ const docTemplate = `{
                 }
             }
         },
+        "mcis.SshHostKeyInfo": {
+            "type": "object",
+            "properties": {
+                "fingerprint": {
+                    "type": "string",
+                    "example": "SHA256:dgMCTA4PQQWAP1cOgzYhjIFCsRMI5WgXJ+7eB+Ig3Rs"
+                },
+                "firstUsedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "hostKey": {
+                    "description": "HostKey is base64 of the host public key in SSH wire format",
+                    "type": "string"
+                },
+                "keyType": {
+                    "type": "string",
+                    "example": "ssh-ed25519"
+                }
+            }
+        },
         "mcis.StatusCountInfo": {
             "type": "object",
             "properties": {
@@ -11761,9 +17729,122 @@ const docTemplate = `{
                     "description": "CountTotal is for Total VMs",
                     "type": "integer"
                 },
-                "countUndefined": {
-                    "description": "CountUndefined is for counting Undefined",
-                    "type": "integer"
+                "countUndefined": {
+                    "description": "CountUndefined is for counting Undefined",
+                    "type": "integer"
+                }
+            }
+        },
+        "mcis.SubGroupPlacement": {
+            "type": "object",
+            "properties": {
+                "connectionName": {
+                    "type": "string",
+                    "example": "aws-ap-northeast-2"
+                },
+                "costPerHour": {
+                    "type": "number",
+                    "example": 0.0116
+                },
+                "providerName": {
+                    "type": "string",
+                    "example": "aws"
+                },
+                "regionName": {
+                    "type": "string",
+                    "example": "ap-northeast-2"
+                },
+                "score": {
+                    "description": "Score is the total score of the spec among the candidates of the subGroup (1 is the best)",
+                    "type": "number",
+                    "example": 0.87
+                },
+                "specId": {
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
+                },
+                "subGroupName": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "subGroupSize": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "mcis.SubGroupRightSizing": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the recommended action (keep, downsize, upsize)",
+                    "type": "string",
+                    "enum": [
+                        "keep",
+                        "downsize",
+                        "upsize"
+                    ],
+                    "example": "downsize"
+                },
+                "candidates": {
+                    "description": "Candidates are the alternatives for the action (ordered by cost)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.RightSizingSpec"
+                    }
+                },
+                "cpuPeak": {
+                    "description": "CpuPeak and MemPeak are the max of VM peaks (%) in the subGroup",
+                    "type": "number",
+                    "example": 20.1
+                },
+                "currentSpec": {
+                    "$ref": "#/definitions/mcis.RightSizingSpec"
+                },
+                "estimatedMonthlySavings": {
+                    "description": "EstimatedMonthlySavings is MonthlyCostCurrent - MonthlyCostRecommended (negative for upsize)",
+                    "type": "number",
+                    "example": 56.94
+                },
+                "marketType": {
+                    "description": "MarketType is the market of VMs in the subGroup (the cost is evaluated for the market)",
+                    "type": "string",
+                    "example": "spot"
+                },
+                "memPeak": {
+                    "type": "number",
+                    "example": 40.3
+                },
+                "monthlyCostCurrent": {
+                    "description": "MonthlyCostCurrent, MonthlyCostRecommended are the estimated monthly costs of running VMs (CostPerHour x 730h)",
+                    "type": "number",
+                    "example": 113.88
+                },
+                "monthlyCostRecommended": {
+                    "type": "number",
+                    "example": 56.94
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "CPU peak 20.1% or memory peak 40.3% is under 30%"
+                },
+                "recommendedSpec": {
+                    "$ref": "#/definitions/mcis.RightSizingSpec"
+                },
+                "runningVmCount": {
+                    "description": "RunningVmCount is the number of running VMs evaluated in the subGroup",
+                    "type": "integer",
+                    "example": 3
+                },
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "vms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.VmUtilization"
+                    }
                 }
             }
         },
@@ -12518,94 +18599,333 @@ const docTemplate = `{
                     "type": "string",
                     "example": "TCP"
                 },
-                "subGroupId": {
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                },
+                "vms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mcis.TbNLBTargetGroupReq": {
+            "type": "object",
+            "properties": {
+                "port": {
+                    "description": "Listener Port or 1-65535",
+                    "type": "string",
+                    "example": "80"
+                },
+                "protocol": {
+                    "description": "TCP|HTTP|HTTPS",
+                    "type": "string",
+                    "example": "TCP"
+                },
+                "subGroupId": {
+                    "type": "string",
+                    "example": "g1"
+                }
+            }
+        },
+        "mcis.TbScaleOutSubGroupReq": {
+            "type": "object",
+            "required": [
+                "numVMsToAdd"
+            ],
+            "properties": {
+                "numVMsToAdd": {
+                    "description": "Define addtional VMs to scaleOut",
+                    "type": "string",
+                    "example": "2"
+                }
+            }
+        },
+        "mcis.TbSetK8sNodeGroupAutoscalingReq": {
+            "type": "object",
+            "properties": {
+                "onAutoScaling": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcis.TbUpgradeK8sClusterReq": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcis.TbVmDynamicReq": {
+            "type": "object",
+            "required": [
+                "commonImage",
+                "commonSpec"
+            ],
+            "properties": {
+                "commonImage": {
+                    "description": "CommonImage is field for id of a image in common namespace",
+                    "type": "string",
+                    "example": "ubuntu18.04"
+                },
+                "commonSpec": {
+                    "description": "CommonSpec is field for id of a spec in common namespace",
+                    "type": "string",
+                    "example": "aws+ap-northeast-2+t2.small"
+                },
+                "connectionName": {
+                    "description": "if ConnectionName is given, the VM tries to use associtated credential.\nif not, it will use predefined ConnectionName in Spec objects",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Description"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "DynamicVM"
+                },
+                "market": {
+                    "description": "Market is the purchase option of the VM (on-demand if omitted)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.VmMarketOption"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "VM name or subGroup name if is (not empty) \u0026\u0026 (\u003e 0). If it is a group, actual VM name will be generated with -N postfix.",
+                    "type": "string",
+                    "example": "g1-1"
+                },
+                "rootDiskSize": {
+                    "description": "\"default\", Integer (GB): [\"50\", ..., \"1000\"]",
+                    "type": "string",
+                    "default": "default",
+                    "example": "default, 30, 42, ..."
+                },
+                "rootDiskType": {
+                    "description": "\"\", \"default\", \"TYPE1\", AWS: [\"standard\", \"gp2\", \"gp3\"], Azure: [\"PremiumSSD\", \"StandardSSD\", \"StandardHDD\"], GCP: [\"pd-standard\", \"pd-balanced\", \"pd-ssd\", \"pd-extreme\"], ALIBABA: [\"cloud_efficiency\", \"cloud\", \"cloud_essd\"], TENCENT: [\"CLOUD_PREMIUM\", \"CLOUD_SSD\"]",
+                    "type": "string",
+                    "default": "default",
+                    "example": "default, TYPE1, ..."
+                },
+                "subGroupSize": {
+                    "description": "if subGroupSize is (not empty) \u0026\u0026 (\u003e 0), subGroup will be gernetad. VMs will be created accordingly.",
+                    "type": "string",
+                    "default": "1",
+                    "example": "3"
+                },
+                "vmUserPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcis.TbVmInfo": {
+            "type": "object",
+            "properties": {
+                "connectionConfig": {
+                    "$ref": "#/definitions/common.ConnConfig"
+                },
+                "connectionName": {
+                    "type": "string"
+                },
+                "createdTime": {
+                    "description": "Created time",
+                    "type": "string",
+                    "example": "2022-11-10 23:00:00"
+                },
+                "cspViewVmDetail": {
+                    "$ref": "#/definitions/mcis.SpiderVMInfo"
+                },
+                "dataDiskIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idByCSP": {
+                    "description": "CSP managed ID or Name",
+                    "type": "string"
+                },
+                "imageId": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/common.Location"
+                },
+                "market": {
+                    "description": "Market is the purchase option of the VM",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.VmMarketOption"
+                        }
+                    ]
+                },
+                "monAgentStatus": {
+                    "description": "Montoring agent status",
+                    "type": "string",
+                    "example": "[installed, notInstalled, installing, upgrading, uninstalling, unhealthy, unknown, failed]"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "networkAgentStatus": {
+                    "description": "NetworkAgent status",
+                    "type": "string",
+                    "example": "[notInstalled, installing, installed, failed]"
+                },
+                "privateDNS": {
+                    "type": "string"
+                },
+                "privateIP": {
+                    "type": "string"
+                },
+                "publicDNS": {
+                    "type": "string"
+                },
+                "publicIP": {
+                    "type": "string"
+                },
+                "region": {
+                    "description": "AWS, ex) {us-east1, us-east1-c} or {ap-northeast-2}",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.RegionInfo"
+                        }
+                    ]
+                },
+                "rootDeviceName": {
+                    "type": "string"
+                },
+                "rootDiskSize": {
+                    "type": "string"
+                },
+                "rootDiskType": {
+                    "type": "string"
+                },
+                "securityGroupIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "specId": {
+                    "type": "string"
+                },
+                "spotInterruption": {
+                    "description": "SpotInterruption is the interruption of the spot VM detected by CB-Tumblebug",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.SpotInterruptionInfo"
+                        }
+                    ]
+                },
+                "sshHostKeyInfo": {
+                    "description": "SSH host key of the VM recorded on the first connection (trust-on-first-use)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.SshHostKeyInfo"
+                        }
+                    ]
+                },
+                "sshKeyId": {
+                    "type": "string"
+                },
+                "sshPort": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Required by CB-Tumblebug",
+                    "type": "string"
+                },
+                "subGroupId": {
+                    "description": "defined if the VM is in a group",
+                    "type": "string"
+                },
+                "subnetId": {
+                    "type": "string"
+                },
+                "systemMessage": {
+                    "description": "Latest system message such as error message",
                     "type": "string",
-                    "example": "g1"
+                    "example": "Failed because ..."
                 },
-                "vms": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
-        },
-        "mcis.TbNLBTargetGroupReq": {
-            "type": "object",
-            "properties": {
-                "port": {
-                    "description": "Listener Port or 1-65535",
-                    "type": "string",
-                    "example": "80"
+                "targetAction": {
+                    "type": "string"
                 },
-                "protocol": {
-                    "description": "TCP|HTTP|HTTPS",
-                    "type": "string",
-                    "example": "TCP"
+                "targetStatus": {
+                    "type": "string"
                 },
-                "subGroupId": {
-                    "type": "string",
-                    "example": "g1"
-                }
-            }
-        },
-        "mcis.TbScaleOutSubGroupReq": {
-            "type": "object",
-            "required": [
-                "numVMsToAdd"
-            ],
-            "properties": {
-                "numVMsToAdd": {
-                    "description": "Define addtional VMs to scaleOut",
-                    "type": "string",
-                    "example": "2"
-                }
-            }
-        },
-        "mcis.TbSetK8sNodeGroupAutoscalingReq": {
-            "type": "object",
-            "properties": {
-                "onAutoScaling": {
+                "vNetId": {
                     "type": "string"
-                }
-            }
-        },
-        "mcis.TbUpgradeK8sClusterReq": {
-            "type": "object",
-            "properties": {
-                "version": {
+                },
+                "vmUserAccount": {
+                    "type": "string"
+                },
+                "vmUserPassword": {
                     "type": "string"
                 }
             }
         },
-        "mcis.TbVmDynamicReq": {
+        "mcis.TbVmReq": {
             "type": "object",
             "required": [
-                "commonImage",
-                "commonSpec"
+                "connectionName",
+                "imageId",
+                "name",
+                "securityGroupIds",
+                "specId",
+                "sshKeyId",
+                "subnetId",
+                "vNetId"
             ],
             "properties": {
-                "commonImage": {
-                    "description": "CommonImage is field for id of a image in common namespace",
-                    "type": "string",
-                    "example": "ubuntu18.04"
-                },
-                "commonSpec": {
-                    "description": "CommonSpec is field for id of a spec in common namespace",
+                "connectionName": {
                     "type": "string",
-                    "example": "aws+ap-northeast-2+t2.small"
+                    "example": "testcloud01-seoul"
                 },
-                "connectionName": {
-                    "description": "if ConnectionName is given, the VM tries to use associtated credential.\nif not, it will use predefined ConnectionName in Spec objects",
-                    "type": "string"
+                "dataDiskIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "description": {
                     "type": "string",
                     "example": "Description"
                 },
-                "label": {
+                "idByCsp": {
+                    "description": "CSP managed ID or Name (required for option=register)",
                     "type": "string",
-                    "example": "DynamicVM"
+                    "example": "i-014fa6ede6ada0b2c"
+                },
+                "imageId": {
+                    "description": "ImageType        string   ` + "`" + `json:\"imageType\"` + "`" + `",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "market": {
+                    "description": "Market is the purchase option of the VM (on-demand if omitted)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcis.VmMarketOption"
+                        }
+                    ]
                 },
                 "name": {
                     "description": "VM name or subGroup name if is (not empty) \u0026\u0026 (\u003e 0). If it is a group, actual VM name will be generated with -N postfix.",
@@ -12615,65 +18935,67 @@ const docTemplate = `{
                 "rootDiskSize": {
                     "description": "\"default\", Integer (GB): [\"50\", ..., \"1000\"]",
                     "type": "string",
-                    "default": "default",
                     "example": "default, 30, 42, ..."
                 },
                 "rootDiskType": {
-                    "description": "\"\", \"default\", \"TYPE1\", AWS: [\"standard\", \"gp2\", \"gp3\"], Azure: [\"PremiumSSD\", \"StandardSSD\", \"StandardHDD\"], GCP: [\"pd-standard\", \"pd-balanced\", \"pd-ssd\", \"pd-extreme\"], ALIBABA: [\"cloud_efficiency\", \"cloud\", \"cloud_essd\"], TENCENT: [\"CLOUD_PREMIUM\", \"CLOUD_SSD\"]",
+                    "description": "\"\", \"default\", \"TYPE1\", AWS: [\"standard\", \"gp2\", \"gp3\"], Azure: [\"PremiumSSD\", \"StandardSSD\", \"StandardHDD\"], GCP: [\"pd-standard\", \"pd-balanced\", \"pd-ssd\", \"pd-extreme\"], ALIBABA: [\"cloud_efficiency\", \"cloud\", \"cloud_ssd\"], TENCENT: [\"CLOUD_PREMIUM\", \"CLOUD_SSD\"]",
                     "type": "string",
-                    "default": "default",
                     "example": "default, TYPE1, ..."
                 },
+                "securityGroupIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "specId": {
+                    "type": "string"
+                },
+                "sshKeyId": {
+                    "type": "string"
+                },
                 "subGroupSize": {
                     "description": "if subGroupSize is (not empty) \u0026\u0026 (\u003e 0), subGroup will be gernetad. VMs will be created accordingly.",
                     "type": "string",
-                    "default": "1",
                     "example": "3"
                 },
+                "subnetId": {
+                    "type": "string"
+                },
+                "vNetId": {
+                    "type": "string"
+                },
+                "vmUserAccount": {
+                    "type": "string"
+                },
                 "vmUserPassword": {
                     "type": "string"
                 }
             }
         },
-        "mcis.TbVmInfo": {
+        "mcis.TbVmSnapshotReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "aws-ap-southeast-1-snapshot"
+                }
+            }
+        },
+        "mcis.TbVmStatusInfo": {
             "type": "object",
             "properties": {
-                "connectionConfig": {
-                    "$ref": "#/definitions/common.ConnConfig"
-                },
-                "connectionName": {
-                    "type": "string"
-                },
                 "createdTime": {
                     "description": "Created time",
                     "type": "string",
                     "example": "2022-11-10 23:00:00"
                 },
-                "cspViewVmDetail": {
-                    "$ref": "#/definitions/mcis.SpiderVMInfo"
-                },
-                "dataDiskIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "description": {
+                "cspVmId": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "idByCSP": {
-                    "description": "CSP managed ID or Name",
-                    "type": "string"
-                },
-                "imageId": {
-                    "type": "string"
-                },
-                "label": {
-                    "type": "string"
-                },
                 "location": {
                     "$ref": "#/definitions/common.Location"
                 },
@@ -12685,232 +19007,465 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "networkAgentStatus": {
-                    "description": "NetworkAgent status",
-                    "type": "string",
-                    "example": "[notInstalled, installing, installed, failed]"
+                "nativeStatus": {
+                    "type": "string"
                 },
-                "privateDNS": {
+                "privateIp": {
                     "type": "string"
                 },
-                "privateIP": {
+                "publicIp": {
                     "type": "string"
                 },
-                "publicDNS": {
+                "sshPort": {
                     "type": "string"
                 },
-                "publicIP": {
+                "status": {
                     "type": "string"
                 },
-                "region": {
-                    "description": "AWS, ex) {us-east1, us-east1-c} or {ap-northeast-2}",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/mcis.RegionInfo"
-                        }
-                    ]
+                "systemMessage": {
+                    "description": "Latest system message such as error message",
+                    "type": "string",
+                    "example": "Failed because ..."
                 },
-                "rootDeviceName": {
+                "targetAction": {
                     "type": "string"
                 },
-                "rootDiskSize": {
+                "targetStatus": {
                     "type": "string"
+                }
+            }
+        },
+        "mcis.TerminalSessionInfo": {
+            "type": "object",
+            "properties": {
+                "clientIp": {
+                    "type": "string",
+                    "example": "127.0.0.1"
                 },
-                "rootDiskType": {
+                "endedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:10:00Z"
+                },
+                "mcisId": {
+                    "type": "string",
+                    "example": "mcis01"
+                },
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
+                },
+                "recordingFile": {
+                    "description": "RecordingFile is the asciicast v2 recording of the session output",
                     "type": "string"
                 },
-                "securityGroupIds": {
+                "requestedBy": {
+                    "type": "string",
+                    "example": "default"
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "cq2a3b4c5d6e7f8g9h0i"
+                },
+                "startedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Active"
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
+                },
+                "vmId": {
+                    "type": "string",
+                    "example": "g1-1"
+                }
+            }
+        },
+        "mcis.TerminalSessionInfoList": {
+            "type": "object",
+            "properties": {
+                "sessions": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/mcis.TerminalSessionInfo"
                     }
+                }
+            }
+        },
+        "mcis.VmMarketOption": {
+            "type": "object",
+            "properties": {
+                "marketType": {
+                    "type": "string",
+                    "default": "on-demand",
+                    "enum": [
+                        "on-demand",
+                        "spot"
+                    ],
+                    "example": "spot"
+                },
+                "spotInterruptionAction": {
+                    "description": "SpotInterruptionAction is the action when the spot VM is interrupted by the CSP (default: none)",
+                    "type": "string",
+                    "default": "none",
+                    "enum": [
+                        "none",
+                        "replace"
+                    ],
+                    "example": "replace"
                 },
-                "specId": {
-                    "type": "string"
+                "spotMaxPrice": {
+                    "description": "SpotMaxPrice is the maximum price per hour for a spot VM (0 means up to the on-demand price)",
+                    "type": "number",
+                    "example": 0.05
+                }
+            }
+        },
+        "mcis.VmUtilization": {
+            "type": "object",
+            "properties": {
+                "cpuAvg": {
+                    "description": "CpuAvg and CpuPeak are the average and 95th percentile of CPU utilization (%)",
+                    "type": "number",
+                    "example": 12.5
                 },
-                "sshKeyId": {
-                    "type": "string"
+                "cpuPeak": {
+                    "type": "number",
+                    "example": 20.1
                 },
-                "sshPort": {
+                "err": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "Required by CB-Tumblebug",
-                    "type": "string"
+                "memAvg": {
+                    "description": "MemAvg and MemPeak are the average and 95th percentile of memory utilization (%)",
+                    "type": "number",
+                    "example": 35.2
                 },
-                "subGroupId": {
-                    "description": "defined if the VM is in a group",
-                    "type": "string"
+                "memPeak": {
+                    "type": "number",
+                    "example": 40.3
                 },
-                "subnetId": {
-                    "type": "string"
+                "samples": {
+                    "description": "Samples is the number of data points in the window (0 if only on-demand monitoring data is used)",
+                    "type": "integer",
+                    "example": 288
                 },
-                "systemMessage": {
-                    "description": "Latest system message such as error message",
+                "vmId": {
                     "type": "string",
-                    "example": "Failed because ..."
-                },
-                "targetAction": {
-                    "type": "string"
+                    "example": "g1-1"
+                }
+            }
+        },
+        "mcis.WorkflowInfo": {
+            "type": "object",
+            "properties": {
+                "createdTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "targetStatus": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "example": "deploy ceph (master first, then workers)"
                 },
-                "vNetId": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "ceph"
                 },
-                "vmUserAccount": {
-                    "type": "string"
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.WorkflowStep"
+                    }
                 },
-                "vmUserPassword": {
-                    "type": "string"
+                "updatedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "mcis.TbVmReq": {
+        "mcis.WorkflowInfoList": {
+            "type": "object",
+            "properties": {
+                "workflow": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.WorkflowInfo"
+                    }
+                }
+            }
+        },
+        "mcis.WorkflowReq": {
             "type": "object",
             "required": [
-                "connectionName",
-                "imageId",
-                "name",
-                "securityGroupIds",
-                "specId",
-                "sshKeyId",
-                "subnetId",
-                "vNetId"
+                "steps"
             ],
             "properties": {
-                "connectionName": {
+                "description": {
                     "type": "string",
-                    "example": "testcloud01-seoul"
+                    "example": "deploy ceph (master first, then workers)"
                 },
-                "dataDiskIds": {
+                "name": {
+                    "description": "Name is required to create a workflow (ignored to update a workflow)",
+                    "type": "string",
+                    "example": "ceph"
+                },
+                "steps": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/mcis.WorkflowStep"
                     }
-                },
-                "description": {
+                }
+            }
+        },
+        "mcis.WorkflowRunInfo": {
+            "type": "object",
+            "properties": {
+                "finishedTime": {
                     "type": "string",
-                    "example": "Description"
+                    "example": "2024-01-01T00:10:00Z"
                 },
-                "idByCsp": {
-                    "description": "CSP managed ID or Name (required for option=register)",
+                "mcisId": {
                     "type": "string",
-                    "example": "i-014fa6ede6ada0b2c"
+                    "example": "mcis01"
                 },
-                "imageId": {
-                    "description": "ImageType        string   ` + "`" + `json:\"imageType\"` + "`" + `",
-                    "type": "string"
+                "nsId": {
+                    "type": "string",
+                    "example": "ns01"
                 },
-                "label": {
-                    "type": "string"
+                "runId": {
+                    "type": "string",
+                    "example": "cq2a3b4c5d6e7f8g9h0i"
                 },
-                "name": {
-                    "description": "VM name or subGroup name if is (not empty) \u0026\u0026 (\u003e 0). If it is a group, actual VM name will be generated with -N postfix.",
+                "startedTime": {
                     "type": "string",
-                    "example": "g1-1"
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "rootDiskSize": {
-                    "description": "\"default\", Integer (GB): [\"50\", ..., \"1000\"]",
+                "status": {
                     "type": "string",
-                    "example": "default, 30, 42, ..."
+                    "example": "Running"
                 },
-                "rootDiskType": {
-                    "description": "\"\", \"default\", \"TYPE1\", AWS: [\"standard\", \"gp2\", \"gp3\"], Azure: [\"PremiumSSD\", \"StandardSSD\", \"StandardHDD\"], GCP: [\"pd-standard\", \"pd-balanced\", \"pd-ssd\", \"pd-extreme\"], ALIBABA: [\"cloud_efficiency\", \"cloud\", \"cloud_ssd\"], TENCENT: [\"CLOUD_PREMIUM\", \"CLOUD_SSD\"]",
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.WorkflowStepRunInfo"
+                    }
+                },
+                "workflowName": {
                     "type": "string",
-                    "example": "default, TYPE1, ..."
+                    "example": "ceph"
+                }
+            }
+        },
+        "mcis.WorkflowRunInfoList": {
+            "type": "object",
+            "properties": {
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.WorkflowRunInfo"
+                    }
+                }
+            }
+        },
+        "mcis.WorkflowRunReq": {
+            "type": "object",
+            "required": [
+                "workflowName"
+            ],
+            "properties": {
+                "workflowName": {
+                    "type": "string",
+                    "example": "ceph"
+                }
+            }
+        },
+        "mcis.WorkflowStep": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "command": {
+                    "description": "Command to execute (built-in functions and $$Var(stepId.outputName) can be used).\nA variable is quoted for shell unless escape=none is given: $$Var(stepId.outputName, escape=none)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "echo $$Var(install-master.masterIp)"
+                    ]
                 },
-                "securityGroupIds": {
+                "continueOnError": {
+                    "description": "ContinueOnError executes the remaining commands after a failed command (stops at the first failure by default)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "dependsOn": {
+                    "description": "DependsOn is the steps to be succeeded before this step",
                     "type": "array",
                     "items": {
                         "type": "string"
+                    },
+                    "example": [
+                        "install-master"
+                    ]
+                },
+                "env": {
+                    "description": "Env is environment variables for the commands",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 },
-                "specId": {
+                "id": {
+                    "type": "string",
+                    "example": "install-master"
+                },
+                "label": {
                     "type": "string"
                 },
-                "sshKeyId": {
+                "outputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.WorkflowStepOutput"
+                    }
+                },
+                "retry": {
+                    "description": "Retry is the number of retries of a failed command",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 10,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "retryIntervalSec": {
+                    "description": "RetryIntervalSec is the interval between retries in seconds",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 3600,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "scriptName": {
+                    "description": "ScriptName of the script library to execute instead of Command",
                     "type": "string"
                 },
-                "subGroupSize": {
-                    "description": "if subGroupSize is (not empty) \u0026\u0026 (\u003e 0), subGroup will be gernetad. VMs will be created accordingly.",
+                "scriptParams": {
+                    "description": "ScriptParams is parameters of the script ($$Var(stepId.outputName) can be used, always passed as it is)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "subGroupId": {
+                    "description": "Target VMs of the step: VMs in SubGroupId, VmId, or VMs with Label (all VMs in MCIS if none is given)",
                     "type": "string",
-                    "example": "3"
+                    "example": "g1"
                 },
-                "subnetId": {
-                    "type": "string"
+                "sudo": {
+                    "description": "Sudo executes the commands with sudo (requires passwordless sudo)",
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
                 },
-                "vNetId": {
-                    "type": "string"
+                "timeoutSec": {
+                    "description": "TimeoutSec is the timeout of each command in seconds (0: no timeout)",
+                    "type": "integer",
+                    "default": 0,
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
                 },
-                "vmUserAccount": {
-                    "type": "string"
+                "userName": {
+                    "type": "string",
+                    "example": "cb-user"
                 },
-                "vmUserPassword": {
+                "vmId": {
                     "type": "string"
+                },
+                "workDir": {
+                    "description": "WorkDir is the working directory for the commands",
+                    "type": "string",
+                    "example": "/home/cb-user"
                 }
             }
         },
-        "mcis.TbVmSnapshotReq": {
+        "mcis.WorkflowStepOutput": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
+                "cmdIndex": {
+                    "description": "CmdIndex is the index of the command whose stdout is captured",
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 0
+                },
                 "name": {
+                    "description": "Name of the variable (referenced by $$Var(stepId.name) in later steps)",
                     "type": "string",
-                    "example": "aws-ap-southeast-1-snapshot"
+                    "example": "masterIp"
+                },
+                "regex": {
+                    "description": "Regex to extract the value from stdout (the first capture group, or the whole match). Trimmed stdout if empty.",
+                    "type": "string",
+                    "example": "token: (\\S+)"
+                },
+                "separator": {
+                    "description": "Separator to join values from multiple VMs (ordered by VM ID)",
+                    "type": "string",
+                    "default": ",",
+                    "example": ","
                 }
             }
         },
-        "mcis.TbVmStatusInfo": {
+        "mcis.WorkflowStepRunInfo": {
             "type": "object",
             "properties": {
-                "createdTime": {
-                    "description": "Created time",
-                    "type": "string",
-                    "example": "2022-11-10 23:00:00"
-                },
-                "cspVmId": {
-                    "type": "string"
-                },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "location": {
-                    "$ref": "#/definitions/common.Location"
-                },
-                "monAgentStatus": {
-                    "description": "Montoring agent status",
+                "finishedTime": {
                     "type": "string",
-                    "example": "[installed, notInstalled, failed]"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "nativeStatus": {
-                    "type": "string"
+                    "example": "2024-01-01T00:01:00Z"
                 },
-                "privateIp": {
-                    "type": "string"
+                "outputs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "publicIp": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcis.SshCmdResult"
+                    }
                 },
-                "sshPort": {
-                    "type": "string"
+                "startedTime": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
                 "status": {
-                    "type": "string"
-                },
-                "systemMessage": {
-                    "description": "Latest system message such as error message",
                     "type": "string",
-                    "example": "Failed because ..."
+                    "example": "Succeeded"
                 },
-                "targetAction": {
-                    "type": "string"
+                "stepId": {
+                    "type": "string",
+                    "example": "install-master"
                 },
-                "targetStatus": {
-                    "type": "string"
+                "vmErr": {
+                    "description": "VmErr is error message for each VM (SshCmdResult.Err is not kept in the record)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -209,3 +209,93 @@ func RestPutNs(c echo.Context) error {
 	content, err := common.UpdateNs(c.Param("nsId"), u)
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
+
+// RestPutSecret godoc
+// @ID PutSecret
+// @Summary Create or update a namespace secret
+// @Description Create or update a namespace secret. The secret can be used in remote commands by $$Func(GetSecret(key=...)).
+// @Description The value is never returned by the API and is masked in logs and command results,
+// @Description but it is stored in plaintext in the key-value store of CB-Tumblebug (protect access to the store).
+// @Tags [Namespace] Namespace management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param secretKey path string true "Secret key" default(repoToken)
+// @Param secretReq body common.SecretReq true "Value and description of the secret"
+// @Success 200 {object} common.SecretInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/secret/{secretKey} [put]
+func RestPutSecret(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	if err := Validate(c, []string{"nsId", "secretKey"}); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	nsId := c.Param("nsId")
+	secretKey := c.Param("secretKey")
+
+	u := &common.SecretReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := common.PutSecret(nsId, secretKey, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllSecret godoc
+// @ID GetAllSecret
+// @Summary List namespace secrets
+// @Description List namespace secrets (keys and descriptions only)
+// @Tags [Namespace] Namespace management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Success 200 {object} common.SecretInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/secret [get]
+func RestGetAllSecret(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	if err := Validate(c, []string{"nsId"}); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	nsId := c.Param("nsId")
+
+	content, err := common.ListSecret(nsId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelSecret godoc
+// @ID DelSecret
+// @Summary Delete a namespace secret
+// @Description Delete a namespace secret
+// @Tags [Namespace] Namespace management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param secretKey path string true "Secret key" default(repoToken)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/secret/{secretKey} [delete]
+func RestDelSecret(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	if err := Validate(c, []string{"nsId", "secretKey"}); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	nsId := c.Param("nsId")
+	secretKey := c.Param("secretKey")
+
+	err := common.DelSecret(nsId, secretKey)
+	content := map[string]string{"message": "The secret " + secretKey + " has been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
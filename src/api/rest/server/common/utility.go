@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/cloud-barista/cb-tumblebug/src/core/common"
 	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
@@ -32,6 +33,9 @@ import (
 
 var validate *validator.Validate
 
+// metricsHandler serves metrics registered in the default Prometheus registry
+var metricsHandler = promhttp.Handler()
+
 func init() {
 	validate = validator.New()
 }
@@ -92,6 +96,21 @@ func RestGetReadyz(c echo.Context) error {
 	return c.JSON(http.StatusOK, &message)
 }
 
+// RestGetMetrics godoc
+// @ID GetMetrics
+// @Summary Get metrics of CB-Tumblebug in Prometheus format
+// @Description Get metrics of CB-Tumblebug in Prometheus exposition format
+// @Description (API requests/latency by route, in-flight/failed requests, CB-Spider call latency and cache hit,
+// @Description VM counts by status per namespace, MCIS auto-control policy executions)
+// @Tags [Admin] System management
+// @Produce  plain
+// @Success 200 {string} string "Metrics in Prometheus exposition format"
+// @Router /metrics [get]
+func RestGetMetrics(c echo.Context) error {
+	metricsHandler.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
 // RestCheckHTTPVersion godoc
 // @ID CheckHTTPVersion
 // @Summary Check HTTP version of incoming request
@@ -164,3 +164,330 @@ func RestGetBenchmark(c echo.Context) error {
 	content, err := mcis.CoreGetBenchmark(nsId, mcisId, action, req.Host)
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
+
+// RestPostLatency godoc
+// @ID PostLatency
+// @Summary Import latencies between regions
+// @Description Import measured latencies between regions into the latency store. Each latency is added as a sample to the moving average of recent samples.
+// @Description Measured latencies are used by recommendation and placement instead of the static latency map (assets/cloudlatencymap.csv).
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param latencyImportReq body mcis.LatencyImportReq true "Latencies between regions ({providerName}-{regionName})"
+// @Success 200 {object} mcis.LatencyInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /latency [post]
+func RestPostLatency(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	req := &mcis.LatencyImportReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.ImportLatency(req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllLatency godoc
+// @ID GetAllLatency
+// @Summary List measured latencies between regions
+// @Description List latencies in the latency store measured by benchmarks (mrtt) or imported
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param region query string false "List only latencies from/to the region" default(aws-ap-northeast-2)
+// @Success 200 {object} mcis.LatencyInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /latency [get]
+func RestGetAllLatency(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	region := c.QueryParam("region")
+
+	content, err := mcis.ListLatency(region)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetLatency godoc
+// @ID GetLatency
+// @Summary Get the latency between two regions
+// @Description Get the latency between two regions (the measured latency first, and the static latency map otherwise)
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param src path string true "Source region" default(aws-ap-northeast-2)
+// @Param dest path string true "Destination region" default(gcp-asia-northeast3)
+// @Success 200 {object} mcis.LatencyInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /latency/{src}/{dest} [get]
+func RestGetLatency(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	src := c.Param("src")
+	dest := c.Param("dest")
+
+	content, err := mcis.GetLatencyInfo(src, dest)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelAllLatency godoc
+// @ID DelAllLatency
+// @Summary Delete all measured latencies
+// @Description Delete all latencies in the latency store (the static latency map is used again)
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /latency [delete]
+func RestDelAllLatency(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	err := mcis.DelAllLatency()
+	content := map[string]string{"message": "All measured latencies have been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllSpecBenchmark godoc
+// @ID GetAllSpecBenchmark
+// @Summary List benchmark results of specs
+// @Description List benchmark results (recent samples per metric) of specs and scores aggregated from them.
+// @Description Score of each metric is relative to the median of benchmarked specs (100), and the overall score is applied to
+// @Description evaluationScore01 of the spec (evaluationScore02 ~ 10 for cpus, cpum, memR, memW, fioR, fioW, dbR, dbW, rtt) for the performance priority in recommendation.
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param regionName query string false "List only specs in the region" default(ap-northeast-2)
+// @Success 200 {object} mcis.SpecBenchmarkHistoryList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /specBenchmark [get]
+func RestGetAllSpecBenchmark(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	regionName := c.QueryParam("regionName")
+
+	content, err := mcis.ListSpecBenchmarkHistory(regionName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetSpecBenchmark godoc
+// @ID GetSpecBenchmark
+// @Summary Get benchmark results of a spec
+// @Description Get benchmark results (recent samples per metric) of a spec and scores aggregated from them
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param specId path string true "Spec ID" default(aws+ap-northeast-2+t2.small)
+// @Success 200 {object} mcis.SpecBenchmarkHistory
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /specBenchmark/{specId} [get]
+func RestGetSpecBenchmark(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	specId := c.Param("specId")
+
+	content, err := mcis.GetSpecBenchmarkHistory(specId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelAllSpecBenchmark godoc
+// @ID DelAllSpecBenchmark
+// @Summary Delete benchmark results of all specs
+// @Description Delete benchmark results of all specs (scores already applied to specs are kept until specs are reloaded)
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /specBenchmark [delete]
+func RestDelAllSpecBenchmark(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	err := mcis.DelAllSpecBenchmarkHistory()
+	content := map[string]string{"message": "All benchmark results of specs have been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostBenchmarkSuite godoc
+// @ID PostBenchmarkSuite
+// @Summary Register a custom benchmark suite
+// @Description Register (or update) a custom benchmark suite, which is executed by remote commands to VMs.
+// @Description The output of the last run command is parsed by the pattern (regular expression with a capture group) of each metric.
+// @Description A pair suite runs from each VM to every other VM, and {{targetIp}}, {{targetPublicIp}}, {{targetPrivateIp}}, {{targetVmId}} in run commands are replaced with those of the target VM.
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param benchmarkSuite body mcis.BenchmarkSuite true "Benchmark suite"
+// @Success 200 {object} mcis.BenchmarkSuite
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /benchmarkSuite [post]
+func RestPostBenchmarkSuite(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	req := &mcis.BenchmarkSuite{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.RegisterBenchmarkSuite(req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllBenchmarkSuite godoc
+// @ID GetAllBenchmarkSuite
+// @Summary List benchmark suites
+// @Description List built-in (sysbench-cpu, sysbench-mem, fio, iperf3, ping) and custom benchmark suites
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} mcis.BenchmarkSuiteList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /benchmarkSuite [get]
+func RestGetAllBenchmarkSuite(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	content, err := mcis.ListBenchmarkSuite()
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetBenchmarkSuite godoc
+// @ID GetBenchmarkSuite
+// @Summary Get a benchmark suite
+// @Description Get a benchmark suite (built-in or custom)
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param suiteName path string true "Benchmark suite name" default(sysbench-cpu)
+// @Success 200 {object} mcis.BenchmarkSuite
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /benchmarkSuite/{suiteName} [get]
+func RestGetBenchmarkSuite(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	suiteName := c.Param("suiteName")
+
+	content, err := mcis.GetBenchmarkSuite(suiteName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelBenchmarkSuite godoc
+// @ID DelBenchmarkSuite
+// @Summary Delete a custom benchmark suite
+// @Description Delete a custom benchmark suite (built-in suites cannot be deleted)
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param suiteName path string true "Benchmark suite name" default(my-bench)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /benchmarkSuite/{suiteName} [delete]
+func RestDelBenchmarkSuite(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	suiteName := c.Param("suiteName")
+
+	err := mcis.DelBenchmarkSuite(suiteName)
+	content := map[string]string{"message": "The benchmark suite " + suiteName + " has been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostRunBenchmarkSuite godoc
+// @ID PostRunBenchmarkSuite
+// @Summary Run a benchmark suite in MCIS
+// @Description Run a benchmark suite in VMs of MCIS (install commands, then run commands in each VM or each pair of VMs) and get parsed metrics
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param benchmarkSuiteRunReq body mcis.BenchmarkSuiteRunReq true "Benchmark suite to run"
+// @Success 200 {object} mcis.BenchmarkSuiteResult
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/benchmarkSuite/mcis/{mcisId} [post]
+func RestPostRunBenchmarkSuite(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.BenchmarkSuiteRunReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.RunBenchmarkSuite(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostNetworkMeshBenchmark godoc
+// @ID PostNetworkMeshBenchmark
+// @Summary Run full-mesh network benchmark between VMs in MCIS
+// @Description Measure latency, jitter and packet loss (ping) and optionally throughput (iperf3) between every pair of VMs
+// @Description (or representatives of subGroups) in MCIS. The result includes matrices ([source][target], -1 for unmeasured links)
+// @Description and the worst links with issues (unreachable, packetLoss, highLatency, highJitter, lowThroughput compared to medians).
+// @Description Measured latencies between regions are also recorded to the latency store.
+// @Tags [Infra service] MCIS Performance benchmarking (WIP)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param networkMeshReq body mcis.NetworkMeshReq true "Options of full-mesh network benchmark"
+// @Success 200 {object} mcis.NetworkMeshResult
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/benchmarkMesh/mcis/{mcisId} [post]
+func RestPostNetworkMeshBenchmark(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.NetworkMeshReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.RunNetworkMeshBenchmark(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
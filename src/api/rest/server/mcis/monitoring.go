@@ -16,6 +16,7 @@ package mcis
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/cloud-barista/cb-tumblebug/src/core/common"
 	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
@@ -53,6 +54,67 @@ func RestPostInstallMonitorAgentToMcis(c echo.Context) error {
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
 
+// RestPostUpgradeMonitorAgentToMcis godoc
+// @ID PostUpgradeMonitorAgentToMcis
+// @Summary Upgrade monitoring agent (CB-Dragonfly agent) of MCIS
+// @Description Upgrade monitoring agent (CB-Dragonfly agent) of VMs in MCIS (VMs with installed, unhealthy or unknown agent status)
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param mcisInfo body mcis.McisCmdReq true "Details for an MCIS object"
+// @Success 200 {object} mcis.AgentInstallContentWrapper
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/upgrade/mcis/{mcisId} [post]
+func RestPostUpgradeMonitorAgentToMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.McisCmdReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	content, err := mcis.UpgradeMonitorAgentToMcis(nsId, mcisId, common.StrMCIS, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostUninstallMonitorAgentFromMcis godoc
+// @ID PostUninstallMonitorAgentFromMcis
+// @Summary Uninstall monitoring agent (CB-Dragonfly agent) from MCIS
+// @Description Uninstall monitoring agent (CB-Dragonfly agent) from VMs in MCIS.
+// @Description InstallMonAgent option of the MCIS will be set to "no" to prevent automatic reinstallation.
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param mcisInfo body mcis.McisCmdReq true "Details for an MCIS object"
+// @Success 200 {object} mcis.AgentInstallContentWrapper
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/uninstall/mcis/{mcisId} [post]
+func RestPostUninstallMonitorAgentFromMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.McisCmdReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	content, err := mcis.UninstallMonitorAgentFromMcis(nsId, mcisId, common.StrMCIS, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
 // RestPutMonitorAgentStatusInstalled godoc
 // @ID PutMonitorAgentStatusInstalled
 // @Summary Set monitoring agent (CB-Dragonfly agent) installation status installed (for Windows VM only)
@@ -96,6 +158,7 @@ func RestPutMonitorAgentStatusInstalled(c echo.Context) error {
 // @Param nsId path string true "Namespace ID" default(ns01)
 // @Param mcisId path string true "MCIS ID" default(mcis01)
 // @Param metric path string true "Metric type: cpu, memory, disk, network"
+// @Param source query string false "Source of monitoring data (dragonfly: CB-Dragonfly agent, agentless: SSH-based collector)" Enums(dragonfly, agentless) default(dragonfly)
 // @Success 200 {object} mcis.MonResultSimpleResponse
 // @Failure 404 {object} common.SimpleMsg
 // @Failure 500 {object} common.SimpleMsg
@@ -114,6 +177,143 @@ func RestGetMonitorData(c echo.Context) error {
 		return common.EndRequestWithLog(c, reqID, err, nil)
 	}
 
+	if c.QueryParam("source") == mcis.MonSourceAgentless {
+		content, err := mcis.GetAgentlessMonitoringData(nsId, mcisId, metric)
+		return common.EndRequestWithLog(c, reqID, err, content)
+	}
+
 	content, err := mcis.GetMonitoringData(nsId, mcisId, metric)
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
+
+// RestGetMonitorHistory godoc
+// @ID GetMonitorHistory
+// @Summary Get historical monitoring data of MCIS with time range, step and aggregation
+// @Description Get historical monitoring data of MCIS as per-VM and per-subGroup series (downsampled by step and aggregation) suitable for charting.
+// @Description Backed by Tumblebug's own store (agentless) or CB-Dragonfly historical API (dragonfly).
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param metric path string true "Metric type" Enums(cpu, mem, disk, net)
+// @Param source query string false "Source of monitoring data (default: agentless if enabled, otherwise dragonfly)" Enums(agentless, dragonfly)
+// @Param start query string false "Start time of the range (RFC3339, default: 1 hour before end)" default(2024-03-01T11:00:00Z)
+// @Param end query string false "End time of the range (RFC3339, default: now)" default(2024-03-01T12:00:00Z)
+// @Param step query int false "Step in seconds (default: 60)" default(60)
+// @Param aggregation query string false "Aggregation in each step (default: avg)" Enums(avg, min, max, sum, last)
+// @Param subGroupId query string false "subGroupId to query only VMs in the subGroup" default(g1)
+// @Success 200 {object} mcis.MonitoringHistoryResponse
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}/history [get]
+func RestGetMonitorHistory(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.MonitoringHistoryReq{
+		Metric:      c.Param("metric"),
+		Source:      c.QueryParam("source"),
+		Start:       c.QueryParam("start"),
+		End:         c.QueryParam("end"),
+		Aggregation: c.QueryParam("aggregation"),
+		SubGroupId:  c.QueryParam("subGroupId"),
+	}
+	if step := c.QueryParam("step"); step != "" {
+		stepSec, err := strconv.Atoi(step)
+		if err != nil {
+			return common.EndRequestWithLog(c, reqID, err, nil)
+		}
+		req.StepSec = stepSec
+	}
+
+	content, err := mcis.GetMonitoringHistory(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostAgentlessMonitoring godoc
+// @ID PostAgentlessMonitoring
+// @Summary Enable agentless (SSH-based) monitoring for MCIS
+// @Description Enable agentless monitoring that periodically samples cpu, mem, disk, net of VMs via SSH (bastion supported) without CB-Dragonfly agents.
+// @Description Collected data can be retrieved by GET /ns/{nsId}/monitoring/mcis/{mcisId}/metric/{metric}?source=agentless
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param agentlessMonitoringReq body mcis.AgentlessMonitoringReq true "Options for agentless monitoring"
+// @Success 200 {object} mcis.AgentlessMonitoringInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/agentless/mcis/{mcisId} [post]
+func RestPostAgentlessMonitoring(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.AgentlessMonitoringReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.EnableAgentlessMonitoring(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAgentlessMonitoring godoc
+// @ID GetAgentlessMonitoring
+// @Summary Get agentless (SSH-based) monitoring configuration of MCIS
+// @Description Get agentless (SSH-based) monitoring configuration of MCIS
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Success 200 {object} mcis.AgentlessMonitoringInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/agentless/mcis/{mcisId} [get]
+func RestGetAgentlessMonitoring(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	content, err := mcis.GetAgentlessMonitoringInfo(nsId, mcisId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelAgentlessMonitoring godoc
+// @ID DelAgentlessMonitoring
+// @Summary Disable agentless (SSH-based) monitoring for MCIS
+// @Description Disable agentless (SSH-based) monitoring for MCIS and remove the collected data
+// @Tags [Infra service] MCIS Resource monitor (for developer)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/monitoring/agentless/mcis/{mcisId} [delete]
+func RestDelAgentlessMonitoring(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	err := mcis.DisableAgentlessMonitoring(nsId, mcisId)
+	content := map[string]string{"message": "Disabled agentless monitoring for the mcis " + mcisId}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
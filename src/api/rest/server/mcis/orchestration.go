@@ -55,6 +55,38 @@ func RestPostMcisPolicy(c echo.Context) error {
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
 
+// RestPostMcisPolicySimulation godoc
+// @ID PostMcisPolicySimulation
+// @Summary Simulate MCIS Automation policy
+// @Description Simulate MCIS Automation policy with a given metric time series (synthetic or recorded).
+// @Description Returns the sequence of policy states and actions that the controller would take, without executing anything.
+// @Tags [Infra service] MCIS Auto control policy management (WIP)
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param mcisPolicySimulationReq body mcis.McisPolicySimulationReq true "MCIS automation policy and metric time series for simulation"
+// @Success 200 {object} mcis.McisPolicySimulationResult
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/policy/mcis/{mcisId}/simulate [post]
+func RestPostMcisPolicySimulation(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.McisPolicySimulationReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.SimulateMcisPolicy(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
 // RestGetMcisPolicy godoc
 // @ID GetMcisPolicy
 // @Summary Get MCIS Policy
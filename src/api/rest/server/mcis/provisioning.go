@@ -114,6 +114,8 @@ func RestPostSystemMcis(c echo.Context) error {
 // @ID PostMcisDynamic
 // @Summary Create MCIS Dynamically
 // @Description Create MCIS Dynamically from common spec and image
+// @Description Set market.marketType to spot (with spotMaxPrice) for spot VMs. If market.spotInterruptionAction is replace,
+// @Description an interrupted spot VM (stopped or terminated by the CSP) is replaced by a new VM in the subGroup (scale-out).
 // @Tags [Infra service] MCIS Provisioning management
 // @Accept  json
 // @Produce  json
@@ -26,11 +26,15 @@ import (
 // @ID RecommendVm
 // @Summary Recommend MCIS plan (filter and priority)
 // @Description Recommend MCIS plan (filter and priority) Find details from https://github.com/cloud-barista/cb-tumblebug/discussions/1234
+// @Description Specs are ranked by the weighted sum of normalized scores (0~1) of priority policies (weight is 1 if omitted),
+// @Description and the score of each criterion is included in the result. Ties are broken by lower cost and then spec ID.
+// @Description Spot prices (spotCostPerHour) are used for the cost priority with the parameter {"key": "market", "val": ["spot"]} (on-demand price if unknown).
+// @Description The carbon priority ranks specs by the estimated gCO2eq per hour (carbon intensity of the region x estimated power of the spec, see /carbonIntensity).
 // @Tags [Infra service] MCIS Provisioning management
 // @Accept  json
 // @Produce  json
 // @Param deploymentPlan body mcis.DeploymentPlan false "Recommend MCIS plan (filter and priority)"
-// @Success 200 {object} []mcir.TbSpecInfo
+// @Success 200 {object} []mcis.SpecRecommendInfo
 // @Failure 404 {object} common.SimpleMsg
 // @Failure 500 {object} common.SimpleMsg
 // @Router /mcisRecommendVm [post]
@@ -50,6 +54,270 @@ func RestRecommendVm(c echo.Context) error {
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
 
+// RestPostMcisPlacement godoc
+// @ID PostMcisPlacement
+// @Summary Optimize placement of a whole MCIS across regions and providers
+// @Description Find the optimized assignment of specs (and regions) for all subGroups of MCIS under the constraints
+// @Description (minimum number of distinct providers, maximum inter-region latency, total budget per hour, allowed regions, and VM quota per provider).
+// @Description Candidate specs of each subGroup are filtered and ranked as in mcisRecommendVm. The result includes mcisDynamicReq to create the MCIS by /ns/{nsId}/mcisDynamic.
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param mcisPlacementReq body mcis.McisPlacementReq true "Subgroups and constraints of MCIS"
+// @Success 200 {object} mcis.McisPlacementResult
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /mcisPlacement [post]
+func RestPostMcisPlacement(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := common.SystemCommonNs
+
+	u := &mcis.McisPlacementReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.OptimizeMcisPlacement(nsId, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllSpecAvailability godoc
+// @ID GetAllSpecAvailability
+// @Summary List availabilities of specs from provisioning results
+// @Description List availability scores of specs with recent provisioning failures (reason: capacity, quota, unsupported, other) and successes.
+// @Description The score (1 is available) is decreased by failures and recovered over time. Specs with low scores are filtered out (availabilityScore filter, default >= 0.3)
+// @Description or down-ranked (availability priority) in recommendation.
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param connectionName query string false "List only specs of the connection" default(aws-ap-northeast-2)
+// @Success 200 {object} mcis.SpecAvailabilityInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /specAvailability [get]
+func RestGetAllSpecAvailability(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	connectionName := c.QueryParam("connectionName")
+
+	content, err := mcis.ListSpecAvailability(connectionName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelAllSpecAvailability godoc
+// @ID DelAllSpecAvailability
+// @Summary Delete all availabilities of specs
+// @Description Delete all recorded provisioning results (all specs are regarded as available)
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /specAvailability [delete]
+func RestDelAllSpecAvailability(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	err := mcis.DelAllSpecAvailability()
+	content := map[string]string{"message": "All availabilities of specs have been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPutCarbonIntensity godoc
+// @ID PutCarbonIntensity
+// @Summary Update carbon intensities of regions
+// @Description Update carbon intensities (gCO2eq/kWh) of regions overriding assets/carbonintensity.yaml.
+// @Description The carbon priority of recommendation ranks specs by the estimated gCO2eq per hour (carbon intensity of the region x estimated power of the spec).
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param carbonIntensityUpdateReq body mcis.CarbonIntensityUpdateReq true "Carbon intensities of regions"
+// @Success 200 {object} mcis.RegionCarbonIntensityList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /carbonIntensity [put]
+func RestPutCarbonIntensity(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	req := &mcis.CarbonIntensityUpdateReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.UpdateCarbonIntensity(req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllCarbonIntensity godoc
+// @ID GetAllCarbonIntensity
+// @Summary List carbon intensities of regions
+// @Description List carbon intensities (gCO2eq/kWh) of regions from assets/carbonintensity.yaml and updates via API, with the power model of specs
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param providerName query string false "List only regions of the provider" default(aws)
+// @Success 200 {object} mcis.RegionCarbonIntensityList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /carbonIntensity [get]
+func RestGetAllCarbonIntensity(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	providerName := c.QueryParam("providerName")
+
+	content, err := mcis.ListCarbonIntensity(providerName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelAllCarbonIntensity godoc
+// @ID DelAllCarbonIntensity
+// @Summary Delete all carbon intensities updated via API
+// @Description Delete all carbon intensities updated via API (assets/carbonintensity.yaml is used again)
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /carbonIntensity [delete]
+func RestDelAllCarbonIntensity(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+
+	err := mcis.DelAllCarbonIntensity()
+	content := map[string]string{"message": "All carbon intensities updated via API have been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostRightSizing godoc
+// @ID PostRightSizing
+// @Summary Recommend right-sized specs for subGroups of MCIS
+// @Description Recommend smaller (downsize) or larger (upsize) specs for each subGroup by the CPU and memory utilization (95th percentile) of running VMs
+// @Description in a monitoring window (monitoring history, or on-demand monitoring data if no history) and specs of the same connection.
+// @Description Estimated monthly savings are calculated from CostPerHour (SpotCostPerHour for spot VMs) x 730h x the number of running VMs.
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param rightSizingReq body mcis.RightSizingReq true "Monitoring window and utilization thresholds"
+// @Success 200 {object} mcis.RightSizingResult
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/rightSizing/mcis/{mcisId} [post]
+func RestPostRightSizing(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.RightSizingReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.GetRightSizing(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostApplyRightSizing godoc
+// @ID PostApplyRightSizing
+// @Summary Apply right-sizing to a subGroup of MCIS by a rolling spec change
+// @Description Start a rolling spec change of a subGroup to the recommended spec (or targetSpecId) in background.
+// @Description Running VMs are replaced one by one: a VM with the new spec is added to the subGroup, and the old VM is terminated after the new VM is running.
+// @Description The change stops at the first failure (the old VM is kept). Check the progress by GET /ns/{nsId}/specChange/{changeId}
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param rightSizingApplyReq body mcis.RightSizingApplyReq true "SubGroup (required) and target spec (optional)"
+// @Success 200 {object} mcis.SpecChangeInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/rightSizing/mcis/{mcisId}/apply [post]
+func RestPostApplyRightSizing(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.RightSizingApplyReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.ApplyRightSizing(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllSpecChange godoc
+// @ID GetAllSpecChange
+// @Summary List spec changes
+// @Description List rolling spec changes in a namespace (the latest first)
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId query string false "List only spec changes of the MCIS" default(mcis01)
+// @Success 200 {object} mcis.SpecChangeInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/specChange [get]
+func RestGetAllSpecChange(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.QueryParam("mcisId")
+
+	content, err := mcis.ListSpecChange(nsId, mcisId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetSpecChange godoc
+// @ID GetSpecChange
+// @Summary Get a spec change
+// @Description Get a rolling spec change with the states of VM replacements
+// @Tags [Infra service] MCIS Provisioning management
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param changeId path string true "Spec change ID"
+// @Success 200 {object} mcis.SpecChangeInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/specChange/{changeId} [get]
+func RestGetSpecChange(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	changeId := c.Param("changeId")
+
+	content, err := mcis.GetSpecChange(nsId, changeId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
 type RestPostMcisRecommendResponse struct {
 	//VmReq          []TbVmRecommendReq    `json:"vmReq"`
 	VmRecommend    []mcis.TbVmRecommendInfo `json:"vmRecommend"`
@@ -15,11 +15,17 @@ limitations under the License.
 package mcis
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
 
 	"github.com/cloud-barista/cb-tumblebug/src/core/common"
 	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // RestPostCmdMcis godoc
@@ -75,10 +81,52 @@ func RestPostCmdMcis(c echo.Context) error {
 
 }
 
+// RestPostCmdMcisDryRun godoc
+// @ID PostCmdMcisDryRun
+// @Summary Show expanded commands for specified MCIS without execution (dry run)
+// @Description Show the fully expanded commands for each VM without execution. Secrets are masked.
+// @Description Built-in functions: $$Func(GetPublicIP(target=this)), $$Func(GetPublicIPs(target=this, separator=',')),
+// @Description $$Func(GetPrivateIP(target=this)), $$Func(GetPrivateIPs(target=this, subGroup=g1, separator=',')),
+// @Description $$Func(GetVmIndex()), $$Func(GetVmId()), $$Func(GetSubGroupId()), $$Func(GetRegion()), $$Func(GetZone()), $$Func(GetProvider()),
+// @Description $$Func(GetNlbEndpoint(subGroup=g1)), $$Func(GetSecret(key=repoToken)), $$Func(AssignTask(task='a, b')).
+// @Description Common params: prefix, postfix, escape=shell|none (quote the value for shell; GetSecret is quoted by default). Use \$$Func( for a literal.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param mcisCmdReq body mcis.McisCmdReq true "MCIS Command Request"
+// @Param subGroupId query string false "subGroupId to apply the command only for VMs in subGroup of MCIS" default(g1)
+// @Param vmId query string false "vmId to apply the command only for a VM in MCIS" default(g1-1)
+// @Success 200 {object} mcis.McisCmdDryRunResults
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmd/mcis/{mcisId}/dryRun [post]
+func RestPostCmdMcisDryRun(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	subGroupId := c.QueryParam("subGroupId")
+	vmId := c.QueryParam("vmId")
+
+	req := &mcis.McisCmdReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.DryRunRemoteCommandToMcis(nsId, mcisId, subGroupId, vmId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
 // RestSetBastionNodes godoc
 // @ID SetBastionNodes
 // @Summary Set bastion nodes for a VM
-// @Description Set bastion nodes for a VM
+// @Description Set bastion nodes for a VM. If no bastion is set, one of VMs with public IPs in the same subnet (or vNet) is assigned automatically
+// @Description at the first remote access, and other bastions are tried if the bastion is down.
+// @Description A bastion without a public IP is reached through its own bastions (multi-hop).
 // @Tags [Infra service] MCIS Remote command
 // @Accept  json
 // @Produce  json
@@ -157,3 +205,363 @@ func RestRemoveBastionNodes(c echo.Context) error {
 	content, err := mcis.RemoveBastionNodes(nsId, mcisId, bastionVmId)
 	return common.EndRequestWithLog(c, reqID, err, content)
 }
+
+// RestPostCmdJobMcis godoc
+// @ID PostCmdJobMcis
+// @Summary Submit an asynchronous command job to specified MCIS
+// @Description Submit an asynchronous command job to specified MCIS. Returns a job ID immediately.
+// @Description Output of each VM can be streamed line by line via /ns/{nsId}/cmdJob/{jobId}/stream,
+// @Description and the results are kept for later retrieval via /ns/{nsId}/cmdJob/{jobId}.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param mcisCmdReq body mcis.McisCmdReq true "MCIS Command Request"
+// @Param subGroupId query string false "subGroupId to apply the command only for VMs in subGroup of MCIS" default(g1)
+// @Param vmId query string false "vmId to apply the command only for a VM in MCIS" default(g1-1)
+// @Param x-request-id header string false "Custom request ID"
+// @Success 200 {object} mcis.McisCmdJobInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob/mcis/{mcisId} [post]
+func RestPostCmdJobMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	subGroupId := c.QueryParam("subGroupId")
+	vmId := c.QueryParam("vmId")
+
+	req := &mcis.McisCmdReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.SubmitCmdJobToMcis(nsId, mcisId, subGroupId, vmId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetCmdJob godoc
+// @ID GetCmdJob
+// @Summary Get a command job
+// @Description Get a command job (results are included when the job is finished)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param jobId path string true "Command job ID"
+// @Success 200 {object} mcis.McisCmdJobInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob/{jobId} [get]
+func RestGetCmdJob(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	jobId := c.Param("jobId")
+
+	content, err := mcis.GetCmdJob(nsId, jobId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllCmdJob godoc
+// @ID GetAllCmdJob
+// @Summary List command jobs
+// @Description List command jobs in a namespace
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId query string false "List only jobs of the MCIS" default(mcis01)
+// @Success 200 {object} mcis.McisCmdJobInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob [get]
+func RestGetAllCmdJob(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.QueryParam("mcisId")
+
+	content, err := mcis.ListCmdJob(nsId, mcisId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPutCancelCmdJob godoc
+// @ID PutCancelCmdJob
+// @Summary Cancel a command job
+// @Description Cancel a running command job. SSH sessions of the job are closed.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param jobId path string true "Command job ID"
+// @Success 200 {object} mcis.McisCmdJobInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob/{jobId}/cancel [put]
+func RestPutCancelCmdJob(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	jobId := c.Param("jobId")
+
+	content, err := mcis.CancelCmdJob(nsId, jobId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelCmdJob godoc
+// @ID DelCmdJob
+// @Summary Delete a command job
+// @Description Delete a finished command job and its results
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param jobId path string true "Command job ID"
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob/{jobId} [delete]
+func RestDelCmdJob(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	jobId := c.Param("jobId")
+
+	err := mcis.DelCmdJob(nsId, jobId)
+	result := map[string]string{"message": "Deleted the command job " + jobId}
+	return common.EndRequestWithLog(c, reqID, err, result)
+}
+
+// RestGetCmdJobStream godoc
+// @ID GetCmdJobStream
+// @Summary Stream output of a command job
+// @Description Stream output of a command job line by line as Server-Sent Events (text/event-stream).
+// @Description Each event is a JSON of mcis.McisCmdJobOutput. Outputs so far are sent first.
+// @Description The stream ends after the "jobDone" event. For a finished job, persisted results are replayed.
+// @Tags [Infra service] MCIS Remote command
+// @Produce  text/event-stream
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param jobId path string true "Command job ID"
+// @Success 200 {object} mcis.McisCmdJobOutput
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmdJob/{jobId}/stream [get]
+func RestGetCmdJobStream(c echo.Context) error {
+	nsId := c.Param("nsId")
+	jobId := c.Param("jobId")
+
+	backlog, outputCh, unsubscribe, err := mcis.SubscribeCmdJob(nsId, jobId)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, common.SimpleMsg{Message: err.Error()})
+	}
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	writeEvent := func(output mcis.McisCmdJobOutput) error {
+		data, err := json.Marshal(output)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", output.Stream, data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+
+	for _, output := range backlog {
+		if err := writeEvent(output); err != nil {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case output, ok := <-outputCh:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(output); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// RestPostFileToMcis godoc
+// @ID PostFileToMcis
+// @Summary Upload a file to specified MCIS
+// @Description Upload a file to VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP through bastion nodes.
+// @Description Returns per-VM results with SHA-256 checksums (verified by sha256sum in each VM). Max file size is 100 MiB.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param file formData file true "File to upload"
+// @Param path formData string true "Destination file path on VMs (relative path is from the home directory)" default(/home/cb-user/app.conf)
+// @Param mode formData string false "File mode in octal (e.g., 0644, 0755)" default(0644)
+// @Param userName formData string false "SSH user name (default: user name of the VM SSH key)"
+// @Param subGroupId query string false "subGroupId to upload the file only to VMs in subGroup of MCIS" default(g1)
+// @Param vmId query string false "vmId to upload the file only to a VM in MCIS" default(g1-1)
+// @Success 200 {object} mcis.McisFileTransferResults
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/transferFile/mcis/{mcisId} [post]
+func RestPostFileToMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	subGroupId := c.QueryParam("subGroupId")
+	vmId := c.QueryParam("vmId")
+	targetPath := c.FormValue("path")
+	userName := c.FormValue("userName")
+
+	var mode os.FileMode
+	if modeStr := c.FormValue("mode"); modeStr != "" {
+		parsed, err := strconv.ParseUint(modeStr, 8, 32)
+		if err != nil {
+			err = fmt.Errorf("Invalid file mode (%s), use octal (e.g., 0644)", modeStr)
+			return common.EndRequestWithLog(c, reqID, err, nil)
+		}
+		mode = os.FileMode(parsed)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	if fileHeader.Size > mcis.FileTransferMaxSize {
+		err := fmt.Errorf("The file size (%d bytes) exceeds the limit (%d bytes)", fileHeader.Size, mcis.FileTransferMaxSize)
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, mcis.FileTransferMaxSize+1))
+	if err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	result, err := mcis.UploadFileToMcis(nsId, mcisId, subGroupId, vmId, userName, targetPath, mode, content)
+	return common.EndRequestWithLog(c, reqID, err, result)
+}
+
+// RestGetFileFromMcis godoc
+// @ID GetFileFromMcis
+// @Summary Download a file from specified MCIS
+// @Description Download a file from VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP through bastion nodes.
+// @Description Returns a zip archive with {vmId}/{file name} entries and results.json (per-VM results with SHA-256 checksums).
+// @Description Max file size is 100 MiB for each VM.
+// @Tags [Infra service] MCIS Remote command
+// @Produce  application/zip
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param path query string true "Source file path on VMs (relative path is from the home directory)" default(/home/cb-user/app.conf)
+// @Param userName query string false "SSH user name (default: user name of the VM SSH key)"
+// @Param subGroupId query string false "subGroupId to download the file only from VMs in subGroup of MCIS" default(g1)
+// @Param vmId query string false "vmId to download the file only from a VM in MCIS" default(g1-1)
+// @Success 200 {file} file
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/transferFile/mcis/{mcisId} [get]
+func RestGetFileFromMcis(c echo.Context) error {
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	subGroupId := c.QueryParam("subGroupId")
+	vmId := c.QueryParam("vmId")
+	sourcePath := c.QueryParam("path")
+	userName := c.QueryParam("userName")
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", mcisId+"-files.zip"))
+
+	_, err := mcis.DownloadFileFromMcis(nsId, mcisId, subGroupId, vmId, userName, sourcePath, res)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentDisposition)
+			return c.JSON(http.StatusInternalServerError, common.SimpleMsg{Message: err.Error()})
+		}
+	}
+	return nil
+}
+
+// RestGetVmSshHostKey godoc
+// @ID GetVmSshHostKey
+// @Summary Get the SSH host key of a VM
+// @Description Get the SSH host key (fingerprint) of a VM recorded on the first SSH connection (trust-on-first-use).
+// @Description An SSH connection with a different host key fails until the recorded key is reset.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param vmId path string true "VM ID" default(g1-1)
+// @Success 200 {object} mcis.SshHostKeyInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/mcis/{mcisId}/vm/{vmId}/sshHostKey [get]
+func RestGetVmSshHostKey(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	vmId := c.Param("vmId")
+
+	content, err := mcis.GetVmSshHostKey(nsId, mcisId, vmId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelVmSshHostKey godoc
+// @ID DelVmSshHostKey
+// @Summary Reset the SSH host key of a VM
+// @Description Reset the recorded SSH host key of a VM. The host key will be recorded again on the next SSH connection.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param vmId path string true "VM ID" default(g1-1)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/mcis/{mcisId}/vm/{vmId}/sshHostKey [delete]
+func RestDelVmSshHostKey(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	vmId := c.Param("vmId")
+
+	err := mcis.ResetVmSshHostKey(nsId, mcisId, vmId)
+	result := map[string]string{"message": "Reset the SSH host key of " + vmId}
+	return common.EndRequestWithLog(c, reqID, err, result)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to handle REST API for mcis
+package mcis
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// terminalControlMsg is struct for a control message from the web terminal client (WebSocket text message)
+type terminalControlMsg struct {
+	Type string `json:"type"` // input or resize
+	Data string `json:"data"`
+	Cols int    `json:"cols"`
+	Rows int    `json:"rows"`
+}
+
+// terminalUpgrader is WebSocket upgrader for the web terminal
+var terminalUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     checkTerminalOrigin,
+}
+
+// checkTerminalOrigin is func to allow WebSocket connections only from ALLOW_ORIGINS (same as CORS)
+func checkTerminalOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// non-browser clients
+		return true
+	}
+	allowedOrigins := os.Getenv("ALLOW_ORIGINS")
+	for _, allowed := range strings.Split(allowedOrigins, ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// RestGetVmTerminal godoc
+// @ID GetVmTerminal
+// @Summary Open an interactive web terminal to a VM (WebSocket)
+// @Description Open an interactive PTY session to a VM through bastion nodes over WebSocket (no need to download private keys).
+// @Description Binary messages from the client are sent to the terminal as input, and output is sent back as binary messages.
+// @Description Text messages are JSON control messages: {"type":"input","data":"ls\n"} or {"type":"resize","cols":120,"rows":40}.
+// @Description The session output is recorded (asciicast v2) and associated with the requesting user.
+// @Tags [Infra service] MCIS Remote command
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param vmId path string true "VM ID" default(g1-1)
+// @Param userName query string false "SSH user name (default: user name of the VM SSH key)"
+// @Param cols query int false "Terminal width" default(80)
+// @Param rows query int false "Terminal height" default(24)
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/mcis/{mcisId}/vm/{vmId}/terminal [get]
+func RestGetVmTerminal(c echo.Context) error {
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	vmId := c.Param("vmId")
+	userName := c.QueryParam("userName")
+	cols, _ := strconv.Atoi(c.QueryParam("cols"))
+	rows, _ := strconv.Atoi(c.QueryParam("rows"))
+
+	requestedBy, _, ok := c.Request().BasicAuth()
+	if !ok || requestedBy == "" {
+		requestedBy = "anonymous"
+	}
+
+	if !websocket.IsWebSocketUpgrade(c.Request()) {
+		return c.JSON(http.StatusBadRequest, common.SimpleMsg{Message: "WebSocket upgrade is required"})
+	}
+
+	terminal, err := mcis.OpenTerminalSession(nsId, mcisId, vmId, userName, requestedBy, c.RealIP(), cols, rows)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, common.SimpleMsg{Message: err.Error()})
+	}
+	defer terminal.Close()
+
+	ws, err := terminalUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil
+	}
+	defer ws.Close()
+
+	var writeMu sync.Mutex
+	writeMessage := func(messageType int, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return ws.WriteMessage(messageType, data)
+	}
+
+	// terminal output to WebSocket
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := terminal.Read(buf)
+			if n > 0 {
+				if werr := writeMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					break
+				}
+			}
+			if err != nil {
+				break
+			}
+		}
+		terminal.Wait()
+		writeMu.Lock()
+		ws.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, "session closed"),
+			time.Now().Add(time.Second))
+		writeMu.Unlock()
+		ws.Close()
+	}()
+
+	// WebSocket input to terminal
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		switch messageType {
+		case websocket.BinaryMessage:
+			if _, err := terminal.Write(data); err != nil {
+				return nil
+			}
+		case websocket.TextMessage:
+			msg := terminalControlMsg{}
+			if err := json.Unmarshal(data, &msg); err != nil {
+				// plain text is regarded as input
+				if _, err := terminal.Write(data); err != nil {
+					return nil
+				}
+				continue
+			}
+			switch msg.Type {
+			case "resize":
+				if err := terminal.Resize(msg.Cols, msg.Rows); err != nil {
+					log.Debug().Err(err).Msg("")
+				}
+			default:
+				if _, err := terminal.Write([]byte(msg.Data)); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RestGetAllTerminalSession godoc
+// @ID GetAllTerminalSession
+// @Summary List web terminal sessions
+// @Description List web terminal sessions in a namespace
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId query string false "List only sessions of the MCIS" default(mcis01)
+// @Param requestedBy query string false "List only sessions requested by the user"
+// @Success 200 {object} mcis.TerminalSessionInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/terminalSession [get]
+func RestGetAllTerminalSession(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.QueryParam("mcisId")
+	requestedBy := c.QueryParam("requestedBy")
+
+	content, err := mcis.ListTerminalSession(nsId, mcisId, requestedBy)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetTerminalSession godoc
+// @ID GetTerminalSession
+// @Summary Get a web terminal session
+// @Description Get a web terminal session
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param sessionId path string true "Terminal session ID"
+// @Success 200 {object} mcis.TerminalSessionInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/terminalSession/{sessionId} [get]
+func RestGetTerminalSession(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	sessionId := c.Param("sessionId")
+
+	content, err := mcis.GetTerminalSession(nsId, sessionId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetTerminalSessionRecording godoc
+// @ID GetTerminalSessionRecording
+// @Summary Get the recording of a web terminal session
+// @Description Get the recording of a web terminal session (asciicast v2, playable with asciinema)
+// @Tags [Infra service] MCIS Remote command
+// @Produce  application/x-asciicast
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param sessionId path string true "Terminal session ID"
+// @Success 200 {file} file
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/terminalSession/{sessionId}/recording [get]
+func RestGetTerminalSessionRecording(c echo.Context) error {
+	nsId := c.Param("nsId")
+	sessionId := c.Param("sessionId")
+
+	info, err := mcis.GetTerminalSession(nsId, sessionId)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, common.SimpleMsg{Message: err.Error()})
+	}
+	c.Response().Header().Set(echo.HeaderContentType, "application/x-asciicast")
+	return c.Attachment(info.RecordingFile, sessionId+".cast")
+}
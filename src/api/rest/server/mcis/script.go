@@ -0,0 +1,282 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to handle REST API for mcis
+package mcis
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
+	"github.com/labstack/echo/v4"
+)
+
+// RestPostScript godoc
+// @ID PostScript
+// @Summary Create a script in the script library
+// @Description Create a script (version 1) in the script library of a namespace.
+// @Description Parameters are exported to the script as environment variables.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptReq body mcis.ScriptReq true "Script"
+// @Success 200 {object} mcis.ScriptInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script [post]
+func RestPostScript(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+
+	u := &mcis.ScriptReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.CreateScript(nsId, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPutScript godoc
+// @ID PutScript
+// @Summary Update a script in the script library
+// @Description Update a script. A new version is added, and previous versions are kept.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptName path string true "Script name" default(setweb)
+// @Param scriptReq body mcis.ScriptReq true "Script"
+// @Success 200 {object} mcis.ScriptInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script/{scriptName} [put]
+func RestPutScript(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	scriptName := c.Param("scriptName")
+
+	u := &mcis.ScriptReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.UpdateScript(nsId, scriptName, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetScript godoc
+// @ID GetScript
+// @Summary Get a script in the script library
+// @Description Get a script (the latest version by default)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptName path string true "Script name" default(setweb)
+// @Param version query int false "Version of the script (the latest version if omitted)"
+// @Success 200 {object} mcis.ScriptInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script/{scriptName} [get]
+func RestGetScript(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	scriptName := c.Param("scriptName")
+
+	version := 0
+	if v := c.QueryParam("version"); v != "" {
+		var err error
+		version, err = strconv.Atoi(v)
+		if err != nil || version < 1 {
+			err := fmt.Errorf("Invalid version: '%s'", v)
+			return common.EndRequestWithLog(c, reqID, err, nil)
+		}
+	}
+
+	content, err := mcis.GetScript(nsId, scriptName, version)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllScript godoc
+// @ID GetAllScript
+// @Summary List scripts in the script library
+// @Description List the latest versions of scripts in the script library of a namespace
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Success 200 {object} mcis.ScriptInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script [get]
+func RestGetAllScript(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+
+	content, err := mcis.ListScript(nsId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllScriptVersion godoc
+// @ID GetAllScriptVersion
+// @Summary List versions of a script
+// @Description List all versions of a script in the script library
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptName path string true "Script name" default(setweb)
+// @Success 200 {object} mcis.ScriptInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script/{scriptName}/version [get]
+func RestGetAllScriptVersion(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	scriptName := c.Param("scriptName")
+
+	content, err := mcis.ListScriptVersion(nsId, scriptName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelScript godoc
+// @ID DelScript
+// @Summary Delete a script in the script library
+// @Description Delete a script with all versions (execution records are kept)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptName path string true "Script name" default(setweb)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/script/{scriptName} [delete]
+func RestDelScript(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	scriptName := c.Param("scriptName")
+
+	err := mcis.DelScript(nsId, scriptName)
+	content := map[string]string{"message": "The script " + scriptName + " has been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostScriptExecMcis godoc
+// @ID PostScriptExecMcis
+// @Summary Execute a script of the script library to specified MCIS
+// @Description Execute a script of the script library to VMs in MCIS by name. The execution is recorded with the results of each VM.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param scriptExecReq body mcis.ScriptExecReq true "Script execution request"
+// @Param subGroupId query string false "subGroupId to execute the script only for VMs in subGroup of MCIS" default(g1)
+// @Param vmId query string false "vmId to execute the script only for a VM in MCIS" default(g1-1)
+// @Success 200 {object} mcis.ScriptExecutionInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/cmd/mcis/{mcisId}/script [post]
+func RestPostScriptExecMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+	subGroupId := c.QueryParam("subGroupId")
+	vmId := c.QueryParam("vmId")
+
+	req := &mcis.ScriptExecReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.ExecScriptToMcis(nsId, mcisId, subGroupId, vmId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllScriptExecution godoc
+// @ID GetAllScriptExecution
+// @Summary List script executions
+// @Description List records of script executions in a namespace (the latest first)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param scriptName query string false "List only executions of the script" default(setweb)
+// @Param mcisId query string false "List only executions to the MCIS" default(mcis01)
+// @Success 200 {object} mcis.ScriptExecutionInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/scriptExecution [get]
+func RestGetAllScriptExecution(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	scriptName := c.QueryParam("scriptName")
+	mcisId := c.QueryParam("mcisId")
+
+	content, err := mcis.ListScriptExecution(nsId, scriptName, mcisId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetScriptExecution godoc
+// @ID GetScriptExecution
+// @Summary Get a script execution
+// @Description Get a record of a script execution with the results of each VM
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param executionId path string true "Script execution ID"
+// @Success 200 {object} mcis.ScriptExecutionInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/scriptExecution/{executionId} [get]
+func RestGetScriptExecution(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	executionId := c.Param("executionId")
+
+	content, err := mcis.GetScriptExecution(nsId, executionId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to handle REST API for mcis
+package mcis
+
+import (
+	"net/http"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcis"
+	"github.com/labstack/echo/v4"
+)
+
+// RestPostWorkflow godoc
+// @ID PostWorkflow
+// @Summary Create a workflow
+// @Description Create a workflow (DAG of steps). Each step targets VMs (subGroupId, vmId, or label) with commands or a script of the script library.
+// @Description Outputs of a step are captured as variables, which can be referenced by $$Var(stepId.outputName) in the steps depending on it.
+// @Description A variable in a command is quoted for shell unless escape=none is given: $$Var(stepId.outputName, escape=none).
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param workflowReq body mcis.WorkflowReq true "Workflow"
+// @Success 200 {object} mcis.WorkflowInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflow [post]
+func RestPostWorkflow(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+
+	u := &mcis.WorkflowReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.CreateWorkflow(nsId, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPutWorkflow godoc
+// @ID PutWorkflow
+// @Summary Update a workflow
+// @Description Update steps of a workflow
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param workflowName path string true "Workflow name" default(ceph)
+// @Param workflowReq body mcis.WorkflowReq true "Workflow"
+// @Success 200 {object} mcis.WorkflowInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflow/{workflowName} [put]
+func RestPutWorkflow(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	workflowName := c.Param("workflowName")
+
+	u := &mcis.WorkflowReq{}
+	if err := c.Bind(u); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.UpdateWorkflow(nsId, workflowName, u)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetWorkflow godoc
+// @ID GetWorkflow
+// @Summary Get a workflow
+// @Description Get a workflow
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param workflowName path string true "Workflow name" default(ceph)
+// @Success 200 {object} mcis.WorkflowInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflow/{workflowName} [get]
+func RestGetWorkflow(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	workflowName := c.Param("workflowName")
+
+	content, err := mcis.GetWorkflow(nsId, workflowName)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllWorkflow godoc
+// @ID GetAllWorkflow
+// @Summary List workflows
+// @Description List workflows in a namespace
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Success 200 {object} mcis.WorkflowInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflow [get]
+func RestGetAllWorkflow(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+
+	content, err := mcis.ListWorkflow(nsId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelWorkflow godoc
+// @ID DelWorkflow
+// @Summary Delete a workflow
+// @Description Delete a workflow (records of runs are kept)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param workflowName path string true "Workflow name" default(ceph)
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflow/{workflowName} [delete]
+func RestDelWorkflow(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	workflowName := c.Param("workflowName")
+
+	err := mcis.DelWorkflow(nsId, workflowName)
+	content := map[string]string{"message": "The workflow " + workflowName + " has been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestPostWorkflowRunMcis godoc
+// @ID PostWorkflowRunMcis
+// @Summary Run a workflow to specified MCIS
+// @Description Start a run of a workflow to MCIS (asynchronous). Steps are executed in the order of dependencies
+// @Description (independent steps in parallel), and a step is skipped if a step that it depends on did not succeed.
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param mcisId path string true "MCIS ID" default(mcis01)
+// @Param workflowRunReq body mcis.WorkflowRunReq true "Workflow to run"
+// @Success 200 {object} mcis.WorkflowRunInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflowRun/mcis/{mcisId} [post]
+func RestPostWorkflowRunMcis(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	mcisId := c.Param("mcisId")
+
+	req := &mcis.WorkflowRunReq{}
+	if err := c.Bind(req); err != nil {
+		return common.EndRequestWithLog(c, reqID, err, nil)
+	}
+
+	content, err := mcis.RunWorkflowToMcis(nsId, mcisId, req)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetAllWorkflowRun godoc
+// @ID GetAllWorkflowRun
+// @Summary List workflow runs
+// @Description List workflow runs in a namespace (the latest first)
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param workflowName query string false "List only runs of the workflow" default(ceph)
+// @Param mcisId query string false "List only runs to the MCIS" default(mcis01)
+// @Success 200 {object} mcis.WorkflowRunInfoList
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflowRun [get]
+func RestGetAllWorkflowRun(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	workflowName := c.QueryParam("workflowName")
+	mcisId := c.QueryParam("mcisId")
+
+	content, err := mcis.ListWorkflowRun(nsId, workflowName, mcisId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestGetWorkflowRun godoc
+// @ID GetWorkflowRun
+// @Summary Get a workflow run
+// @Description Get a workflow run with the states, outputs and results of steps
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param runId path string true "Workflow run ID"
+// @Success 200 {object} mcis.WorkflowRunInfo
+// @Failure 404 {object} common.SimpleMsg
+// @Failure 500 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflowRun/{runId} [get]
+func RestGetWorkflowRun(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	runId := c.Param("runId")
+
+	content, err := mcis.GetWorkflowRun(nsId, runId)
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
+
+// RestDelWorkflowRun godoc
+// @ID DelWorkflowRun
+// @Summary Delete a workflow run
+// @Description Delete a finished workflow run
+// @Tags [Infra service] MCIS Remote command
+// @Accept  json
+// @Produce  json
+// @Param nsId path string true "Namespace ID" default(ns01)
+// @Param runId path string true "Workflow run ID"
+// @Success 200 {object} common.SimpleMsg
+// @Failure 404 {object} common.SimpleMsg
+// @Router /ns/{nsId}/workflowRun/{runId} [delete]
+func RestDelWorkflowRun(c echo.Context) error {
+	reqID, idErr := common.StartRequestWithLog(c)
+	if idErr != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": idErr.Error()})
+	}
+	nsId := c.Param("nsId")
+	runId := c.Param("runId")
+
+	err := mcis.DelWorkflowRun(nsId, runId)
+	content := map[string]string{"message": "The workflow run " + runId + " has been deleted"}
+	return common.EndRequestWithLog(c, reqID, err, content)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -80,6 +81,40 @@ func Zerologger(skipPatterns [][]string) echo.MiddlewareFunc {
 	})
 }
 
+// ApiMetrics is func to record the count and latency of API requests by route (e.g., /tumblebug/ns/:nsId/mcis/:mcisId)
+// (registered separately from Zerologger, so requests skipped in the log are also counted)
+func ApiMetrics(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		status := c.Response().Status
+		if err != nil && !c.Response().Committed {
+			// the status of the error is written by the error handler later
+			status = http.StatusInternalServerError
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				status = httpErr.Code
+			}
+		}
+		common.ObserveApiRequest(c.Request().Method, c.Path(), status, time.Since(start))
+		return err
+	}
+}
+
+// streamingRoutes are routes ("METHOD path") that stream a long-lived or large non-JSON response
+// (excluded from request tracking and response body dump)
+var streamingRoutes = map[string]bool{
+	"GET /tumblebug/ns/:nsId/cmdJob/:jobId/stream":                 true,
+	"GET /tumblebug/ns/:nsId/transferFile/mcis/:mcisId":            true,
+	"GET /tumblebug/ns/:nsId/mcis/:mcisId/vm/:vmId/terminal":       true,
+	"GET /tumblebug/ns/:nsId/terminalSession/:sessionId/recording": true,
+}
+
+// isStreamingRoute is func to check whether the request is for one of streamingRoutes
+func isStreamingRoute(c echo.Context) bool {
+	return streamingRoutes[c.Request().Method+" "+c.Path()]
+}
+
 func RequestIdAndDetailsIssuer(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		// log.Debug().Msg("Start - Request ID middleware")
@@ -87,6 +122,11 @@ func RequestIdAndDetailsIssuer(next echo.HandlerFunc) echo.HandlerFunc {
 		// Make X-Request-Id visible to all handlers
 		c.Response().Header().Set("Access-Control-Expose-Headers", echo.HeaderXRequestID)
 
+		// Skip request tracking for metrics scraping and long-lived output streams
+		if c.Request().URL.Path == "/tumblebug/metrics" || isStreamingRoute(c) {
+			return next(c)
+		}
+
 		// Get or generate Request ID
 		reqID := c.Request().Header.Get(echo.HeaderXRequestID)
 		if reqID == "" {
@@ -120,7 +160,7 @@ func RequestIdAndDetailsIssuer(next echo.HandlerFunc) echo.HandlerFunc {
 func ResponseBodyDump() echo.MiddlewareFunc {
 	return middleware.BodyDumpWithConfig(middleware.BodyDumpConfig{
 		Skipper: func(c echo.Context) bool {
-			if c.Path() == "/tumblebug/api" {
+			if c.Path() == "/tumblebug/api" || c.Path() == "/tumblebug/metrics" || isStreamingRoute(c) {
 				return true
 			}
 			return false
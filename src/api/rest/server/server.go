@@ -92,9 +92,13 @@ func RunServer(port string) {
 	APILogSkipPatterns := [][]string{
 		{"/tumblebug/api"},
 		{"/mcis", "option=status"},
+		{"/tumblebug/metrics"},
 	}
 	e.Use(middlewares.Zerologger(APILogSkipPatterns))
 
+	// Custom middleware for API request metrics
+	e.Use(middlewares.ApiMetrics)
+
 	e.Use(middleware.Recover())
 	// limit the application to 20 requests/sec using the default in-memory store
 	e.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(20)))
@@ -120,6 +124,7 @@ func RunServer(port string) {
 	// e.GET("/tumblebug/swaggerActive", rest_common.RestGetSwagger)
 	e.GET("/tumblebug/readyz", rest_common.RestGetReadyz)
 	e.GET("/tumblebug/httpVersion", rest_common.RestCheckHTTPVersion)
+	e.GET("/tumblebug/metrics", rest_common.RestGetMetrics)
 
 	allowedOrigins := os.Getenv("ALLOW_ORIGINS")
 	if allowedOrigins == "" {
@@ -228,11 +233,21 @@ func RunServer(port string) {
 	g.DELETE("/:nsId", rest_common.RestDelNs)
 	g.DELETE("", rest_common.RestDelAllNs)
 
+	g.PUT("/:nsId/secret/:secretKey", rest_common.RestPutSecret)
+	g.GET("/:nsId/secret", rest_common.RestGetAllSecret)
+	g.DELETE("/:nsId/secret/:secretKey", rest_common.RestDelSecret)
+
 	//MCIS Management
 	g.POST("/:nsId/mcis", rest_mcis.RestPostMcis)
 	g.POST("/:nsId/registerCspVm", rest_mcis.RestPostRegisterCSPNativeVM)
 
 	e.POST("/tumblebug/mcisRecommendVm", rest_mcis.RestRecommendVm)
+	e.POST("/tumblebug/mcisPlacement", rest_mcis.RestPostMcisPlacement)
+	e.GET("/tumblebug/specAvailability", rest_mcis.RestGetAllSpecAvailability)
+	e.DELETE("/tumblebug/specAvailability", rest_mcis.RestDelAllSpecAvailability)
+	e.PUT("/tumblebug/carbonIntensity", rest_mcis.RestPutCarbonIntensity)
+	e.GET("/tumblebug/carbonIntensity", rest_mcis.RestGetAllCarbonIntensity)
+	e.DELETE("/tumblebug/carbonIntensity", rest_mcis.RestDelAllCarbonIntensity)
 	e.POST("/tumblebug/mcisDynamicCheckRequest", rest_mcis.RestPostMcisDynamicCheckRequest)
 	e.POST("/tumblebug/systemMcis", rest_mcis.RestPostSystemMcis)
 
@@ -263,6 +278,10 @@ func RunServer(port string) {
 	g.GET("/:nsId/mcis/:mcisId/subgroup", rest_mcis.RestGetMcisGroupIds)
 	g.GET("/:nsId/mcis/:mcisId/subgroup/:subgroupId", rest_mcis.RestGetMcisGroupVms)
 	g.POST("/:nsId/mcis/:mcisId/subgroup/:subgroupId", rest_mcis.RestPostMcisSubGroupScaleOut)
+	g.POST("/:nsId/rightSizing/mcis/:mcisId", rest_mcis.RestPostRightSizing)
+	g.POST("/:nsId/rightSizing/mcis/:mcisId/apply", rest_mcis.RestPostApplyRightSizing)
+	g.GET("/:nsId/specChange", rest_mcis.RestGetAllSpecChange)
+	g.GET("/:nsId/specChange/:changeId", rest_mcis.RestGetSpecChange)
 
 	//g.GET("/:nsId/mcis/:mcisId/vm", rest_mcis.RestGetAllMcisVm)
 	// g.PUT("/:nsId/mcis/:mcisId/vm/:vmId", rest_mcis.RestPutMcisVm)
@@ -275,6 +294,39 @@ func RunServer(port string) {
 	g.GET("/:nsId/control/mcis/:mcisId/vm/:vmId", rest_mcis.RestGetControlMcisVm)
 
 	g.POST("/:nsId/cmd/mcis/:mcisId", rest_mcis.RestPostCmdMcis)
+	g.POST("/:nsId/cmd/mcis/:mcisId/dryRun", rest_mcis.RestPostCmdMcisDryRun)
+	g.POST("/:nsId/cmdJob/mcis/:mcisId", rest_mcis.RestPostCmdJobMcis)
+	g.GET("/:nsId/cmdJob", rest_mcis.RestGetAllCmdJob)
+	g.GET("/:nsId/cmdJob/:jobId", rest_mcis.RestGetCmdJob)
+	g.GET("/:nsId/cmdJob/:jobId/stream", rest_mcis.RestGetCmdJobStream)
+	g.PUT("/:nsId/cmdJob/:jobId/cancel", rest_mcis.RestPutCancelCmdJob)
+	g.DELETE("/:nsId/cmdJob/:jobId", rest_mcis.RestDelCmdJob)
+	g.POST("/:nsId/transferFile/mcis/:mcisId", rest_mcis.RestPostFileToMcis)
+	g.GET("/:nsId/transferFile/mcis/:mcisId", rest_mcis.RestGetFileFromMcis)
+	g.GET("/:nsId/mcis/:mcisId/vm/:vmId/terminal", rest_mcis.RestGetVmTerminal)
+	g.GET("/:nsId/mcis/:mcisId/vm/:vmId/sshHostKey", rest_mcis.RestGetVmSshHostKey)
+	g.DELETE("/:nsId/mcis/:mcisId/vm/:vmId/sshHostKey", rest_mcis.RestDelVmSshHostKey)
+	g.GET("/:nsId/terminalSession", rest_mcis.RestGetAllTerminalSession)
+	g.GET("/:nsId/terminalSession/:sessionId", rest_mcis.RestGetTerminalSession)
+	g.GET("/:nsId/terminalSession/:sessionId/recording", rest_mcis.RestGetTerminalSessionRecording)
+	g.POST("/:nsId/script", rest_mcis.RestPostScript)
+	g.GET("/:nsId/script", rest_mcis.RestGetAllScript)
+	g.GET("/:nsId/script/:scriptName", rest_mcis.RestGetScript)
+	g.PUT("/:nsId/script/:scriptName", rest_mcis.RestPutScript)
+	g.DELETE("/:nsId/script/:scriptName", rest_mcis.RestDelScript)
+	g.GET("/:nsId/script/:scriptName/version", rest_mcis.RestGetAllScriptVersion)
+	g.POST("/:nsId/cmd/mcis/:mcisId/script", rest_mcis.RestPostScriptExecMcis)
+	g.GET("/:nsId/scriptExecution", rest_mcis.RestGetAllScriptExecution)
+	g.GET("/:nsId/scriptExecution/:executionId", rest_mcis.RestGetScriptExecution)
+	g.POST("/:nsId/workflow", rest_mcis.RestPostWorkflow)
+	g.GET("/:nsId/workflow", rest_mcis.RestGetAllWorkflow)
+	g.GET("/:nsId/workflow/:workflowName", rest_mcis.RestGetWorkflow)
+	g.PUT("/:nsId/workflow/:workflowName", rest_mcis.RestPutWorkflow)
+	g.DELETE("/:nsId/workflow/:workflowName", rest_mcis.RestDelWorkflow)
+	g.POST("/:nsId/workflowRun/mcis/:mcisId", rest_mcis.RestPostWorkflowRunMcis)
+	g.GET("/:nsId/workflowRun", rest_mcis.RestGetAllWorkflowRun)
+	g.GET("/:nsId/workflowRun/:runId", rest_mcis.RestGetWorkflowRun)
+	g.DELETE("/:nsId/workflowRun/:runId", rest_mcis.RestDelWorkflowRun)
 	g.PUT("/:nsId/mcis/:mcisId/vm/:targetVmId/bastion/:bastionVmId", rest_mcis.RestSetBastionNodes)
 	g.DELETE("/:nsId/mcis/:mcisId/bastion/:bastionVmId", rest_mcis.RestRemoveBastionNodes)
 	g.GET("/:nsId/mcis/:mcisId/vm/:targetVmId/bastion", rest_mcis.RestGetBastionNodes)
@@ -283,6 +335,20 @@ func RunServer(port string) {
 	g.POST("/:nsId/benchmark/mcis/:mcisId", rest_mcis.RestGetBenchmark)
 	g.POST("/:nsId/benchmarkAll/mcis/:mcisId", rest_mcis.RestGetAllBenchmark)
 	g.GET("/:nsId/benchmarkLatency/mcis/:mcisId", rest_mcis.RestGetBenchmarkLatency)
+	g.POST("/:nsId/benchmarkSuite/mcis/:mcisId", rest_mcis.RestPostRunBenchmarkSuite)
+	g.POST("/:nsId/benchmarkMesh/mcis/:mcisId", rest_mcis.RestPostNetworkMeshBenchmark)
+
+	e.POST("/tumblebug/latency", rest_mcis.RestPostLatency)
+	e.GET("/tumblebug/latency", rest_mcis.RestGetAllLatency)
+	e.GET("/tumblebug/latency/:src/:dest", rest_mcis.RestGetLatency)
+	e.DELETE("/tumblebug/latency", rest_mcis.RestDelAllLatency)
+	e.GET("/tumblebug/specBenchmark", rest_mcis.RestGetAllSpecBenchmark)
+	e.GET("/tumblebug/specBenchmark/:specId", rest_mcis.RestGetSpecBenchmark)
+	e.DELETE("/tumblebug/specBenchmark", rest_mcis.RestDelAllSpecBenchmark)
+	e.POST("/tumblebug/benchmarkSuite", rest_mcis.RestPostBenchmarkSuite)
+	e.GET("/tumblebug/benchmarkSuite", rest_mcis.RestGetAllBenchmarkSuite)
+	e.GET("/tumblebug/benchmarkSuite/:suiteName", rest_mcis.RestGetBenchmarkSuite)
+	e.DELETE("/tumblebug/benchmarkSuite/:suiteName", rest_mcis.RestDelBenchmarkSuite)
 
 	// VPN Sites info
 	g.GET("/:nsId/mcis/:mcisId/site", rest_mcis.RestGetSitesInMcis)
@@ -300,6 +366,7 @@ func RunServer(port string) {
 
 	//MCIS AUTO Policy
 	g.POST("/:nsId/policy/mcis/:mcisId", rest_mcis.RestPostMcisPolicy)
+	g.POST("/:nsId/policy/mcis/:mcisId/simulate", rest_mcis.RestPostMcisPolicySimulation)
 	g.GET("/:nsId/policy/mcis/:mcisId", rest_mcis.RestGetMcisPolicy)
 	g.GET("/:nsId/policy/mcis", rest_mcis.RestGetAllMcisPolicy)
 	g.PUT("/:nsId/policy/mcis/:mcisId", rest_mcis.RestPutMcisPolicy)
@@ -307,8 +374,14 @@ func RunServer(port string) {
 	g.DELETE("/:nsId/policy/mcis", rest_mcis.RestDelAllMcisPolicy)
 
 	g.POST("/:nsId/monitoring/install/mcis/:mcisId", rest_mcis.RestPostInstallMonitorAgentToMcis)
+	g.POST("/:nsId/monitoring/upgrade/mcis/:mcisId", rest_mcis.RestPostUpgradeMonitorAgentToMcis)
+	g.POST("/:nsId/monitoring/uninstall/mcis/:mcisId", rest_mcis.RestPostUninstallMonitorAgentFromMcis)
 	g.GET("/:nsId/monitoring/mcis/:mcisId/metric/:metric", rest_mcis.RestGetMonitorData)
+	g.GET("/:nsId/monitoring/mcis/:mcisId/metric/:metric/history", rest_mcis.RestGetMonitorHistory)
 	g.PUT("/:nsId/monitoring/status/mcis/:mcisId/vm/:vmId", rest_mcis.RestPutMonitorAgentStatusInstalled)
+	g.POST("/:nsId/monitoring/agentless/mcis/:mcisId", rest_mcis.RestPostAgentlessMonitoring)
+	g.GET("/:nsId/monitoring/agentless/mcis/:mcisId", rest_mcis.RestGetAgentlessMonitoring)
+	g.DELETE("/:nsId/monitoring/agentless/mcis/:mcisId", rest_mcis.RestDelAgentlessMonitoring)
 
 	// K8sCluster
 	e.GET("/tumblebug/availableK8sClusterVersion", rest_mcis.RestGetAvailableK8sClusterVersion)
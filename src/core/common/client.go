@@ -129,6 +129,7 @@ func ExecuteHttpRequest[B any, T any](
 
 			if time.Now().Before(cachedItem.ExpiresAt) {
 				log.Trace().Msgf("Cache hit! Expires: %v", time.Now().Sub(cachedItem.ExpiresAt))
+				observeExternalRequestCache(url, true)
 				*result = cachedItem.Response
 				//val := reflect.ValueOf(result).Elem()
 				//cachedVal := reflect.ValueOf(cachedItem.Response)
@@ -141,6 +142,8 @@ func ExecuteHttpRequest[B any, T any](
 			}
 		}
 
+		observeExternalRequestCache(url, false)
+
 		// Limit the number of concurrent requests
 		concurrencyLimit := 10
 		retryWait := 5 * time.Second
@@ -194,6 +197,7 @@ func ExecuteHttpRequest[B any, T any](
 	var err error
 
 	// Execute HTTP method based on the given type
+	requestStart := time.Now()
 	switch method {
 	case "GET":
 		resp, err = req.Get(url)
@@ -207,6 +211,12 @@ func ExecuteHttpRequest[B any, T any](
 		return fmt.Errorf("Unsupported rest method: %s", method)
 	}
 
+	if err == nil && resp.IsError() {
+		observeExternalRequest(method, url, requestStart, fmt.Errorf("%s", resp.Status()))
+	} else {
+		observeExternalRequest(method, url, requestStart, err)
+	}
+
 	if err != nil {
 		if method == "GET" {
 			requestDone(requestKey)
@@ -124,6 +124,29 @@ type K8sClusterRootDiskDetailSize struct {
 // RuntimeK8sClusterInfo is global variable for K8sClusterInfo
 var RuntimeK8sClusterInfo = K8sClusterInfo{}
 
+// CarbonIntensityInfo is structure for carbon intensity of regions and the power model of specs
+type CarbonIntensityInfo struct {
+	Default float64                                `mapstructure:"default" json:"default"`
+	Power   PowerModel                             `mapstructure:"power" json:"power"`
+	CSPs    map[string]CarbonIntensityRegionDetail `mapstructure:"cloud" json:"csps"`
+}
+
+// CarbonIntensityRegionDetail is structure for carbon intensity (gCO2eq/kWh) of regions of a CSP
+type CarbonIntensityRegionDetail struct {
+	Regions map[string]float64 `mapstructure:"region" json:"regions"`
+}
+
+// PowerModel is structure for the power model to estimate the power consumption of a spec
+type PowerModel struct {
+	Pue                 float64 `mapstructure:"pue" json:"pue"`
+	WattsPerVCPU        float64 `mapstructure:"wattsPerVCPU" json:"wattsPerVCPU"`
+	WattsPerMemoryGiB   float64 `mapstructure:"wattsPerMemoryGiB" json:"wattsPerMemoryGiB"`
+	WattsPerAccelerator float64 `mapstructure:"wattsPerAccelerator" json:"wattsPerAccelerator"`
+}
+
+// RuntimeCarbonIntensity is global variable for CarbonIntensityInfo
+var RuntimeCarbonIntensity = CarbonIntensityInfo{}
+
 // AdjustKeysToLowercase adjusts the keys of nested maps to lowercase.
 func AdjustKeysToLowercase(cloudInfo *CloudInfo) {
 	newCSPs := make(map[string]CSPDetail)
@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common is to include common methods for managing multi-cloud infra
+package common
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricNamespace is the prefix for all Prometheus metrics of CB-Tumblebug
+const metricNamespace = "tumblebug"
+
+var (
+	// apiRequestsTotal counts REST API requests by route
+	apiRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "api_requests_total",
+			Help:      "Total number of REST API requests by method, route and status code.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	// apiRequestDuration observes REST API latency by route
+	apiRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Name:      "api_request_duration_seconds",
+			Help:      "Latency of REST API requests by method and route.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		},
+		[]string{"method", "route"},
+	)
+
+	// externalRequestDuration observes latency of calls made by ExecuteHttpRequest (e.g., CB-Spider)
+	externalRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Name:      "external_request_duration_seconds",
+			Help:      "Latency of outgoing HTTP requests (CB-Spider, etc.) by target, method and result.",
+			Buckets:   []float64{0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		},
+		[]string{"target", "method", "result"},
+	)
+
+	// externalRequestCache counts cache hits and misses for GET requests of ExecuteHttpRequest
+	externalRequestCache = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "external_request_cache_total",
+			Help:      "Number of cache lookups for outgoing GET requests by target and result (hit or miss).",
+		},
+		[]string{"target", "result"},
+	)
+
+	// policyExecutionsTotal counts actions executed by the MCIS auto-control policies
+	policyExecutionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "policy_executions_total",
+			Help:      "Number of MCIS auto-control policy actions by namespace, action and result.",
+		},
+		[]string{"namespace", "action", "result"},
+	)
+)
+
+// requestMapCollector is a prometheus.Collector that reports requests tracked in RequestMap
+type requestMapCollector struct {
+	requests *prometheus.Desc
+}
+
+// Describe is func to send the descriptor of requestMapCollector
+func (rc *requestMapCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.requests
+}
+
+// Collect is func to count requests in RequestMap by status (Handling, Error, Success)
+func (rc *requestMapCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := map[string]float64{"Handling": 0, "Error": 0, "Success": 0}
+	RequestMap.Range(func(key, value interface{}) bool {
+		if details, ok := value.(RequestDetails); ok {
+			counts[details.Status]++
+		}
+		return true
+	})
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(rc.requests, prometheus.GaugeValue, count, status)
+	}
+}
+
+func init() {
+	prometheus.MustRegister(
+		apiRequestsTotal,
+		apiRequestDuration,
+		externalRequestDuration,
+		externalRequestCache,
+		policyExecutionsTotal,
+		&requestMapCollector{
+			requests: prometheus.NewDesc(
+				prometheus.BuildFQName(metricNamespace, "", "requests"),
+				"Number of requests tracked in the request map by status (Handling: in-flight, Error: failed, Success).",
+				[]string{"status"}, nil,
+			),
+		},
+	)
+}
+
+// ObserveApiRequest is func to record a REST API request to the metrics
+func ObserveApiRequest(method string, route string, status int, latency time.Duration) {
+	if route == "" {
+		route = "unknown"
+	}
+	apiRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	apiRequestDuration.WithLabelValues(method, route).Observe(latency.Seconds())
+}
+
+// ObservePolicyExecution is func to record an action of MCIS auto-control policy to the metrics
+func ObservePolicyExecution(nsId string, action string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failed"
+	}
+	policyExecutionsTotal.WithLabelValues(nsId, action, result).Inc()
+}
+
+// observeExternalRequest is func to record an outgoing HTTP request of ExecuteHttpRequest to the metrics
+func observeExternalRequest(method string, url string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	externalRequestDuration.WithLabelValues(requestTarget(url), method, result).Observe(time.Since(start).Seconds())
+}
+
+// observeExternalRequestCache is func to record a cache lookup of ExecuteHttpRequest to the metrics
+func observeExternalRequestCache(url string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	externalRequestCache.WithLabelValues(requestTarget(url), result).Inc()
+}
+
+// requestTarget is func to classify the target framework of the URL to keep label cardinality low
+func requestTarget(url string) string {
+	switch {
+	case SpiderRestUrl != "" && strings.HasPrefix(url, SpiderRestUrl):
+		return "spider"
+	case DragonflyRestUrl != "" && strings.HasPrefix(url, DragonflyRestUrl):
+		return "dragonfly"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common is to include common methods for managing multi-cloud infra
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// SecretReq is struct for a namespace secret (e.g., a token used in remote commands by $$Func(GetSecret(key=...)))
+type SecretReq struct {
+	Value       string `json:"value" validate:"required" example:"my-token"`
+	Description string `json:"description" example:"token for the artifact repository"`
+}
+
+// SecretInfo is struct for the metadata of a namespace secret (the value is not exposed)
+type SecretInfo struct {
+	Key         string `json:"key" example:"repoToken"`
+	Description string `json:"description" example:"token for the artifact repository"`
+	UpdatedTime string `json:"updatedTime" example:"2024-01-01T00:00:00Z"`
+}
+
+// SecretInfoList is struct for a list of namespace secrets
+type SecretInfoList struct {
+	Secret []SecretInfo `json:"secret"`
+}
+
+// secretObject is struct for a namespace secret in the key-value store
+// (the value is stored in plaintext, so access to the key-value store should be protected)
+type secretObject struct {
+	SecretInfo
+	Value string `json:"value"`
+}
+
+// genSecretKey is func to generate a key for a namespace secret
+func genSecretKey(nsId string, secretKey string) string {
+	return "/ns/" + nsId + "/secret/" + secretKey
+}
+
+// PutSecret is func to create or update a namespace secret
+func PutSecret(nsId string, secretKey string, u *SecretReq) (SecretInfo, error) {
+
+	err := CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SecretInfo{}, err
+	}
+	err = CheckString(secretKey)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SecretInfo{}, err
+	}
+	check, err := CheckNs(nsId)
+	if !check {
+		err := fmt.Errorf("The namespace " + nsId + " does not exist.")
+		return SecretInfo{}, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SecretInfo{}, err
+	}
+	if u.Value == "" {
+		err := fmt.Errorf("The value of the secret " + secretKey + " is empty.")
+		return SecretInfo{}, err
+	}
+
+	obj := secretObject{
+		SecretInfo: SecretInfo{
+			Key:         secretKey,
+			Description: u.Description,
+			UpdatedTime: time.Now().UTC().Format(time.RFC3339),
+		},
+		Value: u.Value,
+	}
+	val, err := json.Marshal(obj)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SecretInfo{}, err
+	}
+	err = CBStore.Put(genSecretKey(nsId, secretKey), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SecretInfo{}, err
+	}
+	return obj.SecretInfo, nil
+}
+
+// GetSecretValue is func to get the value of a namespace secret (for internal use only)
+func GetSecretValue(nsId string, secretKey string) (string, error) {
+
+	err := CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return "", err
+	}
+	keyValue, err := CBStore.Get(genSecretKey(nsId, secretKey))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return "", err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The secret " + secretKey + " does not exist in " + nsId + ".")
+		return "", err
+	}
+	obj := secretObject{}
+	err = json.Unmarshal([]byte(keyValue.Value), &obj)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return "", err
+	}
+	return obj.Value, nil
+}
+
+// ListSecret is func to list namespace secrets (without values)
+func ListSecret(nsId string) (SecretInfoList, error) {
+
+	result := SecretInfoList{Secret: []SecretInfo{}}
+
+	err := CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/secret"
+	keyValue, err := CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		obj := secretObject{}
+		err = json.Unmarshal([]byte(v.Value), &obj)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		result.Secret = append(result.Secret, obj.SecretInfo)
+	}
+	return result, nil
+}
+
+// DelSecret is func to delete a namespace secret
+func DelSecret(nsId string, secretKey string) error {
+
+	err := CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	key := genSecretKey(nsId, secretKey)
+	keyValue, err := CBStore.Get(key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The secret " + secretKey + " does not exist in " + nsId + ".")
+		return err
+	}
+	err = CBStore.Delete(key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
@@ -1512,6 +1512,16 @@ func LoadCommonResource() (common.IdList, error) {
 	}
 	rowsSpec = newRowsSpec
 
+	// optional columns of the asset are located by the header
+	spotCostPerHourColumn := -1
+	if len(rowsSpec) > 0 {
+		for j, header := range rowsSpec[0] {
+			if strings.EqualFold(strings.TrimSpace(header), "spotCostPerHour") {
+				spotCostPerHourColumn = j
+			}
+		}
+	}
+
 	// Read common specs and register spec objects
 	file, fileErr = os.Open("../assets/cloudimage.csv")
 	defer file.Close()
@@ -1659,11 +1669,21 @@ func LoadCommonResource() (common.IdList, error) {
 							log.Error().Msgf("Not valid evaluationScore01 value in the asset: %s", specInfoId)
 							evaluationScore01 = -99.9
 						}
+						// spot price is optional (0: unknown)
+						spotCostPerHour := 0.0
+						if spotCostPerHourColumn >= 0 && spotCostPerHourColumn < len(row) && strings.TrimSpace(row[spotCostPerHourColumn]) != "" {
+							spotCostPerHour, err2 = strconv.ParseFloat(strings.ReplaceAll(row[spotCostPerHourColumn], " ", ""), 32)
+							if err2 != nil || spotCostPerHour < 0 {
+								log.Error().Msgf("Not valid spotCostPerHour value in the asset: %s", specInfoId)
+								spotCostPerHour = 0
+							}
+						}
 						specUpdateRequest :=
 							TbSpecInfo{
 								ProviderName:        providerName,
 								RegionName:          regionName,
 								CostPerHour:         float32(costPerHour),
+								SpotCostPerHour:     float32(spotCostPerHour),
 								RootDiskType:        rootDiskType,
 								RootDiskSize:        rootDiskSize,
 								AcceleratorType:     acceleratorType,
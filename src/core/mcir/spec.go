@@ -100,6 +100,7 @@ type TbSpecInfo struct { // Tumblebug
 	AcceleratorMemoryGB   float32  `json:"acceleratorMemoryGB,omitempty"`
 	AcceleratorType       string   `json:"acceleratorType,omitempty"`
 	CostPerHour           float32  `json:"costPerHour,omitempty"`
+	SpotCostPerHour       float32  `json:"spotCostPerHour,omitempty"`
 	Description           string   `json:"description,omitempty"`
 	OrderInFilteredResult uint16   `json:"orderInFilteredResult,omitempty"`
 	EvaluationStatus      string   `json:"evaluationStatus,omitempty"`
@@ -141,6 +142,7 @@ type FilterSpecsByRangeRequest struct {
 	AcceleratorMemoryGB Range  `json:"acceleratorMemoryGB"`
 	AcceleratorType     string `json:"acceleratorType"`
 	CostPerHour         Range  `json:"costPerHour"`
+	SpotCostPerHour     Range  `json:"spotCostPerHour"`
 	Description         string `json:"description"`
 	EvaluationStatus    string `json:"evaluationStatus"`
 	EvaluationScore01   Range  `json:"evaluationScore01"`
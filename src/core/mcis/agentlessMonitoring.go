@@ -0,0 +1,424 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultAgentlessIntervalSec int = 60
+	defaultAgentlessMaxSamples  int = 360
+	minAgentlessIntervalSec     int = 10
+	maxAgentlessMaxSamples      int = 10000
+)
+
+// agentlessMetricCmd samples cpu and network twice with 1 second interval,
+// and prints memory and root disk usage. Each line is "<KEY> <values>".
+const agentlessMetricCmd = `netTx() { awk 'NR>2 {sub(/^ +/,""); split($0,a,/[: ]+/); if (a[1]!="lo") tx+=a[10]} END {print tx+0}' /proc/net/dev; }; ` +
+	`echo "CPU1 $(head -1 /proc/stat)"; echo "NET1 $(netTx)"; sleep 1; ` +
+	`echo "CPU2 $(head -1 /proc/stat)"; echo "NET2 $(netTx)"; ` +
+	`echo "MEM $(awk '/^MemTotal:/ {t=$2} /^MemAvailable:/ {a=$2} END {print t, a}' /proc/meminfo)"; ` +
+	`echo "DISK $(df -P / | awk 'NR==2 {print $5}' | tr -d '%')"`
+
+// AgentlessMonitoringReq is struct for the request to enable agentless (SSH-based) monitoring for an MCIS
+type AgentlessMonitoringReq struct {
+	// UserName is the SSH user name for VMs (optional, the VM default user will be used)
+	UserName string `json:"userName" example:"cb-user"`
+	// IntervalSec is the sampling interval in seconds (min 10, default 60)
+	IntervalSec int `json:"intervalSec" example:"60"`
+	// MaxSamples is the number of samples to keep for each VM and metric (default 360)
+	MaxSamples int `json:"maxSamples" example:"360"`
+}
+
+// AgentlessMonitoringInfo is struct for the agentless monitoring configuration of an MCIS
+type AgentlessMonitoringInfo struct {
+	NsId          string `json:"nsId" example:"ns01"`
+	McisId        string `json:"mcisId" example:"mcis01"`
+	UserName      string `json:"userName" example:"cb-user"`
+	IntervalSec   int    `json:"intervalSec" example:"60"`
+	MaxSamples    int    `json:"maxSamples" example:"360"`
+	LastCollected string `json:"lastCollected" example:"2024-03-01T12:00:00Z"`
+}
+
+// MonMetricSample is struct for a sample of a metric time series
+type MonMetricSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// agentlessMetricStore keeps bounded time series of agentless monitoring (key: nsId/mcisId/vmId/metric)
+var agentlessMetricStore = struct {
+	sync.RWMutex
+	series map[string][]MonMetricSample
+}{series: make(map[string][]MonMetricSample)}
+
+// agentlessCollecting is to avoid overlapped collection for an MCIS (key: nsId/mcisId)
+var agentlessCollecting sync.Map
+
+func genAgentlessMonitoringKey(nsId string, mcisId string) string {
+	return "/ns/" + nsId + "/monitoring/agentless/mcis/" + mcisId
+}
+
+func genAgentlessSeriesKey(nsId string, mcisId string, vmId string, metric string) string {
+	return nsId + "/" + mcisId + "/" + vmId + "/" + metric
+}
+
+// EnableAgentlessMonitoring is func to enable the agentless (SSH-based) metric collection for an MCIS
+func EnableAgentlessMonitoring(nsId string, mcisId string, req *AgentlessMonitoringReq) (AgentlessMonitoringInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+	check, _ := CheckMcis(nsId, mcisId)
+	if !check {
+		err := fmt.Errorf("The mcis " + mcisId + " does not exist.")
+		return AgentlessMonitoringInfo{}, err
+	}
+
+	info := AgentlessMonitoringInfo{
+		NsId:        nsId,
+		McisId:      mcisId,
+		UserName:    req.UserName,
+		IntervalSec: req.IntervalSec,
+		MaxSamples:  req.MaxSamples,
+	}
+	if info.IntervalSec == 0 {
+		info.IntervalSec = defaultAgentlessIntervalSec
+	}
+	if info.IntervalSec < minAgentlessIntervalSec {
+		err := fmt.Errorf("intervalSec should be equal to or larger than %d", minAgentlessIntervalSec)
+		return AgentlessMonitoringInfo{}, err
+	}
+	if info.MaxSamples == 0 {
+		info.MaxSamples = defaultAgentlessMaxSamples
+	}
+	if info.MaxSamples < 1 || info.MaxSamples > maxAgentlessMaxSamples {
+		err := fmt.Errorf("maxSamples should be in the range of 1-%d", maxAgentlessMaxSamples)
+		return AgentlessMonitoringInfo{}, err
+	}
+
+	err = putAgentlessMonitoringInfo(info)
+	if err != nil {
+		return AgentlessMonitoringInfo{}, err
+	}
+	return info, nil
+}
+
+// GetAgentlessMonitoringInfo is func to get the agentless monitoring configuration of an MCIS
+func GetAgentlessMonitoringInfo(nsId string, mcisId string) (AgentlessMonitoringInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genAgentlessMonitoringKey(nsId, mcisId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("Agentless monitoring is not enabled for the mcis " + mcisId)
+		return AgentlessMonitoringInfo{}, err
+	}
+
+	info := AgentlessMonitoringInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &info)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return AgentlessMonitoringInfo{}, err
+	}
+	return info, nil
+}
+
+// DisableAgentlessMonitoring is func to disable the agentless monitoring and drop the collected series of an MCIS
+func DisableAgentlessMonitoring(nsId string, mcisId string) error {
+
+	_, err := GetAgentlessMonitoringInfo(nsId, mcisId)
+	if err != nil {
+		return err
+	}
+
+	err = common.CBStore.Delete(genAgentlessMonitoringKey(nsId, mcisId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+
+	prefix := nsId + "/" + mcisId + "/"
+	agentlessMetricStore.Lock()
+	for k := range agentlessMetricStore.series {
+		if strings.HasPrefix(k, prefix) {
+			delete(agentlessMetricStore.series, k)
+		}
+	}
+	agentlessMetricStore.Unlock()
+
+	return nil
+}
+
+func putAgentlessMonitoringInfo(info AgentlessMonitoringInfo) error {
+	val, _ := json.Marshal(info)
+	err := common.CBStore.Put(genAgentlessMonitoringKey(info.NsId, info.McisId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// listAgentlessMonitoringInfo is func to list agentless monitoring configurations in a namespace
+func listAgentlessMonitoringInfo(nsId string) []AgentlessMonitoringInfo {
+	keyValue, err := common.CBStore.GetList("/ns/"+nsId+"/monitoring/agentless/mcis/", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil
+	}
+	var infoList []AgentlessMonitoringInfo
+	for _, v := range keyValue {
+		info := AgentlessMonitoringInfo{}
+		if err := json.Unmarshal([]byte(v.Value), &info); err == nil {
+			infoList = append(infoList, info)
+		}
+	}
+	return infoList
+}
+
+// AgentlessMonitoringController is to collect metrics of MCISs with agentless monitoring enabled.
+// AgentlessMonitoringController will be periodically invoked by a time.NewTicker in main.go.
+func AgentlessMonitoringController() {
+
+	nsList, err := common.ListNsId()
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+
+	for _, nsId := range nsList {
+		for _, info := range listAgentlessMonitoringInfo(nsId) {
+
+			lastCollected, _ := time.Parse(time.RFC3339, info.LastCollected)
+			if time.Since(lastCollected) < time.Duration(info.IntervalSec)*time.Second {
+				continue
+			}
+			collectingKey := info.NsId + "/" + info.McisId
+			if _, loaded := agentlessCollecting.LoadOrStore(collectingKey, true); loaded {
+				continue
+			}
+
+			go func(info AgentlessMonitoringInfo) {
+				defer agentlessCollecting.Delete(info.NsId + "/" + info.McisId)
+
+				check, _ := CheckMcis(info.NsId, info.McisId)
+				if !check {
+					// the MCIS has been removed, so the configuration is not valid anymore
+					DisableAgentlessMonitoring(info.NsId, info.McisId)
+					return
+				}
+				CollectAgentlessMetrics(info.NsId, info.McisId, info.UserName, info.MaxSamples)
+
+				// update the collection time unless the monitoring was disabled in the meantime
+				if _, err := GetAgentlessMonitoringInfo(info.NsId, info.McisId); err == nil {
+					info.LastCollected = time.Now().UTC().Format(time.RFC3339)
+					putAgentlessMonitoringInfo(info)
+				}
+			}(info)
+		}
+	}
+}
+
+// CollectAgentlessMetrics is func to sample cpu, mem, disk, net of all VMs in an MCIS via SSH (bastion supported)
+func CollectAgentlessMetrics(nsId string, mcisId string, userName string, maxSamples int) {
+
+	vmList, err := ListVmId(nsId, mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, vmId := range vmList {
+		vmObj, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil || vmObj.Status != StatusRunning {
+			// skip VMs that are not available for SSH
+			continue
+		}
+		wg.Add(1)
+		go func(vmId string) {
+			defer wg.Done()
+
+			stdout, stderr, err := RunRemoteCommand(nsId, mcisId, vmId, userName, []string{agentlessMetricCmd})
+			if err != nil {
+				log.Error().Err(err).Msgf("[Agentless monitoring] %s/%s/%s: %s", nsId, mcisId, vmId, stderr[0])
+				return
+			}
+			values, err := parseAgentlessMetricOutput(stdout[0])
+			if err != nil {
+				log.Error().Err(err).Msgf("[Agentless monitoring] %s/%s/%s", nsId, mcisId, vmId)
+				return
+			}
+
+			now := time.Now()
+			agentlessMetricStore.Lock()
+			defer agentlessMetricStore.Unlock()
+			for metric, value := range values {
+				key := genAgentlessSeriesKey(nsId, mcisId, vmId, metric)
+				series := append(agentlessMetricStore.series[key], MonMetricSample{Timestamp: now, Value: value})
+				if len(series) > maxSamples {
+					series = series[len(series)-maxSamples:]
+				}
+				agentlessMetricStore.series[key] = series
+			}
+		}(vmId)
+	}
+	wg.Wait()
+}
+
+// parseAgentlessMetricOutput is func to calculate metric values from the output of agentlessMetricCmd
+// (cpu, mem, disk: utilization in percent, net: transmitted bytes per second)
+func parseAgentlessMetricOutput(output string) (map[string]float64, error) {
+
+	fields := make(map[string][]string)
+	for _, line := range strings.Split(output, "\n") {
+		f := strings.Fields(line)
+		if len(f) > 0 {
+			fields[f[0]] = f[1:]
+		}
+	}
+
+	values := make(map[string]float64)
+
+	// cpu: /proc/stat "cpu user nice system idle iowait irq softirq steal ..."
+	cpu1, cpu2 := fields["CPU1"], fields["CPU2"]
+	if len(cpu1) > 4 && len(cpu2) > 4 {
+		total1, idle1 := sumCpuTicks(cpu1[1:])
+		total2, idle2 := sumCpuTicks(cpu2[1:])
+		if total2 > total1 {
+			values[monMetricCpu] = 100 * (1 - (idle2-idle1)/(total2-total1))
+		}
+	}
+
+	if len(fields["NET1"]) == 1 && len(fields["NET2"]) == 1 {
+		tx1, err1 := strconv.ParseFloat(fields["NET1"][0], 64)
+		tx2, err2 := strconv.ParseFloat(fields["NET2"][0], 64)
+		if err1 == nil && err2 == nil && tx2 >= tx1 {
+			values[monMetricNet] = tx2 - tx1
+		}
+	}
+
+	if mem := fields["MEM"]; len(mem) == 2 {
+		memTotal, err1 := strconv.ParseFloat(mem[0], 64)
+		memAvailable, err2 := strconv.ParseFloat(mem[1], 64)
+		if err1 == nil && err2 == nil && memTotal > 0 {
+			values[monMetricMem] = 100 * (memTotal - memAvailable) / memTotal
+		}
+	}
+
+	if disk := fields["DISK"]; len(disk) == 1 {
+		if v, err := strconv.ParseFloat(disk[0], 64); err == nil {
+			values[monMetricDisk] = v
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no metric in the output: %s", output)
+	}
+	return values, nil
+}
+
+// sumCpuTicks is func to get total and idle (idle + iowait) ticks from the cpu line of /proc/stat
+func sumCpuTicks(ticks []string) (float64, float64) {
+	total := 0.0
+	idle := 0.0
+	for i, t := range ticks {
+		v, _ := strconv.ParseFloat(t, 64)
+		// guest and guest_nice (index 8, 9) are already included in user and nice
+		if i < 8 {
+			total += v
+		}
+		if i == 3 || i == 4 {
+			idle += v
+		}
+	}
+	return total, idle
+}
+
+// GetAgentlessMetricSeries is func to get the collected time series of a metric for a VM
+func GetAgentlessMetricSeries(nsId string, mcisId string, vmId string, metric string) []MonMetricSample {
+	agentlessMetricStore.RLock()
+	defer agentlessMetricStore.RUnlock()
+
+	series := agentlessMetricStore.series[genAgentlessSeriesKey(nsId, mcisId, vmId, metric)]
+	return append([]MonMetricSample{}, series...)
+}
+
+// GetAgentlessMonitoringData is func to get the latest agentless monitoring values in the shape of GetMonitoringData
+func GetAgentlessMonitoringData(nsId string, mcisId string, metric string) (MonResultSimpleResponse, error) {
+
+	content := MonResultSimpleResponse{}
+
+	_, err := GetAgentlessMonitoringInfo(nsId, mcisId)
+	if err != nil {
+		return content, err
+	}
+	switch metric {
+	case monMetricCpu, monMetricMem, monMetricDisk, monMetricNet:
+	default:
+		err := fmt.Errorf("Not supported metric for agentless monitoring: %s (cpu, mem, disk, net)", metric)
+		return content, err
+	}
+
+	vmList, err := ListVmId(nsId, mcisId)
+	if err != nil {
+		return content, err
+	}
+
+	content.NsId = nsId
+	content.McisId = mcisId
+	for _, vmId := range vmList {
+		result := MonResultSimple{Metric: metric, VmId: vmId}
+		series := GetAgentlessMetricSeries(nsId, mcisId, vmId, metric)
+		if len(series) == 0 {
+			result.Err = "No collected data for the VM yet"
+		} else {
+			result.Value = fmt.Sprintf("%f", series[len(series)-1].Value)
+		}
+		content.McisMonitoring = append(content.McisMonitoring, result)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// bastionMaxHops is the maximum number of bastions in a chain (multi-hop)
+	bastionMaxHops = 4
+
+	// bastionMaxChains is the maximum number of candidate bastion chains to a VM (for failover)
+	bastionMaxChains = 8
+)
+
+// getBastionChains is func to get candidate chains of bastions to reach a VM in the order of preference,
+// and a func to get fallback chains (other VMs with public IPs in the same subnet and vNet) to be used
+// when all the candidates are down. If no bastion is assigned to the subnet of the VM, one of the VMs
+// with public IPs is assigned. A bastion without a public IP is reached through its own bastions
+// (multi-hop, e.g., for cross-vNet private access).
+func getBastionChains(nsId string, mcisId string, vmId string, givenUserName string) ([][]sshInfo, func() [][]sshInfo, error) {
+
+	assigned, err := GetBastionNodes(nsId, mcisId, vmId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fallback := func() [][]sshInfo {
+		candidates, err := findBastionCandidates(nsId, mcisId, vmId)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return nil
+		}
+		var others []mcir.BastionNode
+		for _, c := range candidates {
+			duplicated := false
+			for _, n := range assigned {
+				if n == c {
+					duplicated = true
+				}
+			}
+			if !duplicated {
+				others = append(others, c)
+			}
+		}
+		chains, _ := resolveBastionNodes(nsId, mcisId, vmId, others, givenUserName)
+		return chains
+	}
+
+	if len(assigned) == 0 {
+		candidates, err := findBastionCandidates(nsId, mcisId, vmId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(candidates) == 0 {
+			err := fmt.Errorf("no bastion for VM (ID: %s) in MCIS (ID: %s): set a bastion node or assign a public IP to a VM in the same vNet", vmId, mcisId)
+			return nil, nil, err
+		}
+		msg, err := setBastionNode(nsId, mcisId, vmId, candidates[0])
+		if err != nil {
+			log.Error().Err(err).Msg("")
+		} else {
+			log.Info().Msg("[Bastion] " + msg)
+		}
+		chains, err := resolveBastionNodes(nsId, mcisId, vmId, candidates, givenUserName)
+		return chains, nil, err
+	}
+
+	chains, err := resolveBastionNodes(nsId, mcisId, vmId, assigned, givenUserName)
+	if err != nil {
+		// all the assigned bastions are unavailable
+		log.Warn().Err(err).Msg("")
+		if chains = fallback(); len(chains) == 0 {
+			return nil, nil, err
+		}
+		return chains, nil, nil
+	}
+	return chains, fallback, nil
+}
+
+// resolveBastionNodes is func to get chains of bastions to reach a VM through the bastion nodes
+func resolveBastionNodes(nsId string, mcisId string, vmId string, nodes []mcir.BastionNode, givenUserName string) ([][]sshInfo, error) {
+
+	var chains [][]sshInfo
+	var errs []string
+	for _, node := range nodes {
+		if node.McisId == mcisId && node.VmId == vmId {
+			// the VM is the bastion of its subnet (reached directly if it has a public IP)
+			errs = append(errs, fmt.Sprintf("bastion %s/%s is the VM itself without a public IP", mcisId, vmId))
+			continue
+		}
+		nodeChains, err := resolveBastionChains(nsId, node, givenUserName, map[string]bool{mcisId + "/" + vmId: true})
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		chains = append(chains, nodeChains...)
+		if len(chains) >= bastionMaxChains {
+			chains = chains[:bastionMaxChains]
+			break
+		}
+	}
+	if len(chains) == 0 {
+		err := fmt.Errorf("no available bastion for VM (ID: %s) in MCIS (ID: %s): %s", vmId, mcisId, strings.Join(errs, "; "))
+		return nil, err
+	}
+	return chains, nil
+}
+
+// resolveBastionChains is func to get chains of hops to reach a bastion node (the last hop is the node).
+// visited has the VMs in the current chain to avoid loops.
+func resolveBastionChains(nsId string, node mcir.BastionNode, givenUserName string, visited map[string]bool) ([][]sshInfo, error) {
+
+	nodeKey := node.McisId + "/" + node.VmId
+	if visited[nodeKey] {
+		return nil, fmt.Errorf("bastion %s is in a loop of bastions", nodeKey)
+	}
+	if len(visited) > bastionMaxHops {
+		return nil, fmt.Errorf("bastion %s exceeds the maximum number of hops (%d)", nodeKey, bastionMaxHops)
+	}
+
+	vmObj, err := GetVmObject(nsId, node.McisId, node.VmId)
+	if err != nil {
+		return nil, fmt.Errorf("bastion %s is not available: %w", nodeKey, err)
+	}
+	if !isBastionAvailableStatus(vmObj.Status) {
+		return nil, fmt.Errorf("bastion %s is %s", nodeKey, vmObj.Status)
+	}
+	ip := vmObj.PublicIP
+	if ip == "" {
+		ip = vmObj.PrivateIP
+	}
+	userName, privateKey, err := VerifySshUserName(nsId, node.McisId, node.VmId, ip, vmObj.SSHPort, givenUserName)
+	if err != nil {
+		return nil, fmt.Errorf("bastion %s: %w", nodeKey, err)
+	}
+	hop := sshInfo{
+		EndPoint:        fmt.Sprintf("%s:%s", ip, vmObj.SSHPort),
+		UserName:        userName,
+		PrivateKey:      []byte(privateKey),
+		HostKeyCallback: vmHostKeyCallback(nsId, node.McisId, node.VmId),
+	}
+
+	// use public IP of the bastion VM
+	if vmObj.PublicIP != "" {
+		return [][]sshInfo{{hop}}, nil
+	}
+
+	// a bastion without a public IP is reached by its private IP through its own bastions
+	parents, err := GetBastionNodes(nsId, node.McisId, node.VmId)
+	if err != nil {
+		return nil, fmt.Errorf("bastion %s has no public IP: %w", nodeKey, err)
+	}
+	visited[nodeKey] = true
+	defer delete(visited, nodeKey)
+
+	var chains [][]sshInfo
+	for _, parent := range parents {
+		parentChains, err := resolveBastionChains(nsId, parent, givenUserName, visited)
+		if err != nil {
+			log.Debug().Err(err).Msg("")
+			continue
+		}
+		for _, chain := range parentChains {
+			chains = append(chains, append(append([]sshInfo{}, chain...), hop))
+		}
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("bastion %s has no public IP and no reachable bastion", nodeKey)
+	}
+	return chains, nil
+}
+
+// findBastionCandidates is func to find VMs with public IPs that can be bastions of a VM
+// (VMs in the same subnet first, then VMs in the same vNet in any MCIS of the namespace, except the VM itself)
+func findBastionCandidates(nsId string, mcisId string, vmId string) ([]mcir.BastionNode, error) {
+
+	vmObj, err := GetVmObject(nsId, mcisId, vmId)
+	if err != nil {
+		return nil, err
+	}
+
+	mcisList, err := ListMcisId(nsId)
+	if err != nil {
+		return nil, err
+	}
+	// check the MCIS of the VM first
+	mcisList = append([]string{mcisId}, mcisList...)
+
+	var sameSubnet, sameVNet []mcir.BastionNode
+	checked := map[string]bool{}
+	for _, m := range mcisList {
+		if checked[m] {
+			continue
+		}
+		checked[m] = true
+
+		vmList, err := ListVmId(nsId, m)
+		if err != nil {
+			log.Debug().Err(err).Msg("")
+			continue
+		}
+		for _, v := range vmList {
+			if m == mcisId && v == vmId {
+				continue
+			}
+			candidate, err := GetVmObject(nsId, m, v)
+			if err != nil {
+				continue
+			}
+			if candidate.PublicIP == "" || !isBastionAvailableStatus(candidate.Status) || candidate.VNetId != vmObj.VNetId {
+				continue
+			}
+			node := mcir.BastionNode{McisId: m, VmId: v}
+			if candidate.SubnetId == vmObj.SubnetId {
+				sameSubnet = append(sameSubnet, node)
+			} else {
+				sameVNet = append(sameVNet, node)
+			}
+		}
+	}
+	return append(sameSubnet, sameVNet...), nil
+}
+
+// isBastionAvailableStatus is func to check whether a VM in the status can be a bastion
+func isBastionAvailableStatus(status string) bool {
+	switch status {
+	case StatusSuspended, StatusSuspending, StatusFailed, StatusTerminated, StatusTerminating:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	bastionTestNs    = "tb-unit-test-bastion"
+	bastionTestMcis  = "mcis01"
+	bastionTestVNet  = "vnet01"
+	bastionTestKeyId = "key01"
+)
+
+// putBastionTestObject is func to put an object for bastion tests in the key-value store
+func putBastionTestObject(t *testing.T, key string, obj interface{}) {
+	val, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = common.CBStore.Put(key, string(val))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupBastionTest is func to put VMs, the vNet (bastions of the subnets) and the SSH key for bastion tests
+func setupBastionTest(t *testing.T, vms []TbVmInfo, subnets []mcir.TbSubnetInfo) {
+	t.Cleanup(func() {
+		keyValue, _ := common.CBStore.GetList(common.GenMcisKey(bastionTestNs, "", ""), true)
+		for _, v := range keyValue {
+			common.CBStore.Delete(v.Key)
+		}
+	})
+
+	putBastionTestObject(t, common.GenResourceKey(bastionTestNs, common.StrSSHKey, bastionTestKeyId),
+		mcir.TbSshKeyInfo{Id: bastionTestKeyId, Username: "cb-user", PrivateKey: "dummy"})
+	putBastionTestObject(t, common.GenResourceKey(bastionTestNs, common.StrVNet, bastionTestVNet),
+		mcir.TbVNetInfo{Id: bastionTestVNet, SubnetInfoList: subnets})
+	putBastionTestObject(t, common.GenMcisKey(bastionTestNs, bastionTestMcis, ""), TbMcisInfo{Id: bastionTestMcis})
+	for _, vm := range vms {
+		vm.VNetId = bastionTestVNet
+		vm.SshKeyId = bastionTestKeyId
+		vm.SSHPort = "22"
+		vm.Status = StatusRunning
+		putBastionTestObject(t, common.GenMcisKey(bastionTestNs, bastionTestMcis, vm.Id), vm)
+	}
+}
+
+func TestSshRouteOfSelfBastion(t *testing.T) {
+	// a single VM with a public IP is the bastion of its own subnet
+	setupBastionTest(t,
+		[]TbVmInfo{{Id: "vm-a", SubnetId: "subnet01", PublicIP: "1.2.3.4", PrivateIP: "10.0.1.4"}},
+		[]mcir.TbSubnetInfo{{Id: "subnet01", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}}},
+	)
+
+	route, err := getSshRouteOfVm(bastionTestNs, bastionTestMcis, "vm-a", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4:22", route.Target.EndPoint)
+	assert.Equal(t, [][]sshInfo{{}}, route.BastionChains, "self-bastion should be a direct route with zero hops")
+
+	candidates, err := findBastionCandidates(bastionTestNs, bastionTestMcis, "vm-a")
+	assert.NoError(t, err)
+	assert.Empty(t, candidates, "the VM itself should not be a bastion candidate")
+}
+
+func TestSshRouteThroughBastion(t *testing.T) {
+	setupBastionTest(t,
+		[]TbVmInfo{
+			{Id: "vm-a", SubnetId: "subnet01", PublicIP: "1.2.3.4", PrivateIP: "10.0.1.4"},
+			{Id: "vm-b", SubnetId: "subnet01", PrivateIP: "10.0.1.5"},
+		},
+		[]mcir.TbSubnetInfo{{Id: "subnet01", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}}},
+	)
+
+	route, err := getSshRouteOfVm(bastionTestNs, bastionTestMcis, "vm-b", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.1.5:22", route.Target.EndPoint)
+	if assert.Len(t, route.BastionChains, 1) && assert.Len(t, route.BastionChains[0], 1) {
+		assert.Equal(t, "1.2.3.4:22", route.BastionChains[0][0].EndPoint)
+	}
+
+	candidates, err := findBastionCandidates(bastionTestNs, bastionTestMcis, "vm-b")
+	assert.NoError(t, err)
+	assert.Equal(t, []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}, candidates)
+}
+
+func TestSshRouteOfPublicVmWithAssignedBastion(t *testing.T) {
+	// SSH to vm-b may be allowed only from vm-a, the bastion assigned to the subnet
+	setupBastionTest(t,
+		[]TbVmInfo{
+			{Id: "vm-a", SubnetId: "subnet01", PublicIP: "1.2.3.4", PrivateIP: "10.0.1.4"},
+			{Id: "vm-b", SubnetId: "subnet01", PublicIP: "1.2.3.5", PrivateIP: "10.0.1.5"},
+		},
+		[]mcir.TbSubnetInfo{{Id: "subnet01", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}}},
+	)
+
+	route, err := getSshRouteOfVm(bastionTestNs, bastionTestMcis, "vm-b", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.1.5:22", route.Target.EndPoint, "the assigned bastion should be used")
+	if assert.Len(t, route.BastionChains, 1) && assert.Len(t, route.BastionChains[0], 1) {
+		assert.Equal(t, "1.2.3.4:22", route.BastionChains[0][0].EndPoint)
+	}
+
+	// the bastion itself is reached directly
+	route, err = getSshRouteOfVm(bastionTestNs, bastionTestMcis, "vm-a", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4:22", route.Target.EndPoint)
+	assert.Equal(t, [][]sshInfo{{}}, route.BastionChains)
+}
+
+func TestBastionLoop(t *testing.T) {
+	// vm-a (subnet01) and vm-b (subnet02) without public IPs are the bastions of each other's subnet (A->B->A)
+	setupBastionTest(t,
+		[]TbVmInfo{
+			{Id: "vm-a", SubnetId: "subnet01", PrivateIP: "10.0.1.4"},
+			{Id: "vm-b", SubnetId: "subnet02", PrivateIP: "10.0.2.4"},
+			{Id: "vm-c", SubnetId: "subnet01", PrivateIP: "10.0.1.5"},
+		},
+		[]mcir.TbSubnetInfo{
+			{Id: "subnet01", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-b"}}},
+			{Id: "subnet02", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}},
+		},
+	)
+
+	_, err := resolveBastionChains(bastionTestNs, mcir.BastionNode{McisId: bastionTestMcis, VmId: "vm-b"}, "",
+		map[string]bool{bastionTestMcis + "/vm-c": true})
+	assert.ErrorContains(t, err, "no reachable bastion")
+
+	_, err = resolveBastionNodes(bastionTestNs, bastionTestMcis, "vm-c",
+		[]mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-b"}}, "")
+	assert.ErrorContains(t, err, "no available bastion")
+
+	// the loop is detected at the repeated bastion
+	visited := map[string]bool{bastionTestMcis + "/vm-c": true, bastionTestMcis + "/vm-b": true}
+	_, err = resolveBastionChains(bastionTestNs, mcir.BastionNode{McisId: bastionTestMcis, VmId: "vm-b"}, "", visited)
+	assert.ErrorContains(t, err, "loop")
+
+	_, err = getSshRouteOfVm(bastionTestNs, bastionTestMcis, "vm-c", "")
+	assert.Error(t, err, "no route should be found through a loop of bastions")
+}
+
+func TestBastionSelfWithoutPublicIP(t *testing.T) {
+	// the VM is assigned as the bastion of its subnet but has no public IP
+	setupBastionTest(t,
+		[]TbVmInfo{{Id: "vm-a", SubnetId: "subnet01", PrivateIP: "10.0.1.4"}},
+		[]mcir.TbSubnetInfo{{Id: "subnet01", BastionNodes: []mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}}},
+	)
+
+	_, err := resolveBastionNodes(bastionTestNs, bastionTestMcis, "vm-a",
+		[]mcir.BastionNode{{McisId: bastionTestMcis, VmId: "vm-a"}}, "")
+	assert.ErrorContains(t, err, "the VM itself without a public IP")
+}
@@ -632,6 +632,14 @@ func BenchmarkAction(nsId string, mcisId string, action string, option string) (
 	}
 	wg.Wait() //goroutine sync wg
 
+	if action == "mrtt" {
+		// keep measured latencies between regions for recommendation and placement
+		updateLatencyFromBenchmark(results)
+	} else {
+		// keep measured results of specs for evaluation scores of specs
+		recordSpecBenchmarkResults(nsId, mcisId, action, results)
+	}
+
 	return results, nil
 
 }
@@ -0,0 +1,581 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// BenchmarkSuiteTypeVm is a suite executed in each VM
+	BenchmarkSuiteTypeVm string = "vm"
+	// BenchmarkSuiteTypePair is a suite executed in each pair of VMs (from a source VM to a target VM)
+	BenchmarkSuiteTypePair string = "pair"
+)
+
+// benchmarkSuiteDefaultTimeoutSec is the default timeout of each command of a benchmark suite
+const benchmarkSuiteDefaultTimeoutSec = 600
+
+// benchmarkSuiteMaxOutput is the max length of the output kept in a result of a benchmark suite
+const benchmarkSuiteMaxOutput = 2048
+
+// installPackageCmd is the shell command to install packages with the package manager of the VM
+const installPackageCmd = "(command -v apt-get >/dev/null && sudo apt-get update -qq && sudo DEBIAN_FRONTEND=noninteractive apt-get install -y -qq %[1]s) || " +
+	"(command -v dnf >/dev/null && sudo dnf install -y -q %[1]s) || (command -v yum >/dev/null && sudo yum install -y -q epel-release; sudo yum install -y -q %[1]s)"
+
+// BenchmarkSuite is struct for a benchmark executed by remote commands and an output parser
+type BenchmarkSuite struct {
+	Name        string `json:"name" validate:"required" example:"sysbench-cpu"`
+	Description string `json:"description" example:"CPU benchmark by sysbench (events per second with all cores)"`
+	Type        string `json:"type" validate:"required,oneof=vm pair" example:"vm" enums:"vm,pair"`
+
+	// InstallCommand is executed in each VM before running the suite (should be idempotent)
+	InstallCommand []string `json:"installCommand,omitempty" example:"command -v sysbench || sudo apt-get install -y sysbench"`
+	// ServerCommand is executed in each target VM before running a pair suite (e.g., starting a server in background)
+	ServerCommand []string `json:"serverCommand,omitempty" example:"pgrep -x iperf3 || iperf3 -s -D"`
+	// RunCommand is executed to run the benchmark, and the output of the last command is parsed.
+	// Built-in functions of remote commands ($$Func) are available, and {{targetIp}}, {{targetPublicIp}},
+	// {{targetPrivateIp}}, {{targetVmId}} are replaced with those of the target VM in a pair suite
+	// ({{targetIp}} is the private IP if the VMs are in the same vNet, the public IP otherwise).
+	RunCommand []string `json:"runCommand" validate:"required" example:"sysbench cpu --threads=$(nproc) --time=10 run"`
+
+	Metrics []BenchmarkSuiteMetric `json:"metrics" validate:"required,dive"`
+
+	// TimeoutSec is the timeout of each command in seconds
+	TimeoutSec int `json:"timeoutSec,omitempty" validate:"min=0,max=86400" example:"600" default:"600"`
+
+	// BuiltIn is true for suites shipped with CB-Tumblebug (cannot be changed or deleted)
+	BuiltIn bool `json:"builtIn" example:"false"`
+}
+
+// BenchmarkSuiteMetric is struct for a metric parsed from the output of a benchmark suite
+type BenchmarkSuiteMetric struct {
+	Name string `json:"name" validate:"required" example:"eventsPerSec"`
+	// Pattern is the regular expression to get the value (the first capture group) from the output
+	Pattern       string `json:"pattern" validate:"required" example:"events per second:\\s*([0-9.]+)"`
+	Unit          string `json:"unit" example:"events/sec"`
+	LowerIsBetter bool   `json:"lowerIsBetter" example:"false"`
+}
+
+// BenchmarkSuiteList is struct for a list of benchmark suites
+type BenchmarkSuiteList struct {
+	BenchmarkSuite []BenchmarkSuite `json:"benchmarkSuite"`
+}
+
+// BenchmarkSuiteRunReq is struct for a request to run a benchmark suite in MCIS
+type BenchmarkSuiteRunReq struct {
+	Suite string `json:"suite" validate:"required" example:"sysbench-cpu"`
+	// SubGroupId is to run the suite only in VMs of the subGroup (all VMs in MCIS if omitted)
+	SubGroupId string `json:"subGroupId,omitempty" example:"g1"`
+	// VmIds is to run the suite only in the VMs (all VMs in MCIS if omitted)
+	VmIds    []string `json:"vmIds,omitempty"`
+	UserName string   `json:"userName,omitempty" example:"cb-user"`
+	// SkipInstall is to skip the install command (if the tools are already installed)
+	SkipInstall bool `json:"skipInstall,omitempty" example:"false"`
+}
+
+// BenchmarkSuiteMetricResult is struct for a value of a metric measured by a benchmark suite
+type BenchmarkSuiteMetricResult struct {
+	Name          string  `json:"name" example:"eventsPerSec"`
+	Value         float64 `json:"value" example:"1234.5"`
+	Unit          string  `json:"unit" example:"events/sec"`
+	LowerIsBetter bool    `json:"lowerIsBetter" example:"false"`
+}
+
+// BenchmarkSuiteVmResult is struct for a result of a benchmark suite in a VM (or a pair of VMs)
+type BenchmarkSuiteVmResult struct {
+	VmId   string `json:"vmId" example:"g1-1"`
+	SpecId string `json:"specId" example:"aws+ap-northeast-2+t2.small"`
+	// TargetVmId is the target VM of a pair suite
+	TargetVmId string                       `json:"targetVmId,omitempty" example:"g2-1"`
+	Metrics    []BenchmarkSuiteMetricResult `json:"metrics"`
+	// Output is the (tail of) output of the last run command
+	Output     string  `json:"output,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	ElapsedSec float64 `json:"elapsedSec" example:"10.5"`
+}
+
+// BenchmarkSuiteResult is struct for results of a benchmark suite in MCIS
+type BenchmarkSuiteResult struct {
+	Suite      string                   `json:"suite" example:"sysbench-cpu"`
+	Type       string                   `json:"type" example:"vm"`
+	McisId     string                   `json:"mcisId" example:"mcis01"`
+	StartedAt  string                   `json:"startedAt" example:"2024-01-01T00:00:00Z"`
+	ElapsedSec float64                  `json:"elapsedSec" example:"35.2"`
+	Results    []BenchmarkSuiteVmResult `json:"results"`
+}
+
+// builtInBenchmarkSuites is the list of benchmark suites shipped with CB-Tumblebug
+var builtInBenchmarkSuites = []BenchmarkSuite{
+	{
+		Name:           "sysbench-cpu",
+		Description:    "CPU benchmark by sysbench (events per second with all cores)",
+		Type:           BenchmarkSuiteTypeVm,
+		InstallCommand: []string{"command -v sysbench >/dev/null || { " + fmt.Sprintf(installPackageCmd, "sysbench") + "; }"},
+		RunCommand:     []string{"sysbench cpu --threads=$(nproc) --time=10 run"},
+		Metrics: []BenchmarkSuiteMetric{
+			{Name: "eventsPerSec", Pattern: `events per second:\s*([0-9.]+)`, Unit: "events/sec"},
+		},
+	},
+	{
+		Name:           "sysbench-mem",
+		Description:    "Memory benchmark by sysbench (throughput of sequential writes with all cores)",
+		Type:           BenchmarkSuiteTypeVm,
+		InstallCommand: []string{"command -v sysbench >/dev/null || { " + fmt.Sprintf(installPackageCmd, "sysbench") + "; }"},
+		RunCommand:     []string{"sysbench memory --threads=$(nproc) --memory-total-size=100G --time=10 run"},
+		Metrics: []BenchmarkSuiteMetric{
+			{Name: "throughput", Pattern: `\(([0-9.]+) MiB/sec\)`, Unit: "MiB/sec"},
+		},
+	},
+	{
+		Name:           "fio",
+		Description:    "Disk benchmark by fio (4KiB random read/write IOPS on the root disk)",
+		Type:           BenchmarkSuiteTypeVm,
+		InstallCommand: []string{"command -v fio >/dev/null || { " + fmt.Sprintf(installPackageCmd, "fio") + "; }"},
+		RunCommand: []string{"fio --name=tb-bench --filename=$HOME/tb-bench.fio --size=512M --rw=randrw --bs=4k --direct=1 --ioengine=libaio " +
+			"--iodepth=32 --runtime=20 --time_based --group_reporting --output-format=json; rm -f $HOME/tb-bench.fio"},
+		Metrics: []BenchmarkSuiteMetric{
+			{Name: "readIops", Pattern: `"read"\s*:\s*\{[^{}]*"iops"\s*:\s*([0-9.]+)`, Unit: "IOPS"},
+			{Name: "writeIops", Pattern: `"write"\s*:\s*\{[^{}]*"iops"\s*:\s*([0-9.]+)`, Unit: "IOPS"},
+		},
+	},
+	{
+		Name:           "iperf3",
+		Description:    "Network throughput between VMs by iperf3 (TCP port 5201 should be allowed in security groups)",
+		Type:           BenchmarkSuiteTypePair,
+		InstallCommand: []string{"command -v iperf3 >/dev/null || { " + fmt.Sprintf(installPackageCmd, "iperf3") + "; }"},
+		ServerCommand:  []string{"pgrep -x iperf3 >/dev/null || iperf3 -s -D"},
+		RunCommand:     []string{"iperf3 -c {{targetIp}} -t 10 -f m"},
+		Metrics: []BenchmarkSuiteMetric{
+			{Name: "throughput", Pattern: `([0-9.]+) Mbits/sec\s+receiver`, Unit: "Mbits/sec"},
+		},
+	},
+	{
+		Name:        "ping",
+		Description: "Network latency between VMs by ping (ICMP should be allowed in security groups)",
+		Type:        BenchmarkSuiteTypePair,
+		RunCommand:  []string{"ping -c 20 -i 0.2 -q {{targetIp}}"},
+		Metrics: []BenchmarkSuiteMetric{
+			{Name: "rtt", Pattern: `= [0-9.]+/([0-9.]+)/[0-9.]+/[0-9.]+ ms`, Unit: "ms", LowerIsBetter: true},
+			{Name: "jitter", Pattern: `= [0-9.]+/[0-9.]+/[0-9.]+/([0-9.]+) ms`, Unit: "ms", LowerIsBetter: true},
+			{Name: "packetLoss", Pattern: `([0-9.]+)% packet loss`, Unit: "%", LowerIsBetter: true},
+		},
+	},
+}
+
+// genBenchmarkSuiteKey is func to generate a key of a custom benchmark suite
+func genBenchmarkSuiteKey(name string) string {
+	return "/benchmarkSuite/" + name
+}
+
+// getBuiltInBenchmarkSuite is func to get a built-in benchmark suite
+func getBuiltInBenchmarkSuite(name string) (BenchmarkSuite, bool) {
+	for _, suite := range builtInBenchmarkSuites {
+		if suite.Name == name {
+			suite.BuiltIn = true
+			return suite, true
+		}
+	}
+	return BenchmarkSuite{}, false
+}
+
+// validateBenchmarkSuite is func to validate a benchmark suite
+func validateBenchmarkSuite(suite *BenchmarkSuite) error {
+	err := common.CheckString(suite.Name)
+	if err != nil {
+		return err
+	}
+	err = validate.Struct(suite)
+	if err != nil {
+		if _, ok := err.(*validator.InvalidValidationError); ok {
+			log.Err(err).Msg("")
+		}
+		return err
+	}
+	for _, m := range suite.Metrics {
+		re, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern of metric %s: %s", m.Name, err.Error())
+		}
+		if re.NumSubexp() < 1 {
+			return fmt.Errorf("pattern of metric %s should have a capture group for the value", m.Name)
+		}
+	}
+	return nil
+}
+
+// RegisterBenchmarkSuite is func to register (or update) a custom benchmark suite
+func RegisterBenchmarkSuite(suite *BenchmarkSuite) (BenchmarkSuite, error) {
+
+	suite.BuiltIn = false
+	err := validateBenchmarkSuite(suite)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return BenchmarkSuite{}, err
+	}
+	if _, ok := getBuiltInBenchmarkSuite(suite.Name); ok {
+		err := fmt.Errorf("The benchmark suite %s is a built-in suite", suite.Name)
+		return BenchmarkSuite{}, err
+	}
+
+	val, err := json.Marshal(suite)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return BenchmarkSuite{}, err
+	}
+	err = common.CBStore.Put(genBenchmarkSuiteKey(suite.Name), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return BenchmarkSuite{}, err
+	}
+	return *suite, nil
+}
+
+// GetBenchmarkSuite is func to get a benchmark suite (built-in or custom)
+func GetBenchmarkSuite(name string) (BenchmarkSuite, error) {
+	if suite, ok := getBuiltInBenchmarkSuite(name); ok {
+		return suite, nil
+	}
+
+	keyValue, err := common.CBStore.Get(genBenchmarkSuiteKey(name))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return BenchmarkSuite{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The benchmark suite %s does not exist", name)
+		return BenchmarkSuite{}, err
+	}
+	suite := BenchmarkSuite{}
+	err = json.Unmarshal([]byte(keyValue.Value), &suite)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return BenchmarkSuite{}, err
+	}
+	return suite, nil
+}
+
+// ListBenchmarkSuite is func to list benchmark suites (built-in suites first)
+func ListBenchmarkSuite() (BenchmarkSuiteList, error) {
+
+	result := BenchmarkSuiteList{BenchmarkSuite: []BenchmarkSuite{}}
+	for _, suite := range builtInBenchmarkSuites {
+		suite.BuiltIn = true
+		result.BenchmarkSuite = append(result.BenchmarkSuite, suite)
+	}
+
+	keyValue, err := common.CBStore.GetList("/benchmarkSuite", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	custom := []BenchmarkSuite{}
+	for _, v := range keyValue {
+		suite := BenchmarkSuite{}
+		err = json.Unmarshal([]byte(v.Value), &suite)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		custom = append(custom, suite)
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Name < custom[j].Name })
+	result.BenchmarkSuite = append(result.BenchmarkSuite, custom...)
+	return result, nil
+}
+
+// DelBenchmarkSuite is func to delete a custom benchmark suite
+func DelBenchmarkSuite(name string) error {
+	if _, ok := getBuiltInBenchmarkSuite(name); ok {
+		return fmt.Errorf("The benchmark suite %s is a built-in suite", name)
+	}
+	if _, err := GetBenchmarkSuite(name); err != nil {
+		return err
+	}
+	err := common.CBStore.Delete(genBenchmarkSuiteKey(name))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// parseBenchmarkSuiteOutput is func to get values of metrics from the output of a benchmark suite
+func parseBenchmarkSuiteOutput(suite BenchmarkSuite, output string) ([]BenchmarkSuiteMetricResult, error) {
+	results := []BenchmarkSuiteMetricResult{}
+	missing := []string{}
+	for _, m := range suite.Metrics {
+		re, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return results, err
+		}
+		matched := re.FindStringSubmatch(output)
+		if len(matched) < 2 {
+			missing = append(missing, m.Name)
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(matched[1]), 64)
+		if err != nil {
+			missing = append(missing, m.Name)
+			continue
+		}
+		results = append(results, BenchmarkSuiteMetricResult{Name: m.Name, Value: value, Unit: m.Unit, LowerIsBetter: m.LowerIsBetter})
+	}
+	if len(missing) > 0 {
+		return results, fmt.Errorf("cannot find metrics %v in the output", missing)
+	}
+	return results, nil
+}
+
+// tailOutput is func to get the tail of a long output
+func tailOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > benchmarkSuiteMaxOutput {
+		return "..." + output[len(output)-benchmarkSuiteMaxOutput:]
+	}
+	return output
+}
+
+// benchmarkSuitePair is a pair of VMs (source and target) in a pair suite
+type benchmarkSuitePair struct {
+	vmId       string
+	targetVmId string
+}
+
+// genBenchmarkSuiteRounds is func to generate rounds of pairs among VMs (all ordered pairs),
+// in which each VM is a source once and a target once in a round (to avoid contention of servers)
+func genBenchmarkSuiteRounds(vmList []string) [][]benchmarkSuitePair {
+	rounds := [][]benchmarkSuitePair{}
+	n := len(vmList)
+	for d := 1; d < n; d++ {
+		round := []benchmarkSuitePair{}
+		for i := 0; i < n; i++ {
+			round = append(round, benchmarkSuitePair{vmId: vmList[i], targetVmId: vmList[(i+d)%n]})
+		}
+		rounds = append(rounds, round)
+	}
+	return rounds
+}
+
+// replaceBenchmarkSuiteTarget is func to replace placeholders of the target VM in commands of a pair suite
+func replaceBenchmarkSuiteTarget(commands []string, vm TbVmInfo, target TbVmInfo) []string {
+	targetIp := target.PublicIP
+	if vm.ConnectionName == target.ConnectionName && vm.VNetId == target.VNetId && target.PrivateIP != "" {
+		targetIp = target.PrivateIP
+	}
+	replacer := strings.NewReplacer(
+		"{{targetIp}}", targetIp,
+		"{{targetPublicIp}}", target.PublicIP,
+		"{{targetPrivateIp}}", target.PrivateIP,
+		"{{targetVmId}}", target.Id,
+	)
+	replaced := make([]string, len(commands))
+	for i, c := range commands {
+		replaced[i] = replacer.Replace(c)
+	}
+	return replaced
+}
+
+// runBenchmarkSuiteCommands is func to execute commands of a benchmark suite in a VM and return the output of the last command
+func runBenchmarkSuiteCommands(nsId string, mcisId string, vmId string, vmIndex int, userName string, commands []string, timeoutSec int) (string, error) {
+	processed := make([]string, len(commands))
+	display := make([]string, len(commands))
+	for i, c := range commands {
+		cmd, err := processCommand(c, nsId, mcisId, vmId, vmIndex, false)
+		if err != nil {
+			return "", err
+		}
+		processed[i] = cmd
+		display[i], err = processCommand(c, nsId, mcisId, vmId, vmIndex, true)
+		if err != nil {
+			return "", err
+		}
+	}
+	stdout, stderr, exitCode, err := runRemoteCommandWithOption(nsId, mcisId, vmId, userName, processed, display, SshCmdOption{TimeoutSec: timeoutSec})
+	for i := range processed {
+		if code, ok := exitCode[i]; ok && code != 0 {
+			return stdout[i] + stderr[i], fmt.Errorf("command %d exited with %d: %s", i, code, strings.TrimSpace(stderr[i]))
+		}
+	}
+	last := len(processed) - 1
+	if err != nil {
+		return stdout[last] + stderr[last], err
+	}
+	return stdout[last], nil
+}
+
+// RunBenchmarkSuite is func to run a benchmark suite in VMs of MCIS
+func RunBenchmarkSuite(nsId string, mcisId string, req *BenchmarkSuiteRunReq) (BenchmarkSuiteResult, error) {
+
+	result := BenchmarkSuiteResult{Suite: req.Suite, McisId: mcisId, Results: []BenchmarkSuiteVmResult{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	suite, err := GetBenchmarkSuite(req.Suite)
+	if err != nil {
+		return result, err
+	}
+	result.Type = suite.Type
+
+	vmList, err := getTargetVmList(nsId, mcisId, req.SubGroupId, "")
+	if err != nil {
+		return result, err
+	}
+	if len(req.VmIds) > 0 {
+		inMcis := map[string]bool{}
+		for _, v := range vmList {
+			inMcis[v] = true
+		}
+		for _, v := range req.VmIds {
+			if !inMcis[v] {
+				err := fmt.Errorf("The VM %s does not exist in %s", v, mcisId)
+				return result, err
+			}
+		}
+		vmList = req.VmIds
+	}
+	if suite.Type == BenchmarkSuiteTypePair && len(vmList) < 2 {
+		err := fmt.Errorf("The benchmark suite %s requires at least 2 VMs", suite.Name)
+		return result, err
+	}
+
+	vmInfo := map[string]TbVmInfo{}
+	vmIndex := map[string]int{}
+	for i, v := range vmList {
+		vm, err := GetVmObject(nsId, mcisId, v)
+		if err != nil {
+			return result, err
+		}
+		vmInfo[v] = vm
+		vmIndex[v] = i
+	}
+
+	timeoutSec := suite.TimeoutSec
+	if timeoutSec == 0 {
+		timeoutSec = benchmarkSuiteDefaultTimeoutSec
+	}
+
+	started := time.Now()
+	result.StartedAt = started.UTC().Format(time.RFC3339)
+	log.Info().Msgf("[BenchmarkSuite] run %s in %s/%s (%d VMs)", suite.Name, nsId, mcisId, len(vmList))
+
+	// preparation (install and server commands) in each VM
+	prepareErr := map[string]error{}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, v := range vmList {
+		commands := []string{}
+		if !req.SkipInstall {
+			commands = append(commands, suite.InstallCommand...)
+		}
+		if suite.Type == BenchmarkSuiteTypePair {
+			commands = append(commands, suite.ServerCommand...)
+		}
+		if len(commands) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(vmId string, commands []string) {
+			defer wg.Done()
+			output, err := runBenchmarkSuiteCommands(nsId, mcisId, vmId, vmIndex[vmId], req.UserName, commands, timeoutSec)
+			if err != nil {
+				log.Error().Err(err).Msgf("[BenchmarkSuite] failed to prepare %s in %s: %s", suite.Name, vmId, tailOutput(output))
+				mutex.Lock()
+				prepareErr[vmId] = err
+				mutex.Unlock()
+			}
+		}(v, commands)
+	}
+	wg.Wait()
+
+	runPair := func(pair benchmarkSuitePair) BenchmarkSuiteVmResult {
+		vm := vmInfo[pair.vmId]
+		r := BenchmarkSuiteVmResult{VmId: pair.vmId, SpecId: vm.SpecId, TargetVmId: pair.targetVmId, Metrics: []BenchmarkSuiteMetricResult{}}
+		for _, v := range []string{pair.vmId, pair.targetVmId} {
+			if err, ok := prepareErr[v]; ok && v != "" {
+				r.Error = fmt.Sprintf("failed to prepare %s: %s", v, err.Error())
+				return r
+			}
+		}
+		commands := suite.RunCommand
+		if pair.targetVmId != "" {
+			commands = replaceBenchmarkSuiteTarget(commands, vm, vmInfo[pair.targetVmId])
+		}
+		start := time.Now()
+		output, err := runBenchmarkSuiteCommands(nsId, mcisId, pair.vmId, vmIndex[pair.vmId], req.UserName, commands, timeoutSec)
+		r.ElapsedSec = time.Since(start).Seconds()
+		r.Output = tailOutput(output)
+		if err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		r.Metrics, err = parseBenchmarkSuiteOutput(suite, output)
+		if err != nil {
+			r.Error = err.Error()
+		}
+		return r
+	}
+
+	rounds := [][]benchmarkSuitePair{}
+	if suite.Type == BenchmarkSuiteTypePair {
+		rounds = genBenchmarkSuiteRounds(vmList)
+	} else {
+		round := []benchmarkSuitePair{}
+		for _, v := range vmList {
+			round = append(round, benchmarkSuitePair{vmId: v})
+		}
+		rounds = append(rounds, round)
+	}
+	for _, round := range rounds {
+		roundResults := make([]BenchmarkSuiteVmResult, len(round))
+		for i, pair := range round {
+			wg.Add(1)
+			go func(i int, pair benchmarkSuitePair) {
+				defer wg.Done()
+				roundResults[i] = runPair(pair)
+			}(i, pair)
+		}
+		wg.Wait()
+		result.Results = append(result.Results, roundResults...)
+	}
+
+	sort.SliceStable(result.Results, func(i, j int) bool {
+		if result.Results[i].VmId != result.Results[j].VmId {
+			return vmIndex[result.Results[i].VmId] < vmIndex[result.Results[j].VmId]
+		}
+		return vmIndex[result.Results[i].TargetVmId] < vmIndex[result.Results[j].TargetVmId]
+	})
+	result.ElapsedSec = time.Since(started).Seconds()
+	return result, nil
+}
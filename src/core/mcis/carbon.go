@@ -0,0 +1,282 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// CarbonIntensitySourceFile is const for carbon intensity from assets/carbonintensity.yaml
+	CarbonIntensitySourceFile string = "file"
+	// CarbonIntensitySourceApi is const for carbon intensity updated via API
+	CarbonIntensitySourceApi string = "api"
+	// CarbonIntensitySourceDefault is const for the default carbon intensity (region not listed)
+	CarbonIntensitySourceDefault string = "default"
+)
+
+// RegionCarbonIntensity is struct for the carbon intensity of electricity in a region
+type RegionCarbonIntensity struct {
+	ProviderName string `json:"providerName" example:"aws"`
+	RegionName   string `json:"regionName" example:"eu-north-1"`
+	// Intensity is the carbon intensity of electricity (gCO2eq/kWh)
+	Intensity float64 `json:"intensity" example:"10"`
+	Source    string  `json:"source" example:"file" enums:"file,api,default"`
+	UpdatedAt string  `json:"updatedAt,omitempty" example:"2024-01-01T00:00:00Z"`
+}
+
+// RegionCarbonIntensityList is struct for the carbon intensity table
+type RegionCarbonIntensityList struct {
+	// Default is the carbon intensity (gCO2eq/kWh) for regions not listed
+	Default         float64                 `json:"default" example:"475"`
+	Power           common.PowerModel       `json:"power"`
+	CarbonIntensity []RegionCarbonIntensity `json:"carbonIntensity"`
+}
+
+// CarbonIntensityReq is struct for the carbon intensity of a region to update
+type CarbonIntensityReq struct {
+	ProviderName string  `json:"providerName" validate:"required" example:"aws"`
+	RegionName   string  `json:"regionName" validate:"required" example:"eu-north-1"`
+	Intensity    float64 `json:"intensity" example:"10"`
+}
+
+// CarbonIntensityUpdateReq is struct for carbon intensities of regions to update
+type CarbonIntensityUpdateReq struct {
+	CarbonIntensity []CarbonIntensityReq `json:"carbonIntensity" validate:"required"`
+}
+
+// carbonIntensityStore is the in-memory cache of carbon intensities updated via API (persisted in the key-value store)
+var carbonIntensityStore = struct {
+	sync.RWMutex
+	loaded    bool
+	intensity map[string]RegionCarbonIntensity
+}{intensity: map[string]RegionCarbonIntensity{}}
+
+// genCarbonIntensityKey is func to generate a key of the carbon intensity of a region
+func genCarbonIntensityKey(providerName string, regionName string) string {
+	return "/carbonIntensity/" + providerName + "/" + regionName
+}
+
+// loadCarbonIntensityStore is func to load carbon intensities updated via API from the key-value store (once)
+func loadCarbonIntensityStore() {
+	carbonIntensityStore.RLock()
+	loaded := carbonIntensityStore.loaded
+	carbonIntensityStore.RUnlock()
+	if loaded {
+		return
+	}
+
+	carbonIntensityStore.Lock()
+	defer carbonIntensityStore.Unlock()
+	if carbonIntensityStore.loaded {
+		return
+	}
+	keyValue, err := common.CBStore.GetList("/carbonIntensity", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	for _, v := range keyValue {
+		info := RegionCarbonIntensity{}
+		err = json.Unmarshal([]byte(v.Value), &info)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		carbonIntensityStore.intensity[genCarbonIntensityKey(info.ProviderName, info.RegionName)] = info
+	}
+	carbonIntensityStore.loaded = true
+}
+
+// GetCarbonIntensity is func to get the carbon intensity of a region
+// (updated via API first, then assets/carbonintensity.yaml, then the default)
+func GetCarbonIntensity(providerName string, regionName string) RegionCarbonIntensity {
+	providerName = strings.ToLower(providerName)
+	regionName = strings.ToLower(regionName)
+
+	loadCarbonIntensityStore()
+	carbonIntensityStore.RLock()
+	info, ok := carbonIntensityStore.intensity[genCarbonIntensityKey(providerName, regionName)]
+	carbonIntensityStore.RUnlock()
+	if ok {
+		return info
+	}
+
+	info = RegionCarbonIntensity{ProviderName: providerName, RegionName: regionName}
+	if intensity, ok := common.RuntimeCarbonIntensity.CSPs[providerName].Regions[regionName]; ok {
+		info.Intensity = intensity
+		info.Source = CarbonIntensitySourceFile
+		return info
+	}
+	info.Intensity = common.RuntimeCarbonIntensity.Default
+	info.Source = CarbonIntensitySourceDefault
+	return info
+}
+
+// UpdateCarbonIntensity is func to update carbon intensities of regions (overriding assets/carbonintensity.yaml)
+func UpdateCarbonIntensity(req *CarbonIntensityUpdateReq) (RegionCarbonIntensityList, error) {
+
+	result := RegionCarbonIntensityList{
+		Default:         common.RuntimeCarbonIntensity.Default,
+		Power:           common.RuntimeCarbonIntensity.Power,
+		CarbonIntensity: []RegionCarbonIntensity{},
+	}
+	err := validate.Struct(req)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	if len(req.CarbonIntensity) == 0 {
+		return result, fmt.Errorf("no carbon intensity is given")
+	}
+	for _, v := range req.CarbonIntensity {
+		if v.ProviderName == "" || v.RegionName == "" {
+			return result, fmt.Errorf("providerName and regionName are required")
+		}
+		if v.Intensity < 0 || math.IsNaN(v.Intensity) || math.IsInf(v.Intensity, 0) {
+			return result, fmt.Errorf("invalid carbon intensity of %s %s: %v", v.ProviderName, v.RegionName, v.Intensity)
+		}
+	}
+
+	loadCarbonIntensityStore()
+
+	carbonIntensityStore.Lock()
+	defer carbonIntensityStore.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, v := range req.CarbonIntensity {
+		info := RegionCarbonIntensity{
+			ProviderName: strings.ToLower(v.ProviderName),
+			RegionName:   strings.ToLower(v.RegionName),
+			Intensity:    v.Intensity,
+			Source:       CarbonIntensitySourceApi,
+			UpdatedAt:    now,
+		}
+		key := genCarbonIntensityKey(info.ProviderName, info.RegionName)
+		val, err := json.Marshal(info)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return result, err
+		}
+		err = common.CBStore.Put(key, string(val))
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return result, err
+		}
+		carbonIntensityStore.intensity[key] = info
+		result.CarbonIntensity = append(result.CarbonIntensity, info)
+	}
+	return result, nil
+}
+
+// ListCarbonIntensity is func to list carbon intensities of regions (only those of the provider if given)
+func ListCarbonIntensity(providerName string) (RegionCarbonIntensityList, error) {
+
+	providerName = strings.ToLower(providerName)
+	result := RegionCarbonIntensityList{
+		Default:         common.RuntimeCarbonIntensity.Default,
+		Power:           common.RuntimeCarbonIntensity.Power,
+		CarbonIntensity: []RegionCarbonIntensity{},
+	}
+
+	listed := map[string]bool{}
+	for provider, detail := range common.RuntimeCarbonIntensity.CSPs {
+		if providerName != "" && provider != providerName {
+			continue
+		}
+		for region := range detail.Regions {
+			result.CarbonIntensity = append(result.CarbonIntensity, GetCarbonIntensity(provider, region))
+			listed[genCarbonIntensityKey(provider, region)] = true
+		}
+	}
+
+	loadCarbonIntensityStore()
+	carbonIntensityStore.RLock()
+	for key, info := range carbonIntensityStore.intensity {
+		if listed[key] || (providerName != "" && info.ProviderName != providerName) {
+			continue
+		}
+		result.CarbonIntensity = append(result.CarbonIntensity, info)
+	}
+	carbonIntensityStore.RUnlock()
+
+	sort.Slice(result.CarbonIntensity, func(i, j int) bool {
+		if result.CarbonIntensity[i].ProviderName != result.CarbonIntensity[j].ProviderName {
+			return result.CarbonIntensity[i].ProviderName < result.CarbonIntensity[j].ProviderName
+		}
+		return result.CarbonIntensity[i].RegionName < result.CarbonIntensity[j].RegionName
+	})
+	return result, nil
+}
+
+// DelAllCarbonIntensity is func to delete all carbon intensities updated via API (assets/carbonintensity.yaml is used again)
+func DelAllCarbonIntensity() error {
+	loadCarbonIntensityStore()
+
+	carbonIntensityStore.Lock()
+	defer carbonIntensityStore.Unlock()
+
+	for key := range carbonIntensityStore.intensity {
+		err := common.CBStore.Delete(key)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return err
+		}
+		delete(carbonIntensityStore.intensity, key)
+	}
+	return nil
+}
+
+// estimateSpecPowerWatts is func to estimate the average power (W) of a spec including the data center overhead (PUE)
+func estimateSpecPowerWatts(spec mcir.TbSpecInfo) float64 {
+	power := common.RuntimeCarbonIntensity.Power
+	watts := float64(spec.VCPU)*power.WattsPerVCPU +
+		float64(spec.MemoryGiB)*power.WattsPerMemoryGiB +
+		float64(spec.AcceleratorCount)*power.WattsPerAccelerator
+	if power.Pue > 0 {
+		watts *= power.Pue
+	}
+	return watts
+}
+
+// evaluateSpecsByCarbon func evaluates specs by the estimated carbon emission per hour (gCO2eq/h)
+// (carbon intensity of the region x estimated power of the spec)
+func evaluateSpecsByCarbon(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
+	if len(param) > 0 {
+		return nil, true, fmt.Errorf("invalid parameter key for carbon: '%s' (no parameter is available)", param[0].Key)
+	}
+
+	values := make([]float64, len(specList))
+	for i, k := range specList {
+		if k.VCPU == 0 {
+			// unknown size of the spec
+			values[i] = math.NaN()
+			continue
+		}
+		intensity := GetCarbonIntensity(k.ProviderName, k.RegionName).Intensity
+		values[i] = estimateSpecPowerWatts(k) / 1000 * intensity
+	}
+	return values, true, nil
+}
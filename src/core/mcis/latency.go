@@ -0,0 +1,331 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	LatencySourceBenchmark string = "benchmark"
+	LatencySourceImport    string = "import"
+	LatencySourceStatic    string = "static"
+)
+
+// latencyMaxSamples is the number of recent samples reflected in the moving average of a measured latency
+const latencyMaxSamples = 10
+
+// latencyRegionPattern is the pattern of region keys in the latency map ({providerName}-{regionName})
+var latencyRegionPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// latencyNumberPattern is the pattern to get the number from a result of latency benchmark (e.g., "12.3 ms")
+var latencyNumberPattern = regexp.MustCompile(`[0-9]+(\.[0-9]+)?`)
+
+// LatencyInfo is struct for a latency between two regions
+type LatencyInfo struct {
+	// Src and Dest are regions in the form of {providerName}-{regionName} (latency is symmetric)
+	Src       string  `json:"src" example:"aws-ap-northeast-2"`
+	Dest      string  `json:"dest" example:"gcp-asia-northeast3"`
+	LatencyMs float64 `json:"latencyMs" example:"5.3"`
+	// SampleCount is the number of samples measured (the latency is the moving average of recent samples)
+	SampleCount int    `json:"sampleCount" example:"3"`
+	MeasuredAt  string `json:"measuredAt" example:"2024-01-01T00:00:00Z"`
+	Source      string `json:"source" example:"benchmark" enums:"benchmark,import,static"`
+}
+
+// LatencyInfoList is struct for a list of latencies
+type LatencyInfoList struct {
+	Latency []LatencyInfo `json:"latency"`
+}
+
+// LatencyReq is struct for a latency sample to import
+type LatencyReq struct {
+	Src       string  `json:"src" validate:"required" example:"aws-ap-northeast-2"`
+	Dest      string  `json:"dest" validate:"required" example:"gcp-asia-northeast3"`
+	LatencyMs float64 `json:"latencyMs" example:"5.3"`
+	// MeasuredAt is the time of measurement (now if omitted)
+	MeasuredAt string `json:"measuredAt,omitempty" example:"2024-01-01T00:00:00Z"`
+}
+
+// LatencyImportReq is struct for latency samples to import
+type LatencyImportReq struct {
+	Latency []LatencyReq `json:"latency" validate:"required"`
+}
+
+// latencyStore is the in-memory cache of measured latencies (persisted in the key-value store)
+var latencyStore = struct {
+	sync.RWMutex
+	loaded  bool
+	latency map[string]LatencyInfo
+}{latency: map[string]LatencyInfo{}}
+
+// genLatencyKey is func to generate a key of the latency between two regions (the order of regions is normalized)
+func genLatencyKey(src string, dest string) string {
+	if dest < src {
+		src, dest = dest, src
+	}
+	return "/latency/" + src + "+" + dest
+}
+
+// loadLatencyStore is func to load measured latencies from the key-value store (once)
+func loadLatencyStore() {
+	latencyStore.RLock()
+	loaded := latencyStore.loaded
+	latencyStore.RUnlock()
+	if loaded {
+		return
+	}
+
+	latencyStore.Lock()
+	defer latencyStore.Unlock()
+	if latencyStore.loaded {
+		return
+	}
+	keyValue, err := common.CBStore.GetList("/latency", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	for _, v := range keyValue {
+		info := LatencyInfo{}
+		err = json.Unmarshal([]byte(v.Value), &info)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		latencyStore.latency[genLatencyKey(info.Src, info.Dest)] = info
+	}
+	latencyStore.loaded = true
+}
+
+// getMeasuredLatency is func to get the measured latency between two regions
+func getMeasuredLatency(src string, dest string) (LatencyInfo, bool) {
+	loadLatencyStore()
+
+	latencyStore.RLock()
+	defer latencyStore.RUnlock()
+	info, ok := latencyStore.latency[genLatencyKey(src, dest)]
+	return info, ok
+}
+
+// getStaticLatency is func to get the latency between two regions from the static latency map (assets/cloudlatencymap.csv)
+func getStaticLatency(src string, dest string) (float64, error) {
+	srcIndex, ok := common.RuntimeLatancyMapIndex[src]
+	if !ok {
+		return 0, fmt.Errorf("no latency info of region %s", src)
+	}
+	destIndex, ok := common.RuntimeLatancyMapIndex[dest]
+	if !ok {
+		return 0, fmt.Errorf("no latency info of region %s", dest)
+	}
+	if srcIndex >= len(common.RuntimeLatancyMap) || destIndex >= len(common.RuntimeLatancyMap[srcIndex]) {
+		return 0, fmt.Errorf("no latency info between %s and %s", src, dest)
+	}
+	latencyString := common.RuntimeLatancyMap[srcIndex][destIndex]
+	return strconv.ParseFloat(strings.ReplaceAll(latencyString, " ", ""), 64)
+}
+
+// RecordLatency is func to add a latency sample between two regions to the latency store
+func RecordLatency(src string, dest string, latencyMs float64, measuredAt time.Time, source string) (LatencyInfo, error) {
+
+	src = strings.ToLower(strings.TrimSpace(src))
+	dest = strings.ToLower(strings.TrimSpace(dest))
+	for _, region := range []string{src, dest} {
+		if !latencyRegionPattern.MatchString(region) {
+			err := fmt.Errorf("invalid region: '%s' (should be {providerName}-{regionName})", region)
+			return LatencyInfo{}, err
+		}
+	}
+	if latencyMs < 0 || math.IsNaN(latencyMs) || math.IsInf(latencyMs, 0) {
+		err := fmt.Errorf("invalid latency between %s and %s: %v", src, dest, latencyMs)
+		return LatencyInfo{}, err
+	}
+
+	loadLatencyStore()
+
+	latencyStore.Lock()
+	defer latencyStore.Unlock()
+
+	key := genLatencyKey(src, dest)
+	info, ok := latencyStore.latency[key]
+	if !ok {
+		info = LatencyInfo{Src: src, Dest: dest}
+		if dest < src {
+			info.Src, info.Dest = dest, src
+		}
+	}
+	// moving average of recent samples
+	info.SampleCount++
+	n := info.SampleCount
+	if n > latencyMaxSamples {
+		n = latencyMaxSamples
+	}
+	info.LatencyMs += (latencyMs - info.LatencyMs) / float64(n)
+	info.MeasuredAt = measuredAt.UTC().Format(time.RFC3339)
+	info.Source = source
+
+	val, err := json.Marshal(info)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return LatencyInfo{}, err
+	}
+	err = common.CBStore.Put(key, string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return LatencyInfo{}, err
+	}
+	latencyStore.latency[key] = info
+	return info, nil
+}
+
+// ImportLatency is func to import latency samples into the latency store
+func ImportLatency(req *LatencyImportReq) (LatencyInfoList, error) {
+
+	result := LatencyInfoList{Latency: []LatencyInfo{}}
+	if len(req.Latency) == 0 {
+		return result, fmt.Errorf("no latency is given")
+	}
+
+	for _, v := range req.Latency {
+		measuredAt := time.Now()
+		if v.MeasuredAt != "" {
+			var err error
+			measuredAt, err = time.Parse(time.RFC3339, v.MeasuredAt)
+			if err != nil {
+				err := fmt.Errorf("invalid measuredAt: '%s' (should be RFC3339)", v.MeasuredAt)
+				return result, err
+			}
+		}
+		info, err := RecordLatency(v.Src, v.Dest, v.LatencyMs, measuredAt, LatencySourceImport)
+		if err != nil {
+			return result, err
+		}
+		result.Latency = append(result.Latency, info)
+	}
+	return result, nil
+}
+
+// updateLatencyFromBenchmark is func to record latencies measured by the mrtt benchmark to the latency store
+func updateLatencyFromBenchmark(content BenchmarkInfoArray) {
+
+	regionOfSpec := map[string]string{}
+	getRegion := func(specId string) string {
+		if region, ok := regionOfSpec[specId]; ok {
+			return region
+		}
+		region := ""
+		tempInterface, err := mcir.GetResource(common.SystemCommonNs, common.StrSpec, specId)
+		if err == nil {
+			specInfo := mcir.TbSpecInfo{}
+			err = common.CopySrcToDest(&tempInterface, &specInfo)
+			if err == nil && specInfo.ProviderName != "" && specInfo.RegionName != "" {
+				region = strings.ToLower(specInfo.ProviderName + "-" + specInfo.RegionName)
+			}
+		}
+		regionOfSpec[specId] = region
+		return region
+	}
+
+	now := time.Now()
+	for _, k := range content.ResultArray {
+		src := getRegion(k.SpecId)
+		if src == "" {
+			continue
+		}
+		for _, m := range k.ResultArray {
+			dest := getRegion(m.SpecId)
+			if dest == "" || dest == src {
+				continue
+			}
+			latency, err := strconv.ParseFloat(latencyNumberPattern.FindString(m.Result), 64)
+			if err != nil {
+				log.Debug().Msgf("[Latency] cannot parse the result '%s' from %s to %s", m.Result, src, dest)
+				continue
+			}
+			_, err = RecordLatency(src, dest, latency, now, LatencySourceBenchmark)
+			if err != nil {
+				log.Error().Err(err).Msg("")
+			}
+		}
+	}
+}
+
+// GetLatencyInfo is func to get the latency between two regions (measured one first, static one otherwise)
+func GetLatencyInfo(src string, dest string) (LatencyInfo, error) {
+	src = strings.ToLower(src)
+	dest = strings.ToLower(dest)
+	if info, ok := getMeasuredLatency(src, dest); ok {
+		return info, nil
+	}
+	latency, err := getStaticLatency(src, dest)
+	if err != nil {
+		return LatencyInfo{}, err
+	}
+	return LatencyInfo{Src: src, Dest: dest, LatencyMs: latency, Source: LatencySourceStatic}, nil
+}
+
+// ListLatency is func to list measured latencies (only those related to the region if given)
+func ListLatency(region string) (LatencyInfoList, error) {
+	loadLatencyStore()
+
+	region = strings.ToLower(region)
+	result := LatencyInfoList{Latency: []LatencyInfo{}}
+
+	latencyStore.RLock()
+	for _, info := range latencyStore.latency {
+		if region == "" || info.Src == region || info.Dest == region {
+			result.Latency = append(result.Latency, info)
+		}
+	}
+	latencyStore.RUnlock()
+
+	sort.Slice(result.Latency, func(i, j int) bool {
+		if result.Latency[i].Src != result.Latency[j].Src {
+			return result.Latency[i].Src < result.Latency[j].Src
+		}
+		return result.Latency[i].Dest < result.Latency[j].Dest
+	})
+	return result, nil
+}
+
+// DelAllLatency is func to delete all measured latencies (the static latency map is used again)
+func DelAllLatency() error {
+	loadLatencyStore()
+
+	latencyStore.Lock()
+	defer latencyStore.Unlock()
+
+	for key := range latencyStore.latency {
+		err := common.CBStore.Delete(key)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return err
+		}
+		delete(latencyStore.latency, key)
+	}
+	return nil
+}
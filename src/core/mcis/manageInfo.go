@@ -1281,6 +1281,11 @@ func FetchVmStatus(nsId string, mcisId string, vmId string) (TbVmStatusInfo, err
 	vmStatusTmp.PublicIp = temp.PublicIP
 	vmStatusTmp.SSHPort = temp.SSHPort
 
+	// Detect interruption of a spot VM (status changed without any action requested)
+	if cspVmId != "" && isSpotInterruption(temp, vmStatusTmp.Status) {
+		vmStatusTmp.SystemMessage = handleSpotInterruption(nsId, mcisId, &temp, vmStatusTmp.Status)
+	}
+
 	// Apply current status to vmInfo
 	temp.Status = vmStatusTmp.Status
 	temp.TargetAction = vmStatusTmp.TargetAction
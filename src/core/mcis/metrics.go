@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+// vmStatusCollector is a prometheus.Collector that reports VM counts by status per namespace.
+// It reads the status of VM objects in the key-value store, so scraping does not call CSPs.
+type vmStatusCollector struct {
+	vmStatus  *prometheus.Desc
+	mcisTotal *prometheus.Desc
+}
+
+func init() {
+	prometheus.MustRegister(&vmStatusCollector{
+		vmStatus: prometheus.NewDesc(
+			"tumblebug_vm_status",
+			"Number of VMs by status per namespace (from the latest status of VM objects).",
+			[]string{"namespace", "status"}, nil,
+		),
+		mcisTotal: prometheus.NewDesc(
+			"tumblebug_mcis_total",
+			"Number of MCIS per namespace.",
+			[]string{"namespace"}, nil,
+		),
+	})
+}
+
+// Describe is func to send descriptors of vmStatusCollector
+func (vc *vmStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vc.vmStatus
+	ch <- vc.mcisTotal
+}
+
+// Collect is func to count the status of all VMs in each namespace
+func (vc *vmStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	if common.CBStore == nil {
+		return
+	}
+	nsList, err := common.ListNsId()
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+
+	for _, nsId := range nsList {
+		mcisList, err := ListMcisId(nsId)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+
+		counts := map[string]int{
+			StatusCreating:    0,
+			StatusRunning:     0,
+			StatusFailed:      0,
+			StatusSuspended:   0,
+			StatusRebooting:   0,
+			StatusTerminated:  0,
+			StatusSuspending:  0,
+			StatusResuming:    0,
+			StatusTerminating: 0,
+			StatusUndefined:   0,
+		}
+		for _, mcisId := range mcisList {
+			vmList, err := ListVmId(nsId, mcisId)
+			if err != nil {
+				continue
+			}
+			for _, vmId := range vmList {
+				vmObj, err := GetVmObject(nsId, mcisId, vmId)
+				if err != nil {
+					continue
+				}
+				if _, ok := counts[vmObj.Status]; ok {
+					counts[vmObj.Status]++
+				} else {
+					counts[StatusUndefined]++
+				}
+			}
+		}
+
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(vc.vmStatus, prometheus.GaugeValue, float64(count), nsId, status)
+		}
+		ch <- prometheus.MustNewConstMetric(vc.mcisTotal, prometheus.GaugeValue, float64(len(mcisList)), nsId)
+	}
+}
@@ -42,6 +42,33 @@ const (
 	monMetricDiskio  string = "diskio"
 )
 
+// Status for monitoring agent (TbVmInfo.MonAgentStatus)
+const (
+	// MonAgentStatusNotInstalled is const for "notInstalled" status.
+	MonAgentStatusNotInstalled string = "notInstalled"
+
+	// MonAgentStatusInstalling is const for "installing" status.
+	MonAgentStatusInstalling string = "installing"
+
+	// MonAgentStatusInstalled is const for "installed" status.
+	MonAgentStatusInstalled string = "installed"
+
+	// MonAgentStatusUpgrading is const for "upgrading" status.
+	MonAgentStatusUpgrading string = "upgrading"
+
+	// MonAgentStatusUninstalling is const for "uninstalling" status.
+	MonAgentStatusUninstalling string = "uninstalling"
+
+	// MonAgentStatusUnhealthy is const for "unhealthy" status (installed but heartbeat failed).
+	MonAgentStatusUnhealthy string = "unhealthy"
+
+	// MonAgentStatusUnknown is const for "unknown" status (installed but heartbeat cannot be checked, e.g., no public IP).
+	MonAgentStatusUnknown string = "unknown"
+
+	// MonAgentStatusFailed is const for "failed" status.
+	MonAgentStatusFailed string = "failed"
+)
+
 // MonAgentInstallReq struct
 type MonAgentInstallReq struct {
 	NsId     string `json:"nsId,omitempty"`
@@ -166,9 +193,20 @@ func CallMonitoringAsync(wg *sync.WaitGroup, nsID string, mcisID string, mcisSer
 	}
 	log.Debug().Msg("[CallMonitoringAsync] " + mcisID + "/" + vmID + "(" + vmIP + ")" + "with userName:" + userName)
 
+	// POST: install, PUT: upgrade, DELETE: uninstall
+	ongoingStatus := MonAgentStatusInstalling
+	completedStatus := MonAgentStatusInstalled
+	switch method {
+	case http.MethodPut:
+		ongoingStatus = MonAgentStatusUpgrading
+	case http.MethodDelete:
+		ongoingStatus = MonAgentStatusUninstalling
+		completedStatus = MonAgentStatusNotInstalled
+	}
+
 	// set vm MonAgentStatus = "installing" (to avoid duplicated requests)
 	vmInfoTmp, _ := GetVmObject(nsID, mcisID, vmID)
-	vmInfoTmp.MonAgentStatus = "installing"
+	vmInfoTmp.MonAgentStatus = ongoingStatus
 	UpdateVmInfo(nsID, mcisID, vmInfoTmp)
 
 	if mcisServiceType == "" {
@@ -264,20 +302,62 @@ func CallMonitoringAsync(wg *sync.WaitGroup, nsID string, mcisID string, mcisSer
 		sshResultTmp.Stderr[0] = errStr
 		sshResultTmp.Err = err
 		*returnResult = append(*returnResult, sshResultTmp)
-		vmInfoTmp.MonAgentStatus = "failed"
+		vmInfoTmp.MonAgentStatus = MonAgentStatusFailed
 	} else {
 		fmt.Println("Result: " + result)
 		sshResultTmp.Stdout[0] = result
 		sshResultTmp.Err = nil
 		*returnResult = append(*returnResult, sshResultTmp)
-		vmInfoTmp.MonAgentStatus = "installed"
+		vmInfoTmp.MonAgentStatus = completedStatus
 	}
 
 	UpdateVmInfo(nsID, mcisID, vmInfoTmp)
 
 }
 
+// InstallMonitorAgentToMcis is func to install monitoring agents (CB-Dragonfly agent) to VMs in MCIS
 func InstallMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType string, req *McisCmdReq) (AgentInstallContentWrapper, error) {
+	// Request agent installation (skip if in installing or installed status)
+	isTarget := func(status string) bool {
+		return status != MonAgentStatusInstalled && status != MonAgentStatusInstalling &&
+			status != MonAgentStatusUpgrading && status != MonAgentStatusUninstalling && status != MonAgentStatusUnhealthy &&
+			status != MonAgentStatusUnknown
+	}
+	return handleMonitorAgentOfMcis(nsId, mcisId, mcisServiceType, req, http.MethodPost, isTarget)
+}
+
+// UpgradeMonitorAgentToMcis is func to upgrade monitoring agents (CB-Dragonfly agent) of VMs in MCIS
+func UpgradeMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType string, req *McisCmdReq) (AgentInstallContentWrapper, error) {
+	isTarget := func(status string) bool {
+		return status == MonAgentStatusInstalled || status == MonAgentStatusUnhealthy || status == MonAgentStatusUnknown
+	}
+	return handleMonitorAgentOfMcis(nsId, mcisId, mcisServiceType, req, http.MethodPut, isTarget)
+}
+
+// UninstallMonitorAgentFromMcis is func to uninstall monitoring agents (CB-Dragonfly agent) from VMs in MCIS.
+// InstallMonAgent option of the MCIS is set to "no" to prevent automatic reinstallation.
+func UninstallMonitorAgentFromMcis(nsId string, mcisId string, mcisServiceType string, req *McisCmdReq) (AgentInstallContentWrapper, error) {
+	isTarget := func(status string) bool {
+		return status == MonAgentStatusInstalled || status == MonAgentStatusUnhealthy || status == MonAgentStatusFailed
+	}
+	content, err := handleMonitorAgentOfMcis(nsId, mcisId, mcisServiceType, req, http.MethodDelete, isTarget)
+	if err != nil {
+		return content, err
+	}
+
+	mcisTmp, err := GetMcisObject(nsId, mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return content, err
+	}
+	mcisTmp.InstallMonAgent = "no"
+	UpdateMcisInfo(nsId, mcisTmp)
+
+	return content, nil
+}
+
+// handleMonitorAgentOfMcis is func to call the agent API of CB-Dragonfly for VMs in MCIS with the target agent status
+func handleMonitorAgentOfMcis(nsId string, mcisId string, mcisServiceType string, req *McisCmdReq, method string, isTarget func(status string) bool) (AgentInstallContentWrapper, error) {
 
 	err := common.CheckString(nsId)
 	if err != nil {
@@ -302,7 +382,7 @@ func InstallMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType strin
 
 	content := AgentInstallContentWrapper{}
 
-	//install script
+	//agent API
 	cmd := "/agent"
 
 	vmList, err := ListVmId(nsId, mcisId)
@@ -311,30 +391,20 @@ func InstallMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType strin
 		return content, err
 	}
 
-	log.Debug().Msg("[Install agent for each VM]")
+	log.Debug().Msg("[" + method + " agent for each VM]")
 
 	//goroutin sync wg
 	var wg sync.WaitGroup
 
 	var resultArray []SshCmdResult
 
-	method := "POST"
-
 	for _, v := range vmList {
 		vmObjTmp, _ := GetVmObject(nsId, mcisId, v)
 		fmt.Println("MonAgentStatus : " + vmObjTmp.MonAgentStatus)
 
-		// Request agent installation (skip if in installing or installed status)
-		if vmObjTmp.MonAgentStatus != "installed" && vmObjTmp.MonAgentStatus != "installing" {
-
-			// Avoid RunRemoteCommand to not ready VM
-			if err == nil {
-				wg.Add(1)
-				go CallMonitoringAsync(&wg, nsId, mcisId, mcisServiceType, v, req.UserName, method, cmd, &resultArray)
-			} else {
-				log.Error().Err(err).Msg("")
-			}
-
+		if isTarget(vmObjTmp.MonAgentStatus) {
+			wg.Add(1)
+			go CallMonitoringAsync(&wg, nsId, mcisId, mcisServiceType, v, req.UserName, method, cmd, &resultArray)
 		}
 	}
 	wg.Wait() //goroutin sync wg
@@ -346,6 +416,9 @@ func InstallMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType strin
 		resultTmp.VmId = v.VmId
 		resultTmp.VmIp = v.VmIp
 		resultTmp.Result = v.Stdout[0]
+		if v.Err != nil || v.Stdout[0] == "" {
+			resultTmp.Result = v.Stderr[0]
+		}
 		content.ResultArray = append(content.ResultArray, resultTmp)
 	}
 
@@ -357,7 +430,7 @@ func InstallMonitorAgentToMcis(nsId string, mcisId string, mcisServiceType strin
 
 // SetMonitoringAgentStatusInstalled is func to Set Monitoring Agent Status Installed
 func SetMonitoringAgentStatusInstalled(nsId string, mcisId string, vmId string) error {
-	targetStatus := "installed"
+	targetStatus := MonAgentStatusInstalled
 	return UpdateMonitoringAgentStatusManually(nsId, mcisId, vmId, targetStatus)
 }
 
@@ -537,3 +610,118 @@ func CallGetMonitoringAsync(wg *sync.WaitGroup, nsID string, mcisID string, vmID
 	}
 
 }
+
+// monAgentInstallInProgress is to avoid overlapped automatic installation for an MCIS (key: nsId/mcisId)
+var monAgentInstallInProgress sync.Map
+
+// MonitoringAgentController checks heartbeats of monitoring agents and updates MonAgentStatus (installed <-> unhealthy, or unknown for VMs without a public IP).
+// It also installs agents to running VMs which are not installed yet (e.g., VMs added by scale-out)
+// when InstallMonAgent of the MCIS is not "no".
+// MonitoringAgentController will be periodically invoked by a time.NewTicker in main.go.
+func MonitoringAgentController() {
+
+	if err := CheckDragonflyEndpoint(); err != nil {
+		// do not change agent status when CB-Dragonfly itself is not available
+		log.Debug().Msg("[MonitoringAgentController] CB-Dragonfly is not available")
+		return
+	}
+
+	nsList, err := common.ListNsId()
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+
+	for _, nsId := range nsList {
+		mcisList, err := ListMcisId(nsId)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+
+		for _, mcisId := range mcisList {
+			mcisTmp, err := GetMcisObject(nsId, mcisId)
+			if err != nil {
+				log.Error().Err(err).Msg("")
+				continue
+			}
+
+			var wg sync.WaitGroup
+			installRequired := false
+
+			for _, vm := range mcisTmp.Vm {
+				if vm.Status != StatusRunning {
+					continue
+				}
+				switch vm.MonAgentStatus {
+				case MonAgentStatusInstalled, MonAgentStatusUnhealthy, MonAgentStatusUnknown:
+					wg.Add(1)
+					go func(vm TbVmInfo) {
+						defer wg.Done()
+						checkMonitoringAgentHeartbeat(nsId, mcisId, vm)
+					}(vm)
+				case MonAgentStatusNotInstalled, "":
+					if !strings.Contains(mcisTmp.InstallMonAgent, "no") {
+						installRequired = true
+					}
+				}
+			}
+			wg.Wait()
+
+			if installRequired {
+				installKey := nsId + "/" + mcisId
+				if _, loaded := monAgentInstallInProgress.LoadOrStore(installKey, true); loaded {
+					continue
+				}
+				go func(nsId string, mcisId string) {
+					defer monAgentInstallInProgress.Delete(nsId + "/" + mcisId)
+
+					reqToMon := &McisCmdReq{}
+					reqToMon.UserName = "cb-user" // this MCIS user name is temporal code. Need to improve.
+
+					log.Info().Msgf("[MonitoringAgentController] Install monitoring agent to %s/%s", nsId, mcisId)
+					_, err := InstallMonitorAgentToMcis(nsId, mcisId, common.StrMCIS, reqToMon)
+					if err != nil {
+						log.Error().Err(err).Msg("")
+					}
+				}(nsId, mcisId)
+			}
+		}
+	}
+}
+
+// checkMonitoringAgentHeartbeat is func to check the agent by the on-demand monitoring API of CB-Dragonfly
+// and to update MonAgentStatus of the VM if changed.
+// The agent of a VM without a public IP is not reachable by CB-Dragonfly, so its status is unknown.
+func checkMonitoringAgentHeartbeat(nsId string, mcisId string, vm TbVmInfo) {
+
+	status := MonAgentStatusUnknown
+	if vm.PublicIP != "" {
+		var wg sync.WaitGroup
+		var resultArray []MonResultSimple
+
+		cmd := "/ns/" + nsId + "/mcis/" + mcisId + "/vm/" + vm.Id + "/agent_ip/" + vm.PublicIP + "/metric/" + monMetricCpu + "/ondemand-monitoring-info"
+		wg.Add(1)
+		CallGetMonitoringAsync(&wg, nsId, mcisId, vm.Id, vm.PublicIP, http.MethodGet, monMetricCpu, cmd, &resultArray)
+
+		status = MonAgentStatusUnhealthy
+		if len(resultArray) > 0 && resultArray[0].Err == "" && resultArray[0].Value != "" {
+			status = MonAgentStatusInstalled
+		}
+	}
+
+	if status != vm.MonAgentStatus {
+		// reload the VM object to avoid overwriting changes made during the check
+		vmInfoTmp, err := GetVmObject(nsId, mcisId, vm.Id)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return
+		}
+		if vmInfoTmp.MonAgentStatus != vm.MonAgentStatus {
+			return
+		}
+		log.Info().Msgf("[MonitoringAgentController] MonAgentStatus of %s/%s/%s: %s -> %s", nsId, mcisId, vm.Id, vm.MonAgentStatus, status)
+		vmInfoTmp.MonAgentStatus = status
+		UpdateVmInfo(nsId, mcisId, vmInfoTmp)
+	}
+}
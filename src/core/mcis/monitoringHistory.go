@@ -0,0 +1,393 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+	"github.com/tidwall/gjson"
+)
+
+// Aggregation for monitoring history query
+const (
+	monAggregationAvg  string = "avg"
+	monAggregationMin  string = "min"
+	monAggregationMax  string = "max"
+	monAggregationSum  string = "sum"
+	monAggregationLast string = "last"
+)
+
+// Source of monitoring data
+const (
+	// MonSourceDragonfly is const for monitoring data from CB-Dragonfly (historical API)
+	MonSourceDragonfly string = "dragonfly"
+	// MonSourceAgentless is const for monitoring data from Tumblebug's own store (agentless collector)
+	MonSourceAgentless string = "agentless"
+)
+
+const maxMonitoringHistoryPoints int = 10000
+
+// MonitoringHistoryReq is struct for the request of monitoring history query
+type MonitoringHistoryReq struct {
+	Metric string `json:"metric" example:"cpu" enums:"cpu,mem,disk,net"`
+	// Source is the source of monitoring data (default: agentless if enabled for the MCIS, otherwise dragonfly)
+	Source string `json:"source" example:"agentless" enums:"agentless,dragonfly"`
+	// Start is the start time of the range (RFC3339, default: 1 hour before End)
+	Start string `json:"start" example:"2024-03-01T11:00:00Z"`
+	// End is the end time of the range (RFC3339, default: now)
+	End string `json:"end" example:"2024-03-01T12:00:00Z"`
+	// StepSec is the resolution of the result series in seconds (default: 60)
+	StepSec int `json:"stepSec" example:"60"`
+	// Aggregation is the function to downsample values in each step
+	Aggregation string `json:"aggregation" example:"avg" enums:"avg,min,max,sum,last"`
+	// SubGroupId is to filter VMs by a subGroup (optional)
+	SubGroupId string `json:"subGroupId" example:"g1"`
+}
+
+// MonitoringSeries is struct for a downsampled series of a VM or a subGroup
+type MonitoringSeries struct {
+	VmId       string            `json:"vmId,omitempty" example:"g1-1"`
+	SubGroupId string            `json:"subGroupId" example:"g1"`
+	Points     []MonMetricSample `json:"points"`
+	Err        string            `json:"err,omitempty"`
+}
+
+// MonitoringHistoryResponse is struct for the result of monitoring history query
+type MonitoringHistoryResponse struct {
+	NsId        string `json:"nsId" example:"ns01"`
+	McisId      string `json:"mcisId" example:"mcis01"`
+	Metric      string `json:"metric" example:"cpu"`
+	Source      string `json:"source" example:"agentless"`
+	Start       string `json:"start" example:"2024-03-01T11:00:00Z"`
+	End         string `json:"end" example:"2024-03-01T12:00:00Z"`
+	StepSec     int    `json:"stepSec" example:"60"`
+	Aggregation string `json:"aggregation" example:"avg"`
+
+	// VmSeries is the series of each VM
+	VmSeries []MonitoringSeries `json:"vmSeries"`
+	// SubGroupSeries is the series of each subGroup (aggregated from VM series in each step with the same aggregation, last is treated as avg)
+	SubGroupSeries []MonitoringSeries `json:"subGroupSeries"`
+}
+
+// GetMonitoringHistory is func to query monitoring data of an MCIS with a time range, step and aggregation
+func GetMonitoringHistory(nsId string, mcisId string, req *MonitoringHistoryReq) (MonitoringHistoryResponse, error) {
+
+	result := MonitoringHistoryResponse{}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	check, _ := CheckMcis(nsId, mcisId)
+	if !check {
+		err := fmt.Errorf("The mcis " + mcisId + " does not exist.")
+		return result, err
+	}
+
+	switch req.Metric {
+	case monMetricCpu, monMetricMem, monMetricDisk, monMetricNet:
+	default:
+		err := fmt.Errorf("Not supported metric: %s (cpu, mem, disk, net)", req.Metric)
+		return result, err
+	}
+
+	aggregation := common.NVL(req.Aggregation, monAggregationAvg)
+	switch aggregation {
+	case monAggregationAvg, monAggregationMin, monAggregationMax, monAggregationSum, monAggregationLast:
+	default:
+		err := fmt.Errorf("Not supported aggregation: %s (avg, min, max, sum, last)", aggregation)
+		return result, err
+	}
+
+	end := time.Now()
+	if req.End != "" {
+		end, err = time.Parse(time.RFC3339, req.End)
+		if err != nil {
+			return result, fmt.Errorf("Invalid end time (RFC3339 is required): %s", err.Error())
+		}
+	}
+	start := end.Add(-time.Hour)
+	if req.Start != "" {
+		start, err = time.Parse(time.RFC3339, req.Start)
+		if err != nil {
+			return result, fmt.Errorf("Invalid start time (RFC3339 is required): %s", err.Error())
+		}
+	}
+	if !start.Before(end) {
+		return result, fmt.Errorf("The start time should be before the end time")
+	}
+	stepSec := req.StepSec
+	if stepSec == 0 {
+		stepSec = 60
+	}
+	if stepSec < 1 {
+		return result, fmt.Errorf("stepSec should be a positive number")
+	}
+	step := time.Duration(stepSec) * time.Second
+	if end.Sub(start)/step > time.Duration(maxMonitoringHistoryPoints) {
+		return result, fmt.Errorf("Too many points for the range and step (max: %d). Increase the step", maxMonitoringHistoryPoints)
+	}
+
+	source := req.Source
+	if source == "" {
+		source = MonSourceDragonfly
+		if _, err := GetAgentlessMonitoringInfo(nsId, mcisId); err == nil {
+			source = MonSourceAgentless
+		}
+	}
+	if source != MonSourceDragonfly && source != MonSourceAgentless {
+		return result, fmt.Errorf("Not supported source: %s (agentless, dragonfly)", source)
+	}
+
+	var vmList []string
+	if req.SubGroupId != "" {
+		vmList, err = ListVmBySubGroup(nsId, mcisId, req.SubGroupId)
+	} else {
+		vmList, err = ListVmId(nsId, mcisId)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	result.NsId = nsId
+	result.McisId = mcisId
+	result.Metric = req.Metric
+	result.Source = source
+	result.Start = start.UTC().Format(time.RFC3339)
+	result.End = end.UTC().Format(time.RFC3339)
+	result.StepSec = stepSec
+	result.Aggregation = aggregation
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for _, vmId := range vmList {
+		vmObj, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		wg.Add(1)
+		go func(vmObj TbVmInfo) {
+			defer wg.Done()
+
+			series := MonitoringSeries{VmId: vmObj.Id, SubGroupId: vmObj.SubGroupId}
+			var samples []MonMetricSample
+			var err error
+			if source == MonSourceAgentless {
+				samples = GetAgentlessMetricSeries(nsId, mcisId, vmObj.Id, req.Metric)
+			} else {
+				samples, err = getDragonflyMetricHistory(nsId, mcisId, vmObj, req.Metric, aggregation, start)
+			}
+			if err != nil {
+				series.Err = err.Error()
+			}
+			series.Points = downsampleMetricSeries(samples, start, end, step, aggregation)
+
+			mutex.Lock()
+			result.VmSeries = append(result.VmSeries, series)
+			mutex.Unlock()
+		}(vmObj)
+	}
+	wg.Wait()
+
+	sort.Slice(result.VmSeries, func(i, j int) bool {
+		return result.VmSeries[i].VmId < result.VmSeries[j].VmId
+	})
+
+	// aggregate VM series for each subGroup
+	subGroupSamples := make(map[string][]MonMetricSample)
+	var subGroupList []string
+	for _, v := range result.VmSeries {
+		if _, ok := subGroupSamples[v.SubGroupId]; !ok {
+			subGroupList = append(subGroupList, v.SubGroupId)
+			subGroupSamples[v.SubGroupId] = []MonMetricSample{}
+		}
+		subGroupSamples[v.SubGroupId] = append(subGroupSamples[v.SubGroupId], v.Points...)
+	}
+	subGroupAggregation := aggregation
+	if subGroupAggregation == monAggregationLast {
+		subGroupAggregation = monAggregationAvg
+	}
+	for _, subGroupId := range subGroupList {
+		samples := subGroupSamples[subGroupId]
+		sort.SliceStable(samples, func(i, j int) bool {
+			return samples[i].Timestamp.Before(samples[j].Timestamp)
+		})
+		result.SubGroupSeries = append(result.SubGroupSeries, MonitoringSeries{
+			SubGroupId: subGroupId,
+			Points:     downsampleMetricSeries(samples, start, end, step, subGroupAggregation),
+		})
+	}
+
+	return result, nil
+}
+
+// downsampleMetricSeries is func to aggregate samples (sorted by time) in [start, end) into buckets of the step.
+// The timestamp of each point is the start of its bucket, and empty buckets are omitted.
+func downsampleMetricSeries(samples []MonMetricSample, start time.Time, end time.Time, step time.Duration, aggregation string) []MonMetricSample {
+
+	points := []MonMetricSample{}
+	bucket := int64(-1)
+	var values []float64
+
+	flush := func() {
+		if len(values) == 0 {
+			return
+		}
+		points = append(points, MonMetricSample{
+			Timestamp: start.Add(time.Duration(bucket) * step).UTC(),
+			Value:     aggregateMetricValues(values, aggregation),
+		})
+		values = nil
+	}
+
+	for _, s := range samples {
+		if s.Timestamp.Before(start) || !s.Timestamp.Before(end) {
+			continue
+		}
+		b := int64(s.Timestamp.Sub(start) / step)
+		if b != bucket {
+			flush()
+			bucket = b
+		}
+		values = append(values, s.Value)
+	}
+	flush()
+
+	return points
+}
+
+// aggregateMetricValues is func to aggregate values with the aggregation (avg, min, max, sum, last)
+func aggregateMetricValues(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case monAggregationMin:
+		v := math.Inf(1)
+		for _, x := range values {
+			v = math.Min(v, x)
+		}
+		return v
+	case monAggregationMax:
+		v := math.Inf(-1)
+		for _, x := range values {
+			v = math.Max(v, x)
+		}
+		return v
+	case monAggregationSum:
+		v := 0.0
+		for _, x := range values {
+			v += x
+		}
+		return v
+	case monAggregationLast:
+		return values[len(values)-1]
+	default:
+		v := 0.0
+		for _, x := range values {
+			v += x
+		}
+		return v / float64(len(values))
+	}
+}
+
+// getDragonflyMetricHistory is func to get the historical metric of a VM from CB-Dragonfly
+func getDragonflyMetricHistory(nsId string, mcisId string, vm TbVmInfo, metric string, aggregation string, start time.Time) ([]MonMetricSample, error) {
+
+	// CB-Dragonfly supports min, max, avg, last for statistics criteria
+	criteria := aggregation
+	if criteria == monAggregationSum {
+		criteria = monAggregationAvg
+	}
+	durationMin := int(math.Ceil(time.Since(start).Minutes()))
+	if durationMin < 1 {
+		durationMin = 1
+	}
+
+	// DF: Get vm monitoring metric info
+	// Path Param: /ns/:nsId/mcis/:mcisId/vm/:vmId/metric/:metric_name/info
+	url := common.DragonflyRestUrl + "/ns/" + nsId + "/mcis/" + mcisId + "/vm/" + vm.Id + "/metric/" + metric + "/info" +
+		"?periodType=m&statisticsCriteria=" + criteria + "&duration=" + strconv.Itoa(durationMin) + "m"
+	log.Debug().Msg("URL: " + url)
+
+	client := &http.Client{Timeout: 1 * time.Minute}
+	res, err := client.Get(url)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	if res.StatusCode >= 400 || res.StatusCode < 200 {
+		err := fmt.Errorf("CB-DF HTTP Status: " + strconv.Itoa(res.StatusCode) + " / " + string(body))
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	// same fields with the on-demand monitoring (see CallGetMonitoringAsync)
+	field := map[string]string{
+		monMetricCpu:  "cpu_utilization",
+		monMetricMem:  "mem_utilization",
+		monMetricDisk: "disk_utilization",
+		monMetricNet:  "bytes_out",
+	}[metric]
+
+	response := string(body)
+	columnIndex := -1
+	for i, c := range gjson.Get(response, "columns").Array() {
+		if c.String() == field {
+			columnIndex = i
+		}
+	}
+	if columnIndex < 0 {
+		return nil, fmt.Errorf("No %s field in the CB-DF response", field)
+	}
+
+	var samples []MonMetricSample
+	for _, row := range gjson.Get(response, "values").Array() {
+		cols := row.Array()
+		if len(cols) <= columnIndex {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, cols[0].String())
+		if err != nil {
+			continue
+		}
+		samples = append(samples, MonMetricSample{Timestamp: t, Value: cols[columnIndex].Float()})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+
+	return samples, nil
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// networkMeshLatencySuite is the benchmark suite for latency, jitter and packet loss between VMs
+	networkMeshLatencySuite = "ping"
+	// networkMeshThroughputSuite is the benchmark suite for throughput between VMs
+	networkMeshThroughputSuite = "iperf3"
+
+	// networkMeshDefaultWorstLinks is the default number of worst links in the result
+	networkMeshDefaultWorstLinks = 5
+
+	// networkMeshUnknown is the value of an unmeasured link in matrices
+	networkMeshUnknown = -1.0
+)
+
+const (
+	// thresholds (relative to the median of links) to flag a link as an issue
+	networkMeshHighLatencyRatio    = 2.0
+	networkMeshHighJitterRatio     = 3.0
+	networkMeshLowThroughputRatio  = 0.5
+	networkMeshPacketLossThreshold = 1.0
+)
+
+// NetworkMeshReq is struct for a request of full-mesh network benchmark between VMs in MCIS
+type NetworkMeshReq struct {
+	// SubGroupRepresentative is to measure only between representatives (the first VM) of subGroups
+	SubGroupRepresentative bool `json:"subGroupRepresentative" example:"true" default:"false"`
+	// VmIds is to measure only between the VMs (all VMs in MCIS if omitted)
+	VmIds []string `json:"vmIds,omitempty"`
+	// Throughput is to measure throughput by iperf3 (TCP port 5201 should be allowed), in addition to latency by ping
+	Throughput bool   `json:"throughput" example:"true" default:"false"`
+	UserName   string `json:"userName,omitempty" example:"cb-user"`
+	// SkipInstall is to skip installation of benchmark tools (if already installed)
+	SkipInstall bool `json:"skipInstall,omitempty" example:"false"`
+	// WorstLinks is the number of worst links to highlight (default: 5)
+	WorstLinks int `json:"worstLinks,omitempty" validate:"min=0,max=1000" example:"5" default:"5"`
+}
+
+// NetworkMeshNode is struct for a VM (node) in full-mesh network benchmark
+type NetworkMeshNode struct {
+	VmId       string `json:"vmId" example:"g1-1"`
+	SubGroupId string `json:"subGroupId" example:"g1"`
+	SpecId     string `json:"specId" example:"aws+ap-northeast-2+t2.small"`
+	// Region is {providerName}-{regionName} of the VM
+	Region string `json:"region" example:"aws-ap-northeast-2"`
+}
+
+// NetworkMeshLink is struct for measurements of a link (from a source VM to a target VM)
+type NetworkMeshLink struct {
+	Src            string  `json:"src" example:"g1-1"`
+	Dest           string  `json:"dest" example:"g2-1"`
+	LatencyMs      float64 `json:"latencyMs" example:"32.5"`
+	JitterMs       float64 `json:"jitterMs" example:"0.8"`
+	PacketLoss     float64 `json:"packetLoss" example:"0"`
+	ThroughputMbps float64 `json:"throughputMbps" example:"920.4"`
+	// Issues is the list of problems of the link (unreachable, packetLoss, highLatency, highJitter, lowThroughput)
+	Issues []string `json:"issues,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// NetworkMeshResult is struct for results of full-mesh network benchmark.
+// Matrices are indexed by nodes ([source][target]), and -1 is for an unmeasured link.
+type NetworkMeshResult struct {
+	McisId           string            `json:"mcisId" example:"mcis01"`
+	Nodes            []NetworkMeshNode `json:"nodes"`
+	LatencyMs        [][]float64       `json:"latencyMs"`
+	JitterMs         [][]float64       `json:"jitterMs"`
+	PacketLoss       [][]float64       `json:"packetLoss"`
+	ThroughputMbps   [][]float64       `json:"throughputMbps,omitempty"`
+	MedianLatencyMs  float64           `json:"medianLatencyMs" example:"45.1"`
+	MedianThroughput float64           `json:"medianThroughputMbps,omitempty" example:"850.3"`
+	Links            []NetworkMeshLink `json:"links"`
+	// WorstLinks is the list of links with issues first (and the highest latency)
+	WorstLinks []NetworkMeshLink `json:"worstLinks"`
+	StartedAt  string            `json:"startedAt" example:"2024-01-01T00:00:00Z"`
+	ElapsedSec float64           `json:"elapsedSec" example:"120.5"`
+}
+
+// medianFloat64 is func to get the median of values (0 if empty)
+func medianFloat64(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	if len(sorted)%2 == 1 {
+		return sorted[len(sorted)/2]
+	}
+	return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+}
+
+// networkMeshIssueSeverity is the severity of each issue of a link (to sort the worst links)
+var networkMeshIssueSeverity = map[string]int{
+	"unreachable":   100,
+	"packetLoss":    10,
+	"highLatency":   1,
+	"highJitter":    1,
+	"lowThroughput": 1,
+}
+
+// networkMeshLinkSeverity is func to get the total severity of issues of a link
+func networkMeshLinkSeverity(link NetworkMeshLink) int {
+	severity := 0
+	for _, issue := range link.Issues {
+		severity += networkMeshIssueSeverity[issue]
+	}
+	return severity
+}
+
+// newNetworkMeshMatrix is func to generate a matrix for n nodes (0 for diagonal, unknown otherwise)
+func newNetworkMeshMatrix(n int) [][]float64 {
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		for j := range matrix[i] {
+			if i != j {
+				matrix[i][j] = networkMeshUnknown
+			}
+		}
+	}
+	return matrix
+}
+
+// getNetworkMeshNodes is func to get VMs (nodes) of full-mesh network benchmark
+func getNetworkMeshNodes(nsId string, mcisId string, req *NetworkMeshReq) ([]NetworkMeshNode, error) {
+
+	vmList, err := getTargetVmList(nsId, mcisId, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(req.VmIds) > 0 {
+		inMcis := map[string]bool{}
+		for _, v := range vmList {
+			inMcis[v] = true
+		}
+		for _, v := range req.VmIds {
+			if !inMcis[v] {
+				err := fmt.Errorf("The VM %s does not exist in %s", v, mcisId)
+				return nil, err
+			}
+		}
+		vmList = req.VmIds
+	}
+
+	nodes := []NetworkMeshNode{}
+	representative := map[string]bool{}
+	for _, v := range vmList {
+		vm, err := GetVmObject(nsId, mcisId, v)
+		if err != nil {
+			return nil, err
+		}
+		if req.SubGroupRepresentative && vm.SubGroupId != "" {
+			if representative[vm.SubGroupId] {
+				continue
+			}
+			representative[vm.SubGroupId] = true
+		}
+		nodes = append(nodes, NetworkMeshNode{
+			VmId:       vm.Id,
+			SubGroupId: vm.SubGroupId,
+			SpecId:     vm.SpecId,
+			Region:     strings.ToLower(vm.ConnectionConfig.ProviderName + "-" + vm.ConnectionConfig.RegionDetail.RegionName),
+		})
+	}
+	if len(nodes) < 2 {
+		err := fmt.Errorf("full-mesh network benchmark requires at least 2 VMs (%d)", len(nodes))
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// RunNetworkMeshBenchmark is func to measure latency, jitter, packet loss (and throughput)
+// between every pair of VMs in MCIS and to highlight the worst links
+func RunNetworkMeshBenchmark(nsId string, mcisId string, req *NetworkMeshReq) (NetworkMeshResult, error) {
+
+	result := NetworkMeshResult{McisId: mcisId, Links: []NetworkMeshLink{}, WorstLinks: []NetworkMeshLink{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = validate.Struct(req)
+	if err != nil {
+		return result, err
+	}
+
+	nodes, err := getNetworkMeshNodes(nsId, mcisId, req)
+	if err != nil {
+		return result, err
+	}
+	result.Nodes = nodes
+
+	started := time.Now()
+	result.StartedAt = started.UTC().Format(time.RFC3339)
+
+	index := map[string]int{}
+	vmIds := []string{}
+	for i, node := range nodes {
+		index[node.VmId] = i
+		vmIds = append(vmIds, node.VmId)
+	}
+	n := len(nodes)
+	result.LatencyMs = newNetworkMeshMatrix(n)
+	result.JitterMs = newNetworkMeshMatrix(n)
+	result.PacketLoss = newNetworkMeshMatrix(n)
+
+	links := map[[2]int]*NetworkMeshLink{}
+	for i := range nodes {
+		for j := range nodes {
+			if i != j {
+				links[[2]int{i, j}] = &NetworkMeshLink{
+					Src: nodes[i].VmId, Dest: nodes[j].VmId,
+					LatencyMs: networkMeshUnknown, JitterMs: networkMeshUnknown, PacketLoss: networkMeshUnknown, ThroughputMbps: networkMeshUnknown,
+				}
+			}
+		}
+	}
+
+	// latency, jitter and packet loss (and throughput) are measured in turn not to interfere with each other
+	suites := []string{networkMeshLatencySuite}
+	if req.Throughput {
+		suites = append(suites, networkMeshThroughputSuite)
+		result.ThroughputMbps = newNetworkMeshMatrix(n)
+	}
+	for _, suite := range suites {
+		suiteResult, err := RunBenchmarkSuite(nsId, mcisId, &BenchmarkSuiteRunReq{
+			Suite:       suite,
+			VmIds:       vmIds,
+			UserName:    req.UserName,
+			SkipInstall: req.SkipInstall,
+		})
+		if err != nil {
+			return result, err
+		}
+		for _, r := range suiteResult.Results {
+			i, j := index[r.VmId], index[r.TargetVmId]
+			link, ok := links[[2]int{i, j}]
+			if !ok {
+				continue
+			}
+			if r.Error != "" {
+				if link.Error != "" {
+					link.Error += "; "
+				}
+				link.Error += suite + ": " + r.Error
+			}
+			for _, m := range r.Metrics {
+				switch {
+				case suite == networkMeshLatencySuite && m.Name == "rtt":
+					link.LatencyMs = m.Value
+					result.LatencyMs[i][j] = m.Value
+				case suite == networkMeshLatencySuite && m.Name == "jitter":
+					link.JitterMs = m.Value
+					result.JitterMs[i][j] = m.Value
+				case suite == networkMeshLatencySuite && m.Name == "packetLoss":
+					link.PacketLoss = m.Value
+					result.PacketLoss[i][j] = m.Value
+				case suite == networkMeshThroughputSuite && m.Name == "throughput":
+					link.ThroughputMbps = m.Value
+					result.ThroughputMbps[i][j] = m.Value
+				}
+			}
+		}
+	}
+
+	// medians of links as references of issues
+	latencies, jitters, throughputs := []float64{}, []float64{}, []float64{}
+	for _, link := range links {
+		if link.LatencyMs >= 0 {
+			latencies = append(latencies, link.LatencyMs)
+		}
+		if link.JitterMs >= 0 {
+			jitters = append(jitters, link.JitterMs)
+		}
+		if link.ThroughputMbps >= 0 {
+			throughputs = append(throughputs, link.ThroughputMbps)
+		}
+	}
+	result.MedianLatencyMs = medianFloat64(latencies)
+	medianJitter := medianFloat64(jitters)
+	if req.Throughput {
+		result.MedianThroughput = medianFloat64(throughputs)
+	}
+
+	now := time.Now()
+	for i := range nodes {
+		for j := range nodes {
+			link, ok := links[[2]int{i, j}]
+			if !ok {
+				continue
+			}
+			issues := []string{}
+			if link.LatencyMs < 0 || link.PacketLoss >= 100 {
+				issues = append(issues, "unreachable")
+			} else {
+				if link.PacketLoss >= networkMeshPacketLossThreshold {
+					issues = append(issues, "packetLoss")
+				}
+				if result.MedianLatencyMs > 0 && link.LatencyMs > result.MedianLatencyMs*networkMeshHighLatencyRatio {
+					issues = append(issues, "highLatency")
+				}
+				if medianJitter > 0 && link.JitterMs > medianJitter*networkMeshHighJitterRatio {
+					issues = append(issues, "highJitter")
+				}
+			}
+			if req.Throughput && result.MedianThroughput > 0 && link.ThroughputMbps >= 0 && link.ThroughputMbps < result.MedianThroughput*networkMeshLowThroughputRatio {
+				issues = append(issues, "lowThroughput")
+			}
+			link.Issues = issues
+			result.Links = append(result.Links, *link)
+
+			// keep measured latencies between regions for recommendation and placement
+			src, dest := nodes[i].Region, nodes[j].Region
+			if link.LatencyMs >= 0 && src != dest {
+				_, err := RecordLatency(src, dest, link.LatencyMs, now, LatencySourceBenchmark)
+				if err != nil {
+					log.Debug().Err(err).Msg("")
+				}
+			}
+		}
+	}
+
+	worst := append([]NetworkMeshLink{}, result.Links...)
+	sort.SliceStable(worst, func(a, b int) bool {
+		severityA, severityB := networkMeshLinkSeverity(worst[a]), networkMeshLinkSeverity(worst[b])
+		if severityA != severityB {
+			return severityA > severityB
+		}
+		return worst[a].LatencyMs > worst[b].LatencyMs
+	})
+	worstLinks := req.WorstLinks
+	if worstLinks == 0 {
+		worstLinks = networkMeshDefaultWorstLinks
+	}
+	if len(worst) > worstLinks {
+		worst = worst[:worstLinks]
+	}
+	result.WorstLinks = worst
+
+	result.ElapsedSec = time.Since(started).Seconds()
+	log.Info().Msgf("[NetworkMesh] %s/%s: %d links, median latency %.2f ms", nsId, mcisId, len(result.Links), result.MedianLatencyMs)
+	return result, nil
+}
@@ -194,87 +194,8 @@ func OrchestrationController() {
 						averMcis := (sumMcis / float64(len(content.McisMonitoring)))
 						fmt.Printf("[monData.Value] AverMcis: %f,  SumMcis: %f \n", averMcis, sumMcis)
 
-						evaluationPeriod, _ := strconv.Atoi(mcisPolicyTmp.Policy[policyIndex].AutoCondition.EvaluationPeriod)
-						evaluationValue := mcisPolicyTmp.Policy[policyIndex].AutoCondition.EvaluationValue
-						evaluationValue = append([]string{fmt.Sprintf("%f", averMcis)}, evaluationValue...) // prepend current aver date
-						mcisPolicyTmp.Policy[policyIndex].AutoCondition.EvaluationValue = evaluationValue
-
-						sum := 0.0
-						aver := -0.1
-						// accumerate previous evaluation value
-						fmt.Printf("[Evaluation History]\n")
-						for evi, evv := range evaluationValue {
-							evvFloat, _ := strconv.ParseFloat(evv, 64)
-							sum += evvFloat
-							fmt.Printf("[%v] %f ", evi, evvFloat)
-							// break with outside evaluationValue
-							if evi >= evaluationPeriod-1 {
-								break
-							}
-						}
-						// average for evaluationPeriod (if data for the period is not enough, skip)
-						if evaluationPeriod != 0 && len(evaluationValue) >= evaluationPeriod {
-							aver = sum / float64(evaluationPeriod)
-						}
-						fmt.Printf("\n[Evaluation] Aver: %f,  Period: %v \n", aver, evaluationPeriod)
-
 						//Detecting
-						operator := mcisPolicyTmp.Policy[policyIndex].AutoCondition.Operator
-						operand, _ := strconv.ParseFloat(mcisPolicyTmp.Policy[policyIndex].AutoCondition.Operand, 64)
-
-						if evaluationPeriod == 0 {
-							log.Debug().Msg("[Checking] Not available evaluationPeriod ")
-							mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
-							UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
-							break
-						}
-						// not enough evaluationPeriod
-						if aver == -0.1 {
-							log.Debug().Msg("[Checking] Not enough evaluationPeriod ")
-							mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusReady
-							UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
-							break
-						}
-						switch {
-						case operator == ">=":
-							if aver >= operand {
-								fmt.Printf("[Detected] Aver: %f >=  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusDetected
-							} else {
-								fmt.Printf("[Not Detected] Aver: %f >=  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusReady
-							}
-						case operator == ">":
-							if aver > operand {
-								fmt.Printf("[Detected] Aver: %f >  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusDetected
-
-							} else {
-								fmt.Printf("[Not Detected] Aver: %f >  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusReady
-							}
-						case operator == "<=":
-							if aver <= operand {
-								fmt.Printf("[Detected] Aver: %f <=  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusDetected
-
-							} else {
-								fmt.Printf("[Not Detected] Aver: %f <=  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusReady
-							}
-						case operator == "<":
-							if aver < operand {
-								fmt.Printf("[Detected] Aver: %f <  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusDetected
-
-							} else {
-								fmt.Printf("[Not Detected] Aver: %f <  Operand: %f \n", aver, operand)
-								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusReady
-							}
-						default:
-							log.Debug().Msg("[Checking] Not available operator " + operator)
-							mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
-						}
+						mcisPolicyTmp.Policy[policyIndex].Status, _ = evaluateAutoCondition(&mcisPolicyTmp.Policy[policyIndex].AutoCondition, averMcis)
 					}
 					UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
 					log.Debug().Msg("- PolicyStatus[" + mcisPolicyTmp.Policy[policyIndex].Status + "],[" + v + "]")
@@ -298,6 +219,7 @@ func OrchestrationController() {
 
 					autoAction := mcisPolicyTmp.Policy[policyIndex].AutoAction
 					log.Debug().Msg("[autoAction] " + autoAction.ActionType)
+					var actionErr error
 
 					switch {
 					case autoAction.ActionType == AutoActionScaleOut:
@@ -344,6 +266,7 @@ func OrchestrationController() {
 						log.Debug().Msg("[Generating VM]")
 						result, vmCreateErr := CreateMcisVmDynamic(nsId, mcisPolicyTmp.Id, &autoAction.VmDynamicReq)
 						if vmCreateErr != nil {
+							actionErr = vmCreateErr
 							mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
 							UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
 						}
@@ -354,6 +277,7 @@ func OrchestrationController() {
 							log.Debug().Msgf("[Post Command to VM] %v", autoAction.PostCommand.Command)
 							_, cmdErr := RemoteCommandToMcis(nsId, mcisPolicyTmp.Id, common.ToLower(autoAction.VmDynamicReq.Name), "", &autoAction.PostCommand)
 							if cmdErr != nil {
+								actionErr = cmdErr
 								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
 								UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
 							}
@@ -366,6 +290,7 @@ func OrchestrationController() {
 						log.Debug().Msg("[Removing VM]")
 						vmList, vmListErr := ListVmByLabel(nsId, mcisPolicyTmp.Id, labelAutoGen)
 						if vmListErr != nil {
+							actionErr = vmListErr
 							mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
 							UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
 						}
@@ -374,6 +299,7 @@ func OrchestrationController() {
 							log.Debug().Msg("[Removing VM ID] " + removeTargetVm)
 							delVmErr := DelMcisVm(nsId, mcisPolicyTmp.Id, removeTargetVm, "")
 							if delVmErr != nil {
+								actionErr = delVmErr
 								mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusError
 								UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
 							}
@@ -381,6 +307,7 @@ func OrchestrationController() {
 
 					default:
 					}
+					common.ObservePolicyExecution(nsId, autoAction.ActionType, actionErr)
 
 					mcisPolicyTmp.Policy[policyIndex].Status = AutoStatusStabilizing
 					UpdateMcisPolicyInfo(nsId, mcisPolicyTmp)
@@ -423,6 +350,226 @@ func OrchestrationController() {
 
 }
 
+// evaluateAutoCondition prepends the given value to the evaluation history of the condition,
+// averages the history for the evaluation period, and returns the next policy status with the average.
+// (AutoStatusReady if the period is not filled yet or the condition is not met)
+func evaluateAutoCondition(autoCondition *AutoCondition, value float64) (string, float64) {
+
+	evaluationPeriod, _ := strconv.Atoi(autoCondition.EvaluationPeriod)
+	evaluationValue := autoCondition.EvaluationValue
+	evaluationValue = append([]string{fmt.Sprintf("%f", value)}, evaluationValue...) // prepend current aver date
+	autoCondition.EvaluationValue = evaluationValue
+
+	sum := 0.0
+	aver := -0.1
+	// accumerate previous evaluation value
+	history := ""
+	for evi, evv := range evaluationValue {
+		evvFloat, _ := strconv.ParseFloat(evv, 64)
+		sum += evvFloat
+		history += fmt.Sprintf("[%v] %f ", evi, evvFloat)
+		// break with outside evaluationValue
+		if evi >= evaluationPeriod-1 {
+			break
+		}
+	}
+	// average for evaluationPeriod (if data for the period is not enough, skip)
+	if evaluationPeriod != 0 && len(evaluationValue) >= evaluationPeriod {
+		aver = sum / float64(evaluationPeriod)
+	}
+	log.Debug().Msgf("[Evaluation History] %s", history)
+	log.Debug().Msgf("[Evaluation] Aver: %f,  Period: %v", aver, evaluationPeriod)
+
+	operator := autoCondition.Operator
+	operand, _ := strconv.ParseFloat(autoCondition.Operand, 64)
+
+	if evaluationPeriod == 0 {
+		log.Debug().Msg("[Checking] Not available evaluationPeriod ")
+		return AutoStatusError, aver
+	}
+	// not enough evaluationPeriod
+	if aver == -0.1 {
+		log.Debug().Msg("[Checking] Not enough evaluationPeriod ")
+		return AutoStatusReady, aver
+	}
+
+	detected := false
+	switch {
+	case operator == ">=":
+		detected = aver >= operand
+	case operator == ">":
+		detected = aver > operand
+	case operator == "<=":
+		detected = aver <= operand
+	case operator == "<":
+		detected = aver < operand
+	default:
+		log.Debug().Msg("[Checking] Not available operator " + operator)
+		return AutoStatusError, aver
+	}
+
+	if detected {
+		log.Debug().Msgf("[Detected] Aver: %f %s  Operand: %f", aver, operator, operand)
+		return AutoStatusDetected, aver
+	}
+	log.Debug().Msgf("[Not Detected] Aver: %f %s  Operand: %f", aver, operator, operand)
+	return AutoStatusReady, aver
+}
+
+// McisPolicySimulationMetric is struct for a metric time series used in MCIS auto-control policy simulation.
+type McisPolicySimulationMetric struct {
+	// Metric is the name of the metric (should match autoCondition.metric of the policy)
+	Metric string `json:"metric" example:"cpu"`
+	// Values is the MCIS average value of the metric for each controller tick (synthetic or recorded)
+	Values []float64 `json:"values" example:"10,35,82,91,88,40"`
+}
+
+// McisPolicySimulationReq is struct for MCIS auto-control Policy simulation request.
+type McisPolicySimulationReq struct {
+	McisPolicyReq McisPolicyReq                `json:"mcisPolicyReq"`
+	MetricSeries  []McisPolicySimulationMetric `json:"metricSeries"`
+}
+
+// McisPolicySimulationStep is struct for a state transition of a policy in a simulated controller tick.
+type McisPolicySimulationStep struct {
+	Tick        int    `json:"tick" example:"3"`
+	PolicyIndex int    `json:"policyIndex" example:"0"`
+	Metric      string `json:"metric" example:"cpu"`
+	// Value is the metric value consumed in this tick (empty if the policy was not measuring)
+	Value string `json:"value,omitempty" example:"91.000000"`
+	// EvaluatedAverage is the average for the evaluation period (empty if not enough values)
+	EvaluatedAverage string `json:"evaluatedAverage,omitempty" example:"87.666667"`
+	// StatusFlow is the sequence of statuses the policy went through in this tick
+	StatusFlow []string `json:"statusFlow" example:"Ready,Checking,Detected"`
+	// Action is the action the controller would take in this tick (not executed)
+	Action       string `json:"action,omitempty" example:"ScaleOut"`
+	ActionDetail string `json:"actionDetail,omitempty"`
+}
+
+// McisPolicySimulationResult is struct for the result of MCIS auto-control Policy simulation.
+type McisPolicySimulationResult struct {
+	NsId        string                     `json:"nsId" example:"ns01"`
+	McisId      string                     `json:"mcisId" example:"mcis01"`
+	TotalTicks  int                        `json:"totalTicks" example:"6"`
+	ActionCount int                        `json:"actionCount" example:"1"`
+	Steps       []McisPolicySimulationStep `json:"steps"`
+}
+
+// SimulateMcisPolicy replays the given policies over the given metric series
+// with the same state machine of OrchestrationController, without executing any action.
+func SimulateMcisPolicy(nsId string, mcisId string, req *McisPolicySimulationReq) (McisPolicySimulationResult, error) {
+
+	result := McisPolicySimulationResult{NsId: nsId, McisId: mcisId}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	check, _ := CheckMcis(nsId, mcisId)
+	if !check {
+		err := fmt.Errorf("The mcis " + mcisId + " does not exist.")
+		return result, err
+	}
+	if len(req.McisPolicyReq.Policy) == 0 {
+		err := fmt.Errorf("No policy is given for the simulation")
+		return result, err
+	}
+
+	series := make(map[string][]float64)
+	for _, v := range req.MetricSeries {
+		series[v.Metric] = v.Values
+		if len(v.Values) > result.TotalTicks {
+			result.TotalTicks = len(v.Values)
+		}
+	}
+
+	// work on a copy so that the request is not modified
+	policies := make([]Policy, len(req.McisPolicyReq.Policy))
+	for i, p := range req.McisPolicyReq.Policy {
+		if _, ok := series[p.AutoCondition.Metric]; !ok {
+			err := fmt.Errorf("No metric series is given for the metric (%s) of policy[%d]", p.AutoCondition.Metric, i)
+			return result, err
+		}
+		p.AutoCondition.EvaluationValue = append([]string{}, p.AutoCondition.EvaluationValue...)
+		p.Status = AutoStatusReady
+		policies[i] = p
+	}
+
+	for tick := 0; tick < result.TotalTicks; tick++ {
+		for policyIndex := range policies {
+			policy := &policies[policyIndex]
+			step := McisPolicySimulationStep{
+				Tick:        tick,
+				PolicyIndex: policyIndex,
+				Metric:      policy.AutoCondition.Metric,
+				StatusFlow:  []string{policy.Status},
+			}
+
+			switch policy.Status {
+			case AutoStatusReady:
+				values := series[policy.AutoCondition.Metric]
+				if tick >= len(values) {
+					// no more measurement for this metric (same as failure of GetMonitoringData)
+					policy.Status = AutoStatusError
+					step.StatusFlow = append(step.StatusFlow, AutoStatusChecking, policy.Status)
+					break
+				}
+				step.Value = fmt.Sprintf("%f", values[tick])
+				var aver float64
+				policy.Status, aver = evaluateAutoCondition(&policy.AutoCondition, values[tick])
+				if aver != -0.1 {
+					step.EvaluatedAverage = fmt.Sprintf("%f", aver)
+				}
+				step.StatusFlow = append(step.StatusFlow, AutoStatusChecking, policy.Status)
+
+			case AutoStatusDetected:
+				autoAction := policy.AutoAction
+				step.Action = autoAction.ActionType
+				switch autoAction.ActionType {
+				case AutoActionScaleOut:
+					spec := autoAction.VmDynamicReq.CommonSpec
+					if autoAction.PlacementAlgo == "random" {
+						spec = "(randomly recommended spec)"
+					}
+					step.ActionDetail = fmt.Sprintf("Add SubGroup %s-(uuid) with %s VM(s) [spec: %s, image: %s, label: %s]",
+						common.ToLower(autoAction.VmDynamicReq.Name), common.NVL(autoAction.VmDynamicReq.SubGroupSize, "1"),
+						spec, autoAction.VmDynamicReq.CommonImage, labelAutoGen)
+					if len(autoAction.PostCommand.Command) != 0 {
+						step.ActionDetail += fmt.Sprintf(" and run post command %v", autoAction.PostCommand.Command)
+					}
+				case AutoActionScaleIn:
+					step.ActionDetail = "Remove the last VM labeled " + labelAutoGen
+				default:
+					step.ActionDetail = "No action for the action type"
+				}
+				result.ActionCount++
+				policy.Status = AutoStatusStabilizing
+				step.StatusFlow = append(step.StatusFlow, AutoStatusOperating, policy.Status)
+
+			case AutoStatusStabilizing:
+				policy.AutoCondition.EvaluationValue = nil
+				policy.Status = AutoStatusReady
+				step.StatusFlow = append(step.StatusFlow, policy.Status)
+
+			case AutoStatusError:
+				policy.Status = AutoStatusReady
+				step.StatusFlow = append(step.StatusFlow, policy.Status)
+
+			default:
+			}
+			result.Steps = append(result.Steps, step)
+		}
+	}
+
+	return result, nil
+}
+
 // UpdateMcisPolicyInfo updates McisPolicyInfo object in DB.
 func UpdateMcisPolicyInfo(nsId string, mcisPolicyInfoData McisPolicyInfo) {
 	key := common.GenMcisPolicyKey(nsId, mcisPolicyInfoData.Id, "")
@@ -0,0 +1,504 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// placementMaxCandidates is the maximum number of candidate specs of a subGroup in the placement search
+	placementMaxCandidates = 30
+
+	// placementMaxSearchNodes is the maximum number of nodes visited in the placement search
+	placementMaxSearchNodes = 1000000
+)
+
+// McisPlacementReq is struct for a request to optimize the placement of a whole MCIS
+type McisPlacementReq struct {
+	// Name is the name of MCIS to be used in the generated TbMcisDynamicReq
+	Name            string `json:"name" validate:"required" example:"mcis01"`
+	InstallMonAgent string `json:"installMonAgent" example:"no" default:"no" enums:"yes,no"`
+	Label           string `json:"label" example:"DynamicVM" default:""`
+	Description     string `json:"description" example:"Made in CB-TB"`
+
+	SubGroups  []PlacementSubGroupReq `json:"subGroups" validate:"required"`
+	Constraint PlacementConstraint    `json:"constraint"`
+}
+
+// PlacementSubGroupReq is struct for the requirements of a subGroup in a placement request
+type PlacementSubGroupReq struct {
+	Name         string `json:"name" validate:"required" example:"g1"`
+	SubGroupSize string `json:"subGroupSize" example:"3" default:"1"`
+	// CommonImage is the OS type (resolved for the region of the selected spec) or id of an image in common namespace
+	CommonImage  string `json:"commonImage" validate:"required" example:"ubuntu22.04"`
+	RootDiskType string `json:"rootDiskType,omitempty" example:"default" default:"default"`
+	RootDiskSize string `json:"rootDiskSize,omitempty" example:"default" default:"default"`
+	Label        string `json:"label,omitempty" example:"web"`
+	Description  string `json:"description,omitempty" example:"Description"`
+
+	// Filter is the spec requirements of the subGroup (same as the filter of mcisRecommendVm)
+	Filter FilterInfo `json:"filter"`
+	// Priority is the preference of specs of the subGroup (same as the priority of mcisRecommendVm, cost if omitted)
+	Priority PriorityInfo `json:"priority"`
+	// AllowedRegions is the regions allowed for the subGroup (data residency) in addition to the constraint of MCIS
+	AllowedRegions []string `json:"allowedRegions,omitempty" example:"aws+ap-northeast-2,ap-northeast-1"`
+}
+
+// PlacementConstraint is struct for the constraints of a whole MCIS placement
+type PlacementConstraint struct {
+	// MinDistinctProviders is the minimum number of distinct cloud providers in MCIS (0: no constraint)
+	MinDistinctProviders int `json:"minDistinctProviders" example:"2"`
+	// MaxLatencyMs is the maximum latency between any two regions of subGroups from the latency store (0: no constraint)
+	MaxLatencyMs float64 `json:"maxLatencyMs" example:"150"`
+	// MaxCostPerHour is the total budget per hour of all VMs in MCIS (0: no constraint)
+	MaxCostPerHour float64 `json:"maxCostPerHour" example:"2.5"`
+	// AllowedRegions is the regions allowed for all subGroups (data residency), in the form of {providerName}+{regionName} or {regionName}
+	AllowedRegions []string `json:"allowedRegions,omitempty" example:"aws+ap-northeast-2,gcp+asia-northeast3"`
+	// ProviderQuota is the maximum number of VMs for each provider
+	ProviderQuota map[string]int `json:"providerQuota,omitempty" example:"aws:4,azure:2"`
+}
+
+// SubGroupPlacement is struct for the spec and region assigned to a subGroup
+type SubGroupPlacement struct {
+	SubGroupName   string  `json:"subGroupName" example:"g1"`
+	SubGroupSize   int     `json:"subGroupSize" example:"3"`
+	SpecId         string  `json:"specId" example:"aws+ap-northeast-2+t2.small"`
+	ProviderName   string  `json:"providerName" example:"aws"`
+	RegionName     string  `json:"regionName" example:"ap-northeast-2"`
+	ConnectionName string  `json:"connectionName" example:"aws-ap-northeast-2"`
+	CostPerHour    float64 `json:"costPerHour" example:"0.0116"`
+	// Score is the total score of the spec among the candidates of the subGroup (1 is the best)
+	Score float64 `json:"score" example:"0.87"`
+}
+
+// McisPlacementResult is struct for the result of a placement optimization
+type McisPlacementResult struct {
+	Placement []SubGroupPlacement `json:"placement"`
+	// Score is the average score of subGroups weighted by subGroupSize
+	Score             float64 `json:"score" example:"0.85"`
+	TotalCostPerHour  float64 `json:"totalCostPerHour" example:"0.35"`
+	MaxLatencyMs      float64 `json:"maxLatencyMs" example:"32"`
+	DistinctProviders int     `json:"distinctProviders" example:"2"`
+	// Optimal is false if the search stopped at the limit before exploring all the candidates,
+	// or if candidate specs of subGroups were pruned to limit the search space
+	Optimal bool `json:"optimal" example:"true"`
+	// McisDynamicReq is the request to create MCIS with the placement (for POST /ns/{nsId}/mcisDynamic)
+	McisDynamicReq TbMcisDynamicReq `json:"mcisDynamicReq"`
+}
+
+// placementCandidate is struct for a candidate spec of a subGroup in the placement search
+type placementCandidate struct {
+	spec      mcir.TbSpecInfo
+	regionKey string
+	score     float64
+}
+
+// placementSearch is struct for the state of the placement search (branch and bound)
+type placementSearch struct {
+	constraint PlacementConstraint
+	sizes      []int
+	weightSum  float64
+	candidates [][]placementCandidate
+	// maxRemainingScore[i] is the upper bound of the weighted score of subGroups from i
+	maxRemainingScore []float64
+	// minRemainingCost[i] is the lower bound of the cost of subGroups from i
+	minRemainingCost []float64
+
+	selected      []int
+	providerCount map[string]int
+	nodes         int
+
+	best      []int
+	bestScore float64
+	bestCost  float64
+}
+
+// OptimizeMcisPlacement is func to find the optimized assignment of specs (and regions) for all subGroups of MCIS
+// under the constraints. It maximizes the scores of subGroups weighted by subGroupSize, and the lower cost wins the tie.
+func OptimizeMcisPlacement(nsId string, req *McisPlacementReq) (McisPlacementResult, error) {
+
+	emptyObj := McisPlacementResult{}
+
+	err := common.CheckString(req.Name)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return emptyObj, err
+	}
+	if len(req.SubGroups) == 0 {
+		return emptyObj, fmt.Errorf("no subGroup is given")
+	}
+	c := req.Constraint
+	if c.MinDistinctProviders < 0 || c.MaxLatencyMs < 0 || c.MaxCostPerHour < 0 {
+		return emptyObj, fmt.Errorf("constraints should not be negative")
+	}
+
+	quota := map[string]int{}
+	for provider, n := range c.ProviderQuota {
+		if n < 0 {
+			return emptyObj, fmt.Errorf("quota of provider %s should not be negative", provider)
+		}
+		quota[strings.ToLower(provider)] = n
+	}
+	c.ProviderQuota = quota
+
+	var candidates [][]placementCandidate
+	var sizes []int
+	pruned := false
+	names := map[string]bool{}
+	for _, sg := range req.SubGroups {
+		err := common.CheckString(sg.Name)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return emptyObj, err
+		}
+		if names[sg.Name] {
+			return emptyObj, fmt.Errorf("duplicated subGroup name: %s", sg.Name)
+		}
+		names[sg.Name] = true
+
+		size := 1
+		if sg.SubGroupSize != "" {
+			size, err = strconv.Atoi(sg.SubGroupSize)
+			if err != nil || size < 1 {
+				return emptyObj, fmt.Errorf("invalid subGroupSize of subGroup %s: '%s'", sg.Name, sg.SubGroupSize)
+			}
+		}
+
+		sgCandidates, sgPruned, err := getPlacementCandidates(nsId, sg, c, size)
+		if err != nil {
+			return emptyObj, fmt.Errorf("failed to get candidates of subGroup %s: %w", sg.Name, err)
+		}
+		if len(sgCandidates) == 0 {
+			return emptyObj, fmt.Errorf("no spec satisfies the requirements of subGroup %s", sg.Name)
+		}
+		candidates = append(candidates, sgCandidates)
+		sizes = append(sizes, size)
+		pruned = pruned || sgPruned
+	}
+
+	s := newPlacementSearch(c, candidates, sizes)
+	s.search(0, 0, 0)
+
+	if s.best == nil {
+		if s.nodes >= placementMaxSearchNodes {
+			return emptyObj, fmt.Errorf("no placement found within the search limit (%d): narrow down the filters of subGroups", placementMaxSearchNodes)
+		}
+		if pruned {
+			return emptyObj, fmt.Errorf("no placement satisfies the constraints among the candidate specs (pruned to %d for each subGroup): narrow down the filters of subGroups", placementMaxCandidates)
+		}
+		return emptyObj, fmt.Errorf("no placement satisfies the constraints")
+	}
+
+	result := McisPlacementResult{
+		Score:            s.bestScore / s.weightSum,
+		TotalCostPerHour: s.bestCost,
+		Optimal:          s.nodes < placementMaxSearchNodes && !pruned,
+		McisDynamicReq: TbMcisDynamicReq{
+			Name:            req.Name,
+			InstallMonAgent: req.InstallMonAgent,
+			Label:           req.Label,
+			Description:     req.Description,
+		},
+	}
+	providers := map[string]bool{}
+	for i, candIndex := range s.best {
+		cand := s.candidates[i][candIndex]
+		sg := req.SubGroups[i]
+		result.Placement = append(result.Placement, SubGroupPlacement{
+			SubGroupName:   sg.Name,
+			SubGroupSize:   s.sizes[i],
+			SpecId:         cand.spec.Id,
+			ProviderName:   cand.spec.ProviderName,
+			RegionName:     cand.spec.RegionName,
+			ConnectionName: cand.spec.ConnectionName,
+			CostPerHour:    float64(cand.spec.CostPerHour),
+			Score:          cand.score,
+		})
+		result.McisDynamicReq.Vm = append(result.McisDynamicReq.Vm, TbVmDynamicReq{
+			Name:         sg.Name,
+			SubGroupSize: strconv.Itoa(s.sizes[i]),
+			Label:        sg.Label,
+			Description:  sg.Description,
+			CommonSpec:   cand.spec.Id,
+			CommonImage:  sg.CommonImage,
+			RootDiskType: sg.RootDiskType,
+			RootDiskSize: sg.RootDiskSize,
+		})
+		providers[strings.ToLower(cand.spec.ProviderName)] = true
+
+		for j := 0; j < i; j++ {
+			latency, _ := getRegionLatency(s.candidates[j][s.best[j]].regionKey, cand.regionKey)
+			result.MaxLatencyMs = math.Max(result.MaxLatencyMs, latency)
+		}
+	}
+	result.DistinctProviders = len(providers)
+
+	log.Info().Msgf("[Placement] %s: score %.3f, cost %.4f/h, %d nodes searched", req.Name, result.Score, result.TotalCostPerHour, s.nodes)
+	return result, nil
+}
+
+// newPlacementSearch is func to get the state of the placement search with the bounds of the candidates of subGroups
+func newPlacementSearch(c PlacementConstraint, candidates [][]placementCandidate, sizes []int) *placementSearch {
+	n := len(candidates)
+	s := &placementSearch{
+		constraint:        c,
+		sizes:             sizes,
+		candidates:        candidates,
+		maxRemainingScore: make([]float64, n+1),
+		minRemainingCost:  make([]float64, n+1),
+		selected:          make([]int, n),
+		providerCount:     map[string]int{},
+		bestScore:         -1,
+	}
+	for i := n - 1; i >= 0; i-- {
+		maxScore, minCost := 0.0, math.Inf(1)
+		for _, cand := range candidates[i] {
+			maxScore = math.Max(maxScore, cand.score)
+			minCost = math.Min(minCost, float64(cand.spec.CostPerHour))
+		}
+		s.maxRemainingScore[i] = s.maxRemainingScore[i+1] + maxScore*float64(sizes[i])
+		s.minRemainingCost[i] = s.minRemainingCost[i+1] + minCost*float64(sizes[i])
+		s.weightSum += float64(sizes[i])
+	}
+	return s
+}
+
+// search is func to assign candidates to subGroups from the index i in depth-first order with bounds
+func (s *placementSearch) search(i int, score float64, cost float64) {
+	if s.nodes >= placementMaxSearchNodes {
+		return
+	}
+	s.nodes++
+
+	if i == len(s.candidates) {
+		if s.constraint.MinDistinctProviders > 0 && len(s.providerCount) < s.constraint.MinDistinctProviders {
+			return
+		}
+		if score > s.bestScore+1e-9 || (math.Abs(score-s.bestScore) <= 1e-9 && cost < s.bestCost) {
+			s.best = append([]int{}, s.selected...)
+			s.bestScore = score
+			s.bestCost = cost
+		}
+		return
+	}
+
+	// bound: the best possible score from here cannot beat the best found
+	upper := score + s.maxRemainingScore[i]
+	if upper < s.bestScore-1e-9 {
+		return
+	}
+	// bound: not enough subGroups left to reach the minimum number of distinct providers
+	if s.constraint.MinDistinctProviders > 0 && len(s.providerCount)+len(s.candidates)-i < s.constraint.MinDistinctProviders {
+		return
+	}
+
+	size := s.sizes[i]
+	for candIndex, cand := range s.candidates[i] {
+		candCost := cost + float64(cand.spec.CostPerHour)*float64(size)
+		if s.constraint.MaxCostPerHour > 0 && candCost+s.minRemainingCost[i+1] > s.constraint.MaxCostPerHour+1e-9 {
+			continue
+		}
+		provider := strings.ToLower(cand.spec.ProviderName)
+		if quota, ok := s.constraint.ProviderQuota[provider]; ok && s.providerCount[provider]+size > quota {
+			continue
+		}
+		if !s.isLatencyAllowed(i, cand) {
+			continue
+		}
+
+		s.selected[i] = candIndex
+		s.providerCount[provider] += size
+		s.search(i+1, score+cand.score*float64(size), candCost)
+		s.providerCount[provider] -= size
+		if s.providerCount[provider] == 0 {
+			delete(s.providerCount, provider)
+		}
+	}
+}
+
+// isLatencyAllowed is func to check the latency between the candidate and the regions assigned to previous subGroups
+func (s *placementSearch) isLatencyAllowed(i int, cand placementCandidate) bool {
+	if s.constraint.MaxLatencyMs <= 0 {
+		return true
+	}
+	for j := 0; j < i; j++ {
+		latency, err := getRegionLatency(s.candidates[j][s.selected[j]].regionKey, cand.regionKey)
+		if err != nil || latency > s.constraint.MaxLatencyMs {
+			return false
+		}
+	}
+	return true
+}
+
+// getPlacementCandidates is func to get candidate specs of a subGroup in the order of preference.
+// It also returns whether specs were pruned in a way that can miss the optimal placement.
+func getPlacementCandidates(nsId string, sg PlacementSubGroupReq, c PlacementConstraint, size int) ([]placementCandidate, bool, error) {
+
+	specList, err := filterSpecs(nsId, sg.Filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var allowed []mcir.TbSpecInfo
+	for _, spec := range specList {
+		if !isRegionAllowed(spec, c.AllowedRegions) || !isRegionAllowed(spec, sg.AllowedRegions) {
+			continue
+		}
+		if c.MaxCostPerHour > 0 && spec.CostPerHour < 0 {
+			// unknown cost cannot be checked against the budget
+			continue
+		}
+		if quota, ok := c.ProviderQuota[strings.ToLower(spec.ProviderName)]; ok && quota < size {
+			continue
+		}
+		allowed = append(allowed, spec)
+	}
+	if len(allowed) == 0 {
+		return nil, false, nil
+	}
+
+	policies := sg.Priority.Policy
+	if len(policies) == 0 {
+		policies = []PriorityCondition{{Metric: PriorityMetricCost}}
+	}
+	ranked, err := rankSpecs(allowed, policies)
+	if err != nil {
+		return nil, false, err
+	}
+	candidates, pruned := selectPlacementCandidates(ranked, c)
+	return candidates, pruned, nil
+}
+
+// selectPlacementCandidates is func to select candidates among ranked specs to limit the search space.
+// The constraints except the budget depend only on regions and providers, so only the best spec in each region
+// is kept (and the cheapest one if it differs under a budget). If there are still too many candidates,
+// the best (and the cheapest under a budget) of each provider are kept first for the diversity of providers.
+// It returns whether specs were pruned in a way that can miss the optimal placement.
+func selectPlacementCandidates(ranked []SpecRecommendInfo, c PlacementConstraint) ([]placementCandidate, bool) {
+
+	type regionBest struct {
+		best     int
+		cheapest int
+	}
+	regions := map[string]*regionBest{}
+	var regionOrder []string
+	for i, spec := range ranked {
+		regionKey := strings.ToLower(spec.ProviderName + "-" + spec.RegionName)
+		r, ok := regions[regionKey]
+		if !ok {
+			regions[regionKey] = &regionBest{best: i, cheapest: i}
+			regionOrder = append(regionOrder, regionKey)
+			continue
+		}
+		if spec.CostPerHour < ranked[r.cheapest].CostPerHour {
+			r.cheapest = i
+		}
+	}
+
+	var indexes []int
+	for _, regionKey := range regionOrder {
+		r := regions[regionKey]
+		indexes = append(indexes, r.best)
+		if r.cheapest != r.best && c.MaxCostPerHour > 0 {
+			indexes = append(indexes, r.cheapest)
+		}
+	}
+	sort.Ints(indexes)
+	// specs other than the best in each region can be better only under a budget
+	pruned := c.MaxCostPerHour > 0 && len(indexes) < len(ranked)
+
+	if len(indexes) > placementMaxCandidates {
+		pruned = true
+		type providerBest struct {
+			best     int
+			cheapest int
+		}
+		providers := map[string]*providerBest{}
+		for _, i := range indexes {
+			provider := strings.ToLower(ranked[i].ProviderName)
+			p, ok := providers[provider]
+			if !ok {
+				providers[provider] = &providerBest{best: i, cheapest: i}
+				continue
+			}
+			if ranked[i].CostPerHour < ranked[p.cheapest].CostPerHour {
+				p.cheapest = i
+			}
+		}
+		kept := map[int]bool{}
+		for _, p := range providers {
+			kept[p.best] = true
+			if c.MaxCostPerHour > 0 {
+				kept[p.cheapest] = true
+			}
+		}
+		for _, i := range indexes {
+			if len(kept) >= placementMaxCandidates {
+				break
+			}
+			kept[i] = true
+		}
+		indexes = indexes[:0]
+		for i := range kept {
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+	}
+
+	candidates := make([]placementCandidate, 0, len(indexes))
+	for _, i := range indexes {
+		candidates = append(candidates, placementCandidate{
+			spec:      ranked[i].TbSpecInfo,
+			regionKey: strings.ToLower(ranked[i].ProviderName + "-" + ranked[i].RegionName),
+			score:     ranked[i].TotalScore,
+		})
+	}
+	return candidates, pruned
+}
+
+// isRegionAllowed is func to check whether the region of a spec is in the allowed regions (all regions if empty)
+func isRegionAllowed(spec mcir.TbSpecInfo, allowedRegions []string) bool {
+	if len(allowedRegions) == 0 {
+		return true
+	}
+	for _, region := range allowedRegions {
+		region = strings.ToLower(strings.TrimSpace(region))
+		if region == strings.ToLower(spec.ProviderName+"+"+spec.RegionName) || region == strings.ToLower(spec.RegionName) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRegionLatency is func to get the latency between two regions ({providerName}-{regionName}) from the latency store
+func getRegionLatency(src string, dest string) (float64, error) {
+	if src == dest {
+		return 0, nil
+	}
+	info, err := GetLatencyInfo(src, dest)
+	if err != nil {
+		return 0, err
+	}
+	return info.LatencyMs, nil
+}
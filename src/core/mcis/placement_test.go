@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPlacementCandidate is func to get a placement candidate for tests
+func newTestPlacementCandidate(provider string, region string, cost float32, score float64) placementCandidate {
+	return placementCandidate{
+		spec: mcir.TbSpecInfo{
+			Id:           provider + "+" + region,
+			ProviderName: provider,
+			RegionName:   region,
+			CostPerHour:  cost,
+		},
+		regionKey: provider + "-" + region,
+		score:     score,
+	}
+}
+
+func TestPlacementSearch(t *testing.T) {
+	awsA := newTestPlacementCandidate("aws", "a", 1.0, 1.0)
+	awsB := newTestPlacementCandidate("aws", "b", 0.2, 0.5)
+	gcpA := newTestPlacementCandidate("gcp", "a", 0.8, 0.8)
+	gcpB := newTestPlacementCandidate("gcp", "b", 0.1, 0.3)
+
+	tests := []struct {
+		name       string
+		constraint PlacementConstraint
+		candidates [][]placementCandidate
+		sizes      []int
+		// expected indexes of candidates for subGroups (nil if infeasible)
+		want     []int
+		wantCost float64
+	}{
+		{
+			name:       "no constraint",
+			candidates: [][]placementCandidate{{awsA, gcpA}, {awsA, gcpA}},
+			sizes:      []int{1, 1},
+			want:       []int{0, 0},
+			wantCost:   2.0,
+		},
+		{
+			name:       "budget",
+			constraint: PlacementConstraint{MaxCostPerHour: 1.5},
+			candidates: [][]placementCandidate{{awsA, awsB}, {awsA, awsB}},
+			sizes:      []int{1, 1},
+			want:       []int{0, 1},
+			wantCost:   1.2,
+		},
+		{
+			name:       "budget weighted by subGroup size",
+			constraint: PlacementConstraint{MaxCostPerHour: 1.5},
+			candidates: [][]placementCandidate{{awsA, awsB}, {awsA, awsB}},
+			sizes:      []int{3, 1},
+			want:       []int{1, 1},
+			wantCost:   0.8,
+		},
+		{
+			name:       "budget infeasible",
+			constraint: PlacementConstraint{MaxCostPerHour: 0.3},
+			candidates: [][]placementCandidate{{awsA, awsB}, {awsA, awsB}},
+			sizes:      []int{1, 1},
+			want:       nil,
+		},
+		{
+			name:       "diversity",
+			constraint: PlacementConstraint{MinDistinctProviders: 2},
+			candidates: [][]placementCandidate{{awsA, gcpA}, {awsA, gcpA}},
+			sizes:      []int{1, 1},
+			want:       []int{0, 1},
+			wantCost:   1.8,
+		},
+		{
+			name:       "diversity prefers the larger subGroup for the best score",
+			constraint: PlacementConstraint{MinDistinctProviders: 2},
+			candidates: [][]placementCandidate{{awsA, gcpA}, {awsA, gcpA}},
+			sizes:      []int{1, 3},
+			want:       []int{1, 0},
+			wantCost:   3.8,
+		},
+		{
+			name:       "diversity with budget",
+			constraint: PlacementConstraint{MinDistinctProviders: 2, MaxCostPerHour: 1.05},
+			candidates: [][]placementCandidate{{awsA, awsB, gcpA, gcpB}, {awsA, awsB, gcpA, gcpB}},
+			sizes:      []int{1, 1},
+			want:       []int{1, 2},
+			wantCost:   1.0,
+		},
+		{
+			name:       "diversity infeasible",
+			constraint: PlacementConstraint{MinDistinctProviders: 2},
+			candidates: [][]placementCandidate{{awsA, awsB}, {awsA, awsB}},
+			sizes:      []int{1, 1},
+			want:       nil,
+		},
+		{
+			name:       "diversity infeasible with fewer subGroups than providers",
+			constraint: PlacementConstraint{MinDistinctProviders: 3},
+			candidates: [][]placementCandidate{{awsA, gcpA}, {awsA, gcpA}},
+			sizes:      []int{1, 1},
+			want:       nil,
+		},
+		{
+			name:       "provider quota",
+			constraint: PlacementConstraint{ProviderQuota: map[string]int{"aws": 2}},
+			candidates: [][]placementCandidate{{awsA, gcpA}, {awsA, gcpA}},
+			sizes:      []int{2, 2},
+			want:       []int{0, 1},
+			wantCost:   3.6,
+		},
+		{
+			name:       "lower cost wins the tie",
+			candidates: [][]placementCandidate{{awsA, newTestPlacementCandidate("gcp", "c", 0.5, 1.0)}},
+			sizes:      []int{1},
+			want:       []int{1},
+			wantCost:   0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPlacementSearch(tt.constraint, tt.candidates, tt.sizes)
+			s.search(0, 0, 0)
+			assert.Equal(t, tt.want, s.best)
+			if tt.want != nil {
+				assert.InDelta(t, tt.wantCost, s.bestCost, 1e-6)
+			}
+			assert.Less(t, s.nodes, placementMaxSearchNodes)
+		})
+	}
+}
+
+// newTestRankedSpec is func to get a ranked spec for tests
+func newTestRankedSpec(provider string, region string, name string, cost float32, score float64) SpecRecommendInfo {
+	spec := SpecRecommendInfo{TotalScore: score}
+	spec.TbSpecInfo = mcir.TbSpecInfo{
+		Id:           provider + "+" + region + "+" + name,
+		ProviderName: provider,
+		RegionName:   region,
+		CostPerHour:  cost,
+	}
+	return spec
+}
+
+func TestSelectPlacementCandidates(t *testing.T) {
+	// two specs in a region: the best one and a cheaper one
+	ranked := []SpecRecommendInfo{
+		newTestRankedSpec("aws", "a", "large", 1.0, 1.0),
+		newTestRankedSpec("aws", "a", "small", 0.1, 0.5),
+	}
+
+	candidates, pruned := selectPlacementCandidates(ranked, PlacementConstraint{})
+	assert.Len(t, candidates, 1)
+	assert.False(t, pruned, "only the best spec in a region matters without a budget")
+
+	candidates, pruned = selectPlacementCandidates(ranked, PlacementConstraint{MaxCostPerHour: 1})
+	assert.Len(t, candidates, 2)
+	assert.False(t, pruned)
+
+	ranked = append(ranked, newTestRankedSpec("aws", "a", "medium", 0.5, 0.4))
+	_, pruned = selectPlacementCandidates(ranked, PlacementConstraint{MaxCostPerHour: 1})
+	assert.True(t, pruned, "a spec between the best and the cheapest is pruned under a budget")
+
+	// more regions of a provider than the limit, and another provider ranked last
+	ranked = nil
+	for i := 0; i < placementMaxCandidates+10; i++ {
+		ranked = append(ranked, newTestRankedSpec("aws", fmt.Sprintf("region%02d", i), "large", 1.0, 1.0-float64(i)*0.01))
+	}
+	ranked = append(ranked, newTestRankedSpec("gcp", "a", "large", 2.0, 0.1))
+
+	candidates, pruned = selectPlacementCandidates(ranked, PlacementConstraint{})
+	assert.True(t, pruned)
+	assert.Len(t, candidates, placementMaxCandidates)
+	providers := map[string]bool{}
+	for _, cand := range candidates {
+		providers[cand.spec.ProviderName] = true
+	}
+	assert.True(t, providers["gcp"], "the best of each provider should be kept for the diversity of providers")
+	assert.Equal(t, "aws+region00+large", candidates[0].spec.Id, "candidates should be in the order of preference")
+}
@@ -128,6 +128,28 @@ func TbMcisReqStructLevelValidation(sl validator.StructLevel) {
 	}
 }
 
+// checkVmMarketOption is func to check the purchase option of a VM
+func checkVmMarketOption(market VmMarketOption) error {
+	switch market.MarketType {
+	case "", MarketTypeOnDemand:
+		if market.SpotMaxPrice != 0 || (market.SpotInterruptionAction != "" && market.SpotInterruptionAction != SpotInterruptionActionNone) {
+			return fmt.Errorf("spotMaxPrice and spotInterruptionAction are only for marketType '%s'", MarketTypeSpot)
+		}
+	case MarketTypeSpot:
+		if market.SpotMaxPrice < 0 {
+			return fmt.Errorf("spotMaxPrice should not be negative: %v", market.SpotMaxPrice)
+		}
+		switch market.SpotInterruptionAction {
+		case "", SpotInterruptionActionNone, SpotInterruptionActionReplace:
+		default:
+			return fmt.Errorf("invalid spotInterruptionAction: '%s' (should be one of [%s, %s])", market.SpotInterruptionAction, SpotInterruptionActionNone, SpotInterruptionActionReplace)
+		}
+	default:
+		return fmt.Errorf("invalid marketType: '%s' (should be one of [%s, %s])", market.MarketType, MarketTypeOnDemand, MarketTypeSpot)
+	}
+	return nil
+}
+
 // TbMcisInfo is struct for MCIS info
 type TbMcisInfo struct {
 	Id           string          `json:"id"`
@@ -188,6 +210,40 @@ type TbVmReq struct {
 	RootDiskType     string   `json:"rootDiskType,omitempty" example:"default, TYPE1, ..."`  // "", "default", "TYPE1", AWS: ["standard", "gp2", "gp3"], Azure: ["PremiumSSD", "StandardSSD", "StandardHDD"], GCP: ["pd-standard", "pd-balanced", "pd-ssd", "pd-extreme"], ALIBABA: ["cloud_efficiency", "cloud", "cloud_ssd"], TENCENT: ["CLOUD_PREMIUM", "CLOUD_SSD"]
 	RootDiskSize     string   `json:"rootDiskSize,omitempty" example:"default, 30, 42, ..."` // "default", Integer (GB): ["50", ..., "1000"]
 	DataDiskIds      []string `json:"dataDiskIds"`
+
+	// Market is the purchase option of the VM (on-demand if omitted)
+	Market VmMarketOption `json:"market,omitempty"`
+}
+
+const (
+	MarketTypeOnDemand string = "on-demand"
+	MarketTypeSpot     string = "spot"
+)
+
+const (
+	// SpotInterruptionActionNone only marks the interrupted spot VM
+	SpotInterruptionActionNone string = "none"
+	// SpotInterruptionActionReplace adds a new VM to the subGroup of the interrupted spot VM (scale-out)
+	SpotInterruptionActionReplace string = "replace"
+)
+
+// VmMarketOption is struct for the purchase option of a VM (on-demand or spot)
+type VmMarketOption struct {
+	MarketType string `json:"marketType,omitempty" example:"spot" enums:"on-demand,spot" default:"on-demand"`
+	// SpotMaxPrice is the maximum price per hour for a spot VM (0 means up to the on-demand price)
+	SpotMaxPrice float32 `json:"spotMaxPrice,omitempty" example:"0.05"`
+	// SpotInterruptionAction is the action when the spot VM is interrupted by the CSP (default: none)
+	SpotInterruptionAction string `json:"spotInterruptionAction,omitempty" example:"replace" enums:"none,replace" default:"none"`
+}
+
+// SpotInterruptionInfo is struct for the interruption of a spot VM
+type SpotInterruptionInfo struct {
+	DetectedAt string `json:"detectedAt" example:"2024-01-01T00:00:00Z"`
+	// Status is the status of the VM when the interruption is detected
+	Status string `json:"status" example:"Terminated"`
+	// ReplacementVmId is the ID of the VM added to replace the interrupted VM
+	ReplacementVmId string `json:"replacementVmId,omitempty" example:"g1-4"`
+	Message         string `json:"message,omitempty" example:"Failed to replace because ..."`
 }
 
 // TbVmReq is struct to get requirements to create a new server instance
@@ -240,6 +296,9 @@ type TbVmDynamicReq struct {
 	// if ConnectionName is given, the VM tries to use associtated credential.
 	// if not, it will use predefined ConnectionName in Spec objects
 	ConnectionName string `json:"connectionName,omitempty" default:""`
+
+	// Market is the purchase option of the VM (on-demand if omitted)
+	Market VmMarketOption `json:"market,omitempty"`
 }
 
 // McisConnectionConfigCandidatesReq is struct for a request to check requirements to create a new MCIS instance dynamically (with default resource option)
@@ -306,6 +365,10 @@ type SpiderVMInfo struct {
 	RootDiskSize string // "default", "50", "1000" (GB)
 	ImageType    SpiderImageType
 
+	// Fields for request (spot market, requires a CB-Spider driver supporting spot VMs)
+	MarketType   string `json:",omitempty"` // "on-demand", "spot"
+	SpotMaxPrice string `json:",omitempty"` // max price per hour, "" means up to the on-demand price
+
 	// Fields for response
 	IId               common.IID // {NameId, SystemId}
 	ImageIId          common.IID
@@ -336,6 +399,11 @@ func TbVmReqStructLevelValidation(sl validator.StructLevel) {
 		// ReportError(field interface{}, fieldName, structFieldName, tag, param string)
 		sl.ReportError(u.Name, "name", "Name", err.Error(), "")
 	}
+
+	err = checkVmMarketOption(u.Market)
+	if err != nil {
+		sl.ReportError(u.Market, "market", "Market", err.Error(), "")
+	}
 }
 
 // TbSubGroupInfo is struct to define an object that includes homogeneous VMs
@@ -363,7 +431,7 @@ type TbVmInfo struct {
 	TargetAction string `json:"targetAction"`
 
 	// Montoring agent status
-	MonAgentStatus string `json:"monAgentStatus" example:"[installed, notInstalled, failed]"` // yes or no// installed, notInstalled, failed
+	MonAgentStatus string `json:"monAgentStatus" example:"[installed, notInstalled, installing, upgrading, uninstalling, unhealthy, unknown, failed]"` // installed, notInstalled, installing, upgrading, uninstalling, unhealthy, unknown, failed
 
 	// NetworkAgent status
 	NetworkAgentStatus string `json:"networkAgentStatus" example:"[notInstalled, installing, installed, failed]"` // notInstalled, installing, installed, failed
@@ -399,6 +467,14 @@ type TbVmInfo struct {
 	VmUserAccount    string            `json:"vmUserAccount,omitempty"`
 	VmUserPassword   string            `json:"vmUserPassword,omitempty"`
 
+	// SSH host key of the VM recorded on the first connection (trust-on-first-use)
+	SshHostKeyInfo SshHostKeyInfo `json:"sshHostKeyInfo,omitempty"`
+
+	// Market is the purchase option of the VM
+	Market VmMarketOption `json:"market,omitempty"`
+	// SpotInterruption is the interruption of the spot VM detected by CB-Tumblebug
+	SpotInterruption *SpotInterruptionInfo `json:"spotInterruption,omitempty"`
+
 	CspViewVmDetail SpiderVMInfo `json:"cspViewVmDetail,omitempty"`
 }
 
@@ -625,6 +701,7 @@ func ScaleOutMcisSubGroup(nsId string, mcisId string, subGroupId string, numVMsT
 	vmTemplate.VmUserPassword = vmObj.VmUserPassword
 	vmTemplate.RootDiskType = vmObj.RootDiskType
 	vmTemplate.RootDiskSize = vmObj.RootDiskSize
+	vmTemplate.Market = vmObj.Market
 	vmTemplate.Description = vmObj.Description
 
 	vmTemplate.SubGroupSize = numVMsToAdd
@@ -820,6 +897,8 @@ func CreateMcisGroupVm(nsId string, mcisId string, vmRequest *TbVmReq, newSubGro
 		vmInfoData.VmUserAccount = vmRequest.VmUserAccount
 		vmInfoData.VmUserPassword = vmRequest.VmUserPassword
 
+		vmInfoData.Market = vmRequest.Market
+
 		wg.Add(1)
 		// option != register
 		go AddVmToMcis(&wg, nsId, mcisId, &vmInfoData, "")
@@ -847,30 +926,8 @@ func CreateMcisGroupVm(nsId string, mcisId string, vmRequest *TbVmReq, newSubGro
 	UpdateMcisInfo(nsId, mcisTmp)
 
 	// Install CB-Dragonfly monitoring agent
-
-	if !strings.Contains(mcisTmp.InstallMonAgent, "no") {
-
-		// Sleep for 60 seconds for a safe DF agent installation.
-		fmt.Printf("\n\n[Info] Sleep for 60 seconds for safe CB-Dragonfly Agent installation.\n\n")
-		time.Sleep(60 * time.Second)
-
-		check := CheckDragonflyEndpoint()
-		if check != nil {
-			fmt.Printf("\n\n[Warning] CB-Dragonfly is not available\n\n")
-		} else {
-			reqToMon := &McisCmdReq{}
-			reqToMon.UserName = "cb-user" // this MCIS user name is temporal code. Need to improve.
-
-			fmt.Printf("\n[InstallMonitorAgentToMcis]\n\n")
-			content, err := InstallMonitorAgentToMcis(nsId, mcisId, common.StrMCIS, reqToMon)
-			if err != nil {
-				log.Error().Err(err).Msg("")
-				//mcisTmp.InstallMonAgent = "no"
-			}
-			common.PrintJsonPretty(content)
-			//mcisTmp.InstallMonAgent = "yes"
-		}
-	}
+	// Agents for the added VMs (MonAgentStatus: notInstalled) will be installed by MonitoringAgentController
+	// if InstallMonAgent of the MCIS is not "no". (no need to block the scale-out request)
 
 	vmList, err := ListVmBySubGroup(nsId, mcisId, tentativeVmId)
 
@@ -956,6 +1013,11 @@ func CreateMcis(nsId string, req *TbMcisReq, option string) (*TbMcisInfo, error)
 			log.Error().Err(err).Msg("")
 			return &TbMcisInfo{}, err
 		}
+		err = checkVmMarketOption(k.Market)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return &TbMcisInfo{}, err
+		}
 	}
 
 	// hold option will hold the MCIS creation process until the user releases it.
@@ -1062,6 +1124,7 @@ func CreateMcis(nsId string, req *TbMcisReq, option string) (*TbMcisInfo, error)
 			vmInfoData.VmUserPassword = k.VmUserPassword
 			vmInfoData.RootDiskType = k.RootDiskType
 			vmInfoData.RootDiskSize = k.RootDiskSize
+			vmInfoData.Market = k.Market
 
 			vmInfoData.Label = k.Label
 
@@ -1542,6 +1605,7 @@ func getVmReqFromDynamicReq(reqID string, nsId string, req *TbVmDynamicReq) (*Tb
 	vmReq.RootDiskType = k.RootDiskType
 	vmReq.RootDiskSize = k.RootDiskSize
 	vmReq.VmUserPassword = k.VmUserPassword
+	vmReq.Market = k.Market
 
 	common.PrintJsonPretty(vmReq)
 	common.UpdateRequestProgress(reqID, common.ProgressInfo{Title: "Prepared resources for VM:" + vmReq.Name, Info: vmReq, Time: time.Now()})
@@ -1616,7 +1680,7 @@ func AddVmToMcis(wg *sync.WaitGroup, nsId string, mcisId string, vmInfoData *TbV
 	vmInfoData.Status = vmStatusInfoTmp.Status
 
 	// Monitoring Agent Installation Status (init: notInstalled)
-	vmInfoData.MonAgentStatus = "notInstalled"
+	vmInfoData.MonAgentStatus = MonAgentStatusNotInstalled
 	vmInfoData.NetworkAgentStatus = "notInstalled"
 
 	// set CreatedTime
@@ -1691,6 +1755,14 @@ func CreateVm(nsId string, mcisId string, vmInfoData *TbVmInfo, option string) e
 	requestBody.ReqInfo.RootDiskType = vmInfoData.RootDiskType
 	requestBody.ReqInfo.RootDiskSize = vmInfoData.RootDiskSize
 
+	// request a spot VM with the max price (on-demand is the default of CSPs)
+	if option != "register" && vmInfoData.Market.MarketType == MarketTypeSpot {
+		requestBody.ReqInfo.MarketType = MarketTypeSpot
+		if vmInfoData.Market.SpotMaxPrice > 0 {
+			requestBody.ReqInfo.SpotMaxPrice = strconv.FormatFloat(float64(vmInfoData.Market.SpotMaxPrice), 'f', -1, 32)
+		}
+	}
+
 	if option == "register" {
 		requestBody.ReqInfo.CSPid = vmInfoData.IdByCSP
 
@@ -1813,8 +1885,14 @@ func CreateVm(nsId string, mcisId string, vmInfoData *TbVmInfo, option string) e
 
 	if err != nil {
 		log.Error().Err(err).Msg("Spider returned an error")
+		if option != "register" {
+			recordProvisioningResult(nsId, mcisId, vmInfoData.Id, vmInfoData.ConnectionName, requestBody.ReqInfo.VMSpecName, vmInfoData.SpecId, err)
+		}
 		return err
 	}
+	if option != "register" {
+		recordProvisioningResult(nsId, mcisId, vmInfoData.Id, vmInfoData.ConnectionName, requestBody.ReqInfo.VMSpecName, vmInfoData.SpecId, nil)
+	}
 
 	vmInfoData.CspViewVmDetail = callResult
 	vmInfoData.VmUserAccount = callResult.VMUserId
@@ -1834,6 +1912,21 @@ func CreateVm(nsId string, mcisId string, vmInfoData *TbVmInfo, option string) e
 	vmInfoData.RootDeviceName = callResult.RootDeviceName
 	//configTmp, _ := common.GetConnConfig(vmInfoData.ConnectionName)
 
+	// the market of the VM is what was actually created (a spot request is ignored by connections without spot support)
+	if callResult.MarketType == MarketTypeSpot {
+		vmInfoData.Market.MarketType = MarketTypeSpot
+		if price, err := strconv.ParseFloat(callResult.SpotMaxPrice, 32); err == nil {
+			vmInfoData.Market.SpotMaxPrice = float32(price)
+		}
+	} else {
+		if vmInfoData.Market.MarketType == MarketTypeSpot {
+			msg := fmt.Sprintf("The VM %s is created as %s (spot is not supported by the connection %s)", vmInfoData.Name, MarketTypeOnDemand, vmInfoData.ConnectionName)
+			log.Warn().Msg(msg)
+			vmInfoData.SystemMessage = msg
+		}
+		vmInfoData.Market = VmMarketOption{MarketType: MarketTypeOnDemand}
+	}
+
 	if option == "register" {
 
 		// Reconstuct resource IDs
@@ -23,7 +23,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 	"unicode"
 
@@ -48,7 +47,7 @@ type FilterInfo struct {
 
 // FilterCondition is struct for .
 type FilterCondition struct {
-	Metric    string      `json:"metric" example:"vCPU" enums:"vCPU,memoryGiB,costPerHour"`
+	Metric    string      `json:"metric" example:"vCPU" enums:"vCPU,memoryGiB,costPerHour,availabilityScore"`
 	Condition []Operation `json:"condition"`
 }
 
@@ -65,15 +64,76 @@ type PriorityInfo struct {
 
 // FilterCondition is struct for .
 type PriorityCondition struct {
-	Metric    string            `json:"metric" example:"location" enums:"location,cost,random,performance,latency"`
-	Weight    string            `json:"weight" example:"0.3" enums:"0.1,0.2,..."`
+	Metric    string            `json:"metric" example:"location" enums:"location,cost,random,performance,latency,availability,carbon"`
+	Weight    string            `json:"weight" example:"0.3" enums:"0.1,0.2,..."` // relative weight of the metric (1 if omitted)
 	Parameter []ParameterKeyVal `json:"parameter,omitempty"`
 }
 
 // Operation is struct for .
 type ParameterKeyVal struct {
-	Key string   `json:"key" example:"coordinateClose" enums:"coordinateClose,coordinateWithin,coordinateFair"` // coordinate
-	Val []string `json:"val" example:"44.146838/-116.411403"`                                                   // ["Latitude,Longitude","12,543",..,"31,433"]
+	Key string   `json:"key" example:"coordinateClose" enums:"coordinateClose,coordinateWithin,coordinateFair,market"` // coordinate, market (for cost: on-demand or spot)
+	Val []string `json:"val" example:"44.146838/-116.411403"`                                                          // ["Latitude,Longitude","12,543",..,"31,433"]
+}
+
+// CriterionScore is struct for the score of a spec in a priority criterion
+type CriterionScore struct {
+	Metric string  `json:"metric" example:"cost"`
+	Weight float64 `json:"weight" example:"0.3"`
+	// Value is the evaluated value of the spec in the criterion (e.g., cost per hour, distance in km, sum of latencies in ms, gCO2eq per hour)
+	Value float64 `json:"value" example:"0.0116"`
+	// NormalizedScore is the score normalized into [0, 1] among the filtered specs (1 is the best)
+	NormalizedScore float64 `json:"normalizedScore" example:"0.95"`
+	// WeightedScore is NormalizedScore * Weight
+	WeightedScore float64 `json:"weightedScore" example:"0.285"`
+}
+
+// SpecRecommendInfo is struct for a recommended spec with the scores of the priority criteria
+type SpecRecommendInfo struct {
+	mcir.TbSpecInfo
+	// TotalScore is the weighted average of the normalized scores (1 is the best)
+	TotalScore float64          `json:"totalScore" example:"0.87"`
+	Scores     []CriterionScore `json:"scores"`
+}
+
+const (
+	PriorityMetricLocation    string = "location"
+	PriorityMetricCost        string = "cost"
+	PriorityMetricRandom      string = "random"
+	PriorityMetricPerformance string = "performance"
+	PriorityMetricLatency     string = "latency"
+	// PriorityMetricAvailability is for the availability score of specs from recent provisioning results
+	PriorityMetricAvailability string = "availability"
+	// PriorityMetricCarbon is for the estimated carbon emission per hour (carbon intensity of the region x power of the spec)
+	PriorityMetricCarbon string = "carbon"
+)
+
+// FilterMetricAvailabilityScore is the filter metric for the availability score of specs from recent provisioning results.
+// Specs with a score lower than specAvailabilityThreshold are filtered out if it is not given.
+const FilterMetricAvailabilityScore string = "availabilityScore"
+
+// priorityEvaluator is func type to evaluate specs in a priority criterion.
+// It returns a value for each spec (NaN if not available) and whether a lower value is better.
+type priorityEvaluator func(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error)
+
+// priorityEvaluators is the map of priority metrics and their evaluators
+var priorityEvaluators = map[string]priorityEvaluator{
+	PriorityMetricLocation:     evaluateSpecsByLocation,
+	PriorityMetricCost:         evaluateSpecsByCost,
+	PriorityMetricRandom:       evaluateSpecsByRandom,
+	PriorityMetricPerformance:  evaluateSpecsByPerformance,
+	PriorityMetricLatency:      evaluateSpecsByLatency,
+	PriorityMetricAvailability: evaluateSpecsByAvailability,
+	PriorityMetricCarbon:       evaluateSpecsByCarbon,
+}
+
+// priorityMetricList is func to get the sorted list of available priority metrics
+func priorityMetricList() []string {
+	metrics := make([]string, 0, len(priorityEvaluators))
+	for metric := range priorityEvaluators {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	return metrics
 }
 
 // toUpperFirst converts the first letter of a string to uppercase
@@ -91,6 +151,10 @@ func applyFilterPolicies(request *mcir.FilterSpecsByRangeRequest, plan *Deployme
 	val := reflect.ValueOf(request).Elem()
 
 	for _, policy := range plan.Filter.Policy {
+		if policy.Metric == FilterMetricAvailabilityScore {
+			// not a field of spec (see filterSpecs)
+			continue
+		}
 		for _, condition := range policy.Condition {
 			fieldName := toUpperFirst(policy.Metric) // Correctly capitalize the first letter
 			field := val.FieldByName(fieldName)
@@ -138,401 +202,292 @@ func applyRange(field reflect.Value, operator string, operand float32) error {
 	return nil
 }
 
-// RecommendVm is func to recommend a VM
-func RecommendVm(nsId string, plan DeploymentPlan) ([]mcir.TbSpecInfo, error) {
-	// Filtering first
-
-	u := &mcir.FilterSpecsByRangeRequest{}
-	// Apply filter policies dynamically.
-	if err := applyFilterPolicies(u, &plan); err != nil {
-		log.Error().Err(err).Msg("Failed to apply filter policies")
-		return nil, err
-	}
-
-	// veryLargeValue := float32(math.MaxFloat32)
-	// verySmallValue := float32(0)
-
+// RecommendVm is func to recommend a VM.
+// Specs are filtered by plan.Filter, and ranked by the weighted sum of normalized scores of plan.Priority policies.
+func RecommendVm(nsId string, plan DeploymentPlan) ([]SpecRecommendInfo, error) {
 	// Filtering
 	log.Debug().Msg("[Filtering specs]")
 
-	filteredSpecs, err := mcir.FilterSpecsByRange(nsId, *u)
+	filteredSpecs, err := filterSpecs(nsId, plan.Filter)
 
 	if err != nil {
 		log.Error().Err(err).Msg("")
-		return []mcir.TbSpecInfo{}, err
+		return []SpecRecommendInfo{}, err
 	}
 	if len(filteredSpecs) == 0 {
-		return []mcir.TbSpecInfo{}, nil
+		return []SpecRecommendInfo{}, nil
 	}
 
 	// Prioritizing
 	log.Debug().Msg("[Prioritizing specs]")
-	prioritySpecs := []mcir.TbSpecInfo{}
-
-	for _, v := range plan.Priority.Policy {
-		metric := v.Metric
-
-		switch metric {
-		case "location":
-			prioritySpecs, err = RecommendVmLocation(nsId, &filteredSpecs, &v.Parameter)
-		case "performance":
-			prioritySpecs, err = RecommendVmPerformance(nsId, &filteredSpecs)
-		case "cost":
-			prioritySpecs, err = RecommendVmCost(nsId, &filteredSpecs)
-		case "random":
-			prioritySpecs, err = RecommendVmRandom(nsId, &filteredSpecs)
-		case "latency":
-			prioritySpecs, err = RecommendVmLatency(nsId, &filteredSpecs, &v.Parameter)
-		default:
-			prioritySpecs, err = RecommendVmCost(nsId, &filteredSpecs)
-		}
-
+	policies := plan.Priority.Policy
+	if len(policies) == 0 {
+		policies = []PriorityCondition{{Metric: PriorityMetricCost}}
 	}
-	if plan.Priority.Policy == nil {
-		prioritySpecs, err = RecommendVmCost(nsId, &filteredSpecs)
+	prioritySpecs, err := rankSpecs(filteredSpecs, policies)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return []SpecRecommendInfo{}, err
 	}
 
 	// limit the number of items in result list
-	result := []mcir.TbSpecInfo{}
 	limitNum, err := strconv.Atoi(plan.Limit)
-	if err != nil {
-		limitNum = math.MaxInt
-	}
-	for i, v := range prioritySpecs {
-		result = append(result, v)
-		if i == (limitNum - 1) {
-			break
-		}
+	if err == nil && limitNum >= 0 && limitNum < len(prioritySpecs) {
+		prioritySpecs = prioritySpecs[:limitNum]
 	}
 
-	return result, nil
-
+	return prioritySpecs, nil
 }
 
-// RecommendVmLatency func prioritize specs by latency based on given MCIS (fair)
-func RecommendVmLatency(nsId string, specList *[]mcir.TbSpecInfo, param *[]ParameterKeyVal) ([]mcir.TbSpecInfo, error) {
-
-	result := []mcir.TbSpecInfo{}
+// filterSpecs is func to get specs satisfying the filter policies.
+// Specs recently unavailable (by provisioning failures) are filtered out by the availabilityScore policy or its default.
+func filterSpecs(nsId string, filter FilterInfo) ([]mcir.TbSpecInfo, error) {
 
-	for _, v := range *param {
-
-		switch v.Key {
-		case "latencyMinimal":
+	u := &mcir.FilterSpecsByRangeRequest{}
+	// Apply filter policies dynamically.
+	if err := applyFilterPolicies(u, &DeploymentPlan{Filter: filter}); err != nil {
+		log.Error().Err(err).Msg("Failed to apply filter policies")
+		return nil, err
+	}
 
-			// distance (in terms of latency)
-			type distanceType struct {
-				distance      float64
-				index         int
-				priorityIndex int
+	minAvailability, maxAvailability := float64(specAvailabilityThreshold), 1.0
+	for _, policy := range filter.Policy {
+		if policy.Metric != FilterMetricAvailabilityScore {
+			continue
+		}
+		minAvailability = 0
+		for _, condition := range policy.Condition {
+			operand, err := strconv.ParseFloat(condition.Operand, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operand of %s: '%s'", policy.Metric, condition.Operand)
+			}
+			switch condition.Operator {
+			case ">=":
+				minAvailability = operand
+			case "<=":
+				maxAvailability = operand
+			case "==":
+				minAvailability, maxAvailability = operand, operand
+			default:
+				return nil, fmt.Errorf("unsupported operator: %s", condition.Operator)
 			}
-			distances := []distanceType{}
+		}
+	}
 
-			// Evaluate
-			for i, k := range *specList {
-				sumLatancy := 0.0
-				for _, region := range v.Val {
-					l, _ := GetLatency(region, k.ProviderName+"-"+k.RegionName)
-					sumLatancy += l
-				}
+	specList, err := mcir.FilterSpecsByRange(nsId, *u)
+	if err != nil {
+		return nil, err
+	}
 
-				distances = append(distances, distanceType{})
-				distances[i].distance = sumLatancy
-				distances[i].index = i
-			}
+	now := time.Now()
+	filtered := specList[:0]
+	for _, spec := range specList {
+		score := getSpecAvailabilityScore(spec.ConnectionName, spec.CspSpecName, now)
+		if score < minAvailability || score > maxAvailability {
+			continue
+		}
+		filtered = append(filtered, spec)
+	}
+	return filtered, nil
+}
 
-			// Sort
-			sort.Slice(distances, func(i, j int) bool {
-				return (*specList)[i].CostPerHour < (*specList)[j].CostPerHour
-			})
-			sort.Slice(distances, func(i, j int) bool {
-				return distances[i].distance < distances[j].distance
-			})
-			//fmt.Printf("\n[Latency]\n %v \n", distances)
-
-			priorityCnt := 1
-			for i := range distances {
-
-				// priorityIndex++ if two distances are not equal (give the same priorityIndex if two variables are same)
-				if i != 0 {
-					if distances[i].distance > distances[i-1].distance {
-						priorityCnt++
-					}
-				}
-				distances[i].priorityIndex = priorityCnt
+// rankSpecs is func to rank specs by the weighted sum of normalized scores of the priority policies.
+// Ties are broken by lower cost, and then by spec ID.
+func rankSpecs(specList []mcir.TbSpecInfo, policies []PriorityCondition) ([]SpecRecommendInfo, error) {
 
-			}
+	result := make([]SpecRecommendInfo, len(specList))
+	for i := range specList {
+		result[i].TbSpecInfo = specList[i]
+	}
 
-			max := float32(distances[len(*specList)-1].distance)
-			min := float32(distances[0].distance)
+	weightSum := 0.0
+	for _, policy := range policies {
+		weight, err := parsePriorityWeight(policy.Weight)
+		if err != nil {
+			return nil, err
+		}
+		evaluator, ok := priorityEvaluators[policy.Metric]
+		if !ok {
+			return nil, fmt.Errorf("invalid priority metric: '%s' (available: %s)", policy.Metric, strings.Join(priorityMetricList(), ", "))
+		}
+		values, lowerIsBetter, err := evaluator(specList, policy.Parameter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate specs by %s: %w", policy.Metric, err)
+		}
 
-			for i := range *specList {
-				// update OrderInFilteredResult based on calculated priorityIndex
-				(*specList)[distances[i].index].OrderInFilteredResult = uint16(distances[i].priorityIndex)
-				// assign nomalized priorityIdex value to EvaluationScore09
-				(*specList)[distances[i].index].EvaluationScore09 = float32((max - float32(distances[i].distance)) / (max - min + 0.0000001)) // Add small value to avoid NaN by division
-				(*specList)[distances[i].index].EvaluationScore10 = float32(distances[i].distance)
-				// fmt.Printf("\n [%v] OrderInFilteredResult:%v, max:%v, min:%v, distance:%v, eval:%v \n", i, (*specList)[distances[i].index].OrderInFilteredResult, max, min, float32(distances[i].distance), (*specList)[distances[i].index].EvaluationScore09)
+		scores := normalizeScores(values, lowerIsBetter)
+		for i := range result {
+			criterion := CriterionScore{
+				Metric:          policy.Metric,
+				Weight:          weight,
+				Value:           values[i],
+				NormalizedScore: scores[i],
+				WeightedScore:   weight * scores[i],
 			}
-		default:
-			// log.Debug().Msg("[Checking] Not available metric " + metric)
+			if math.IsNaN(values[i]) {
+				// the value is not available (e.g., unknown location of the region)
+				criterion.Value = 0
+			}
+			result[i].Scores = append(result[i].Scores, criterion)
+			result[i].TotalScore += criterion.WeightedScore
 		}
-
+		weightSum += weight
 	}
 
-	for i := range *specList {
-		result = append(result, (*specList)[i])
-		//result[i].OrderInFilteredResult = uint16(i + 1)
+	if weightSum > 0 {
+		for i := range result {
+			result[i].TotalScore /= weightSum
+		}
 	}
 
-	// if evaluations for distance are same, low cost will have priolity
-	sort.Slice(result, func(i, j int) bool {
-		if result[i].OrderInFilteredResult < result[j].OrderInFilteredResult {
-			return true
-		} else if result[i].OrderInFilteredResult > result[j].OrderInFilteredResult {
-			return false
-		} else {
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].TotalScore != result[j].TotalScore {
+			return result[i].TotalScore > result[j].TotalScore
+		}
+		if result[i].CostPerHour != result[j].CostPerHour {
 			return result[i].CostPerHour < result[j].CostPerHour
 		}
-		//return result[i].OrderInFilteredResult < result[j].OrderInFilteredResult
+		return result[i].Id < result[j].Id
 	})
-	// fmt.Printf("\n result : %v \n", result)
 
-	// updatedSpec, err := mcir.UpdateSpec(nsId, *result)
-	// content, err = mcir.SortSpecs(*specList, "memoryGiB", "descending")
+	for i := range result {
+		result[i].OrderInFilteredResult = uint16(i + 1)
+	}
 	return result, nil
 }
 
-// RecommendVmLocation func prioritize specs based on given location
-func RecommendVmLocation(nsId string, specList *[]mcir.TbSpecInfo, param *[]ParameterKeyVal) ([]mcir.TbSpecInfo, error) {
-
-	result := []mcir.TbSpecInfo{}
+// parsePriorityWeight is func to parse the weight of a priority policy (1 if omitted)
+func parsePriorityWeight(weightStr string) (float64, error) {
+	weightStr = strings.TrimSpace(weightStr)
+	if weightStr == "" {
+		return 1, nil
+	}
+	weight, err := strconv.ParseFloat(weightStr, 64)
+	if err != nil || weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return 0, fmt.Errorf("invalid priority weight: '%s' (should be a non-negative number)", weightStr)
+	}
+	return weight, nil
+}
 
-	for _, v := range *param {
+// normalizeScores is func to normalize values into scores in [0, 1] (1 is the best).
+// A value which is not available (NaN) gets 0, and all values get 1 if they are the same.
+func normalizeScores(values []float64, lowerIsBetter bool) []float64 {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
 
-		switch v.Key {
-		case "coordinateClose":
-			//
-			coordinateStr := v.Val[0]
+	scores := make([]float64, len(values))
+	for i, v := range values {
+		switch {
+		case math.IsNaN(v):
+			scores[i] = 0
+		case max == min:
+			scores[i] = 1
+		case lowerIsBetter:
+			scores[i] = (max - v) / (max - min)
+		default:
+			scores[i] = (v - min) / (max - min)
+		}
+	}
+	return scores
+}
 
-			slice := strings.Split(coordinateStr, "/")
-			latitude, err := strconv.ParseFloat(strings.ReplaceAll(slice[0], " ", ""), 32)
-			if err != nil {
-				log.Error().Err(err).Msg("")
-				return []mcir.TbSpecInfo{}, err
-			}
-			longitude, err := strconv.ParseFloat(strings.ReplaceAll(slice[1], " ", ""), 32)
-			if err != nil {
-				log.Error().Err(err).Msg("")
-				return []mcir.TbSpecInfo{}, err
-			}
+// evaluateSpecsByLatency func evaluates specs by the sum of latencies from the given regions (latencyMinimal)
+func evaluateSpecsByLatency(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
 
-			type distanceType struct {
-				distance      float64
-				index         int
-				priorityIndex int
-			}
-			distances := make([]distanceType, len(*specList))
-
-			var wg sync.WaitGroup // WaitGroup to wait for all goroutines to finish
-			var mu sync.Mutex     // Mutex to protect shared data
-			var once sync.Once    // Once ensures that certain actions are performed only once
-			var globalErr error   // Global error variable to capture any error from goroutines
-
-			for i := range *specList {
-				wg.Add(1)
-				go func(i int) {
-					defer wg.Done() // Decrement the counter when the goroutine completes
-
-					var distance float64
-					distance, err = getDistance(latitude, longitude, (*specList)[i].ProviderName, (*specList)[i].RegionName)
-					if err != nil {
-						log.Error().Err(err).Msg("")
-						mu.Lock()
-						globalErr = err // Capture the error in globalErr
-						mu.Unlock()
-
-						once.Do(func() {
-							// If an error occurs, stop all operations (this block is executed only once)
-							distance = 99999999 // Set a very large value to avoid using this value in the calculation
-						})
-					}
-
-					mu.Lock() // Lock to protect the shared data
-					distances[i].distance = distance
-					distances[i].index = i
-					mu.Unlock() // Unlock after updating
-
-				}(i)
+	values := make([]float64, len(specList))
+	for _, v := range param {
+		switch v.Key {
+		case "latencyMinimal":
+			for i, k := range specList {
+				sumLatency := 0.0
+				for _, region := range v.Val {
+					l, _ := GetLatency(region, k.ProviderName+"-"+k.RegionName)
+					sumLatency += l
+				}
+				values[i] += sumLatency
 			}
+		default:
+			return nil, true, fmt.Errorf("invalid parameter key for latency: '%s' (available: latencyMinimal)", v.Key)
+		}
+	}
+	return values, true, nil
+}
 
-			wg.Wait() // Wait for all goroutines to finish
-
-			if globalErr != nil { // If there's an error from any goroutine, return it
-				// log.Error().Err(globalErr).Msg("")
-				// return []mcir.TbSpecInfo{}, globalErr
-			}
+// evaluateSpecsByLocation func evaluates specs by the geographical distance from the given location.
+// coordinateClose uses the distance from the coordinate, and coordinateFair uses the distance from the centroid of the coordinates.
+func evaluateSpecsByLocation(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
 
-			sort.Slice(distances, func(i, j int) bool {
-				return (*specList)[i].CostPerHour < (*specList)[j].CostPerHour
-			})
-			sort.Slice(distances, func(i, j int) bool {
-				return distances[i].distance < distances[j].distance
-			})
-
-			priorityCnt := 1
-			for i := range distances {
-
-				// priorityIndex++ if two distances are not equal (give the same priorityIndex if two variables are same)
-				if i != 0 {
-					if distances[i].distance > distances[i-1].distance {
-						priorityCnt++
-					}
-				}
-				distances[i].priorityIndex = priorityCnt
+	values := make([]float64, len(specList))
+	for _, v := range param {
 
+		var latitude, longitude float64
+		switch v.Key {
+		case "coordinateClose", "coordinateFair":
+			if len(v.Val) == 0 {
+				return nil, true, fmt.Errorf("no coordinate is given for %s", v.Key)
 			}
-
-			max := float32(distances[len(*specList)-1].distance)
-			min := float32(distances[0].distance)
-
-			for i := range *specList {
-				// update OrderInFilteredResult based on calculated priorityIndex
-				(*specList)[distances[i].index].OrderInFilteredResult = uint16(distances[i].priorityIndex)
-				// assign nomalized priorityIdex value to EvaluationScore09
-				(*specList)[distances[i].index].EvaluationScore09 = float32((max - float32(distances[i].distance)) / (max - min + 0.0000001)) // Add small value to avoid NaN by division
-				(*specList)[distances[i].index].EvaluationScore10 = float32(distances[i].distance)
-				// fmt.Printf("\n [%v] OrderInFilteredResult:%v, max:%v, min:%v, distance:%v, eval:%v \n", i, (*specList)[distances[i].index].OrderInFilteredResult, max, min, float32(distances[i].distance), (*specList)[distances[i].index].EvaluationScore09)
+			coordinates := v.Val
+			if v.Key == "coordinateClose" {
+				coordinates = v.Val[:1]
 			}
-
-		case "coordinateWithin":
-			//
-		case "coordinateFair":
-
 			// Calculate centroid of coordinate clusters
-			latitudeSum := 0.0
-			longitudeSum := 0.0
-			for _, coordinateStr := range v.Val {
-				slice := strings.Split(coordinateStr, "/")
-				latitudeEach, err := strconv.ParseFloat(strings.ReplaceAll(slice[0], " ", ""), 32)
-				if err != nil {
-					log.Error().Err(err).Msg("")
-					return []mcir.TbSpecInfo{}, err
-				}
-				longitudeEach, err := strconv.ParseFloat(strings.ReplaceAll(slice[1], " ", ""), 32)
+			for _, coordinateStr := range coordinates {
+				latitudeEach, longitudeEach, err := parseCoordinate(coordinateStr)
 				if err != nil {
 					log.Error().Err(err).Msg("")
-					return []mcir.TbSpecInfo{}, err
+					return nil, true, err
 				}
-				latitudeSum += latitudeEach
-				longitudeSum += longitudeEach
-			}
-			latitude := latitudeSum / (float64)(len(v.Val))
-			longitude := longitudeSum / (float64)(len(v.Val))
-
-			// Sorting, closes to the centroid.
-
-			type distanceType struct {
-				distance      float64
-				index         int
-				priorityIndex int
-			}
-			distances := make([]distanceType, len(*specList))
-
-			var wg sync.WaitGroup // WaitGroup to wait for all goroutines to finish
-			var mu sync.Mutex     // Mutex to protect shared data
-			var once sync.Once    // Once ensures that certain actions are performed only once
-			var globalErr error   // Global error variable to capture any error from goroutines
-
-			for i := range *specList {
-				wg.Add(1)
-				go func(i int) {
-					defer wg.Done() // Decrement the counter when the goroutine completes
-
-					distance, err := getDistance(latitude, longitude, (*specList)[i].ProviderName, (*specList)[i].RegionName)
-					if err != nil {
-						log.Error().Err(err).Msg("")
-						mu.Lock()
-						globalErr = err // Capture the error in globalErr
-						mu.Unlock()
-
-						once.Do(func() {
-							// If an error occurs, stop all operations (this block is executed only once)
-							return
-						})
-					}
-
-					mu.Lock() // Lock to protect the shared data
-					distances[i].distance = distance
-					distances[i].index = i
-					mu.Unlock() // Unlock after updating
-
-				}(i)
-			}
-
-			wg.Wait() // Wait for all goroutines to finish
-
-			if globalErr != nil { // If there's an error from any goroutine, return it
-				return []mcir.TbSpecInfo{}, globalErr
+				latitude += latitudeEach
+				longitude += longitudeEach
 			}
+			latitude /= float64(len(coordinates))
+			longitude /= float64(len(coordinates))
+		case "coordinateWithin":
+			continue
+		default:
+			return nil, true, fmt.Errorf("invalid parameter key for location: '%s' (available: coordinateClose, coordinateFair)", v.Key)
+		}
 
-			sort.Slice(distances, func(i, j int) bool {
-				return (*specList)[i].CostPerHour < (*specList)[j].CostPerHour
-			})
-			sort.Slice(distances, func(i, j int) bool {
-				return distances[i].distance < distances[j].distance
-			})
-
-			priorityCnt := 1
-			for i := range distances {
-
-				// priorityIndex++ if two distances are not equal (give the same priorityIndex if two variables are same)
-				if i != 0 {
-					if distances[i].distance > distances[i-1].distance {
-						priorityCnt++
-					}
+		// distances are cached by region since many specs are in the same region
+		distanceOfRegion := map[string]float64{}
+		for i, k := range specList {
+			regionKey := k.ProviderName + "/" + k.RegionName
+			distance, ok := distanceOfRegion[regionKey]
+			if !ok {
+				var err error
+				distance, err = getDistance(latitude, longitude, k.ProviderName, k.RegionName)
+				if err != nil {
+					distance = math.NaN()
 				}
-				distances[i].priorityIndex = priorityCnt
-
+				distanceOfRegion[regionKey] = distance
 			}
-
-			max := float32(distances[len(*specList)-1].distance)
-			min := float32(distances[0].distance)
-
-			for i := range *specList {
-				// update OrderInFilteredResult based on calculated priorityIndex
-				(*specList)[distances[i].index].OrderInFilteredResult = uint16(distances[i].priorityIndex)
-				// assign nomalized priorityIdex value to EvaluationScore09
-				(*specList)[distances[i].index].EvaluationScore09 = float32((max - float32(distances[i].distance)) / (max - min + 0.0000001)) // Add small value to avoid NaN by division
-				(*specList)[distances[i].index].EvaluationScore10 = float32(distances[i].distance)
-				// fmt.Printf("\n [%v] OrderInFilteredResult:%v, max:%v, min:%v, distance:%v, eval:%v \n", i, (*specList)[distances[i].index].OrderInFilteredResult, max, min, float32(distances[i].distance), (*specList)[distances[i].index].EvaluationScore09)
-			}
-		default:
-			// log.Debug().Msg("[Checking] Not available metric " + metric)
+			values[i] += distance
 		}
-
 	}
+	return values, true, nil
+}
 
-	for i := range *specList {
-		result = append(result, (*specList)[i])
-		//result[i].OrderInFilteredResult = uint16(i + 1)
+// parseCoordinate func parses a coordinate string ("latitude/longitude")
+func parseCoordinate(coordinateStr string) (float64, float64, error) {
+	slice := strings.Split(coordinateStr, "/")
+	if len(slice) != 2 {
+		return 0, 0, fmt.Errorf("invalid coordinate: '%s' (should be latitude/longitude)", coordinateStr)
 	}
-
-	// if evaluations for distance are same, low cost will have priolity
-	sort.Slice(result, func(i, j int) bool {
-		if result[i].OrderInFilteredResult < result[j].OrderInFilteredResult {
-			return true
-		} else if result[i].OrderInFilteredResult > result[j].OrderInFilteredResult {
-			return false
-		} else {
-			return result[i].CostPerHour < result[j].CostPerHour
-		}
-		//return result[i].OrderInFilteredResult < result[j].OrderInFilteredResult
-	})
-	// fmt.Printf("\n result : %v \n", result)
-
-	// updatedSpec, err := mcir.UpdateSpec(nsId, *result)
-	// content, err = mcir.SortSpecs(*specList, "memoryGiB", "descending")
-	return result, nil
+	latitude, err := strconv.ParseFloat(strings.ReplaceAll(slice[0], " ", ""), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	longitude, err := strconv.ParseFloat(strings.ReplaceAll(slice[1], " ", ""), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return latitude, longitude, nil
 }
 
 // getDistance func get geographical distance between given coordinate and region
@@ -553,16 +508,16 @@ func getDistance(latitude float64, longitude float64, providerName string, regio
 
 }
 
-// GetLatency func get latency between given two regions
+// GetLatency func get latency between given two regions.
+// A latency measured by benchmarks (or imported) is used first, and the static latency map is used otherwise.
 func GetLatency(src string, dest string) (float64, error) {
 
-	latencyString := common.RuntimeLatancyMap[common.RuntimeLatancyMapIndex[src]][common.RuntimeLatancyMapIndex[dest]]
-	latency, err := strconv.ParseFloat(strings.ReplaceAll(latencyString, " ", ""), 32)
+	info, err := GetLatencyInfo(src, dest)
 	if err != nil {
 		log.Info().Err(err).Msgf("Cannot get GetLatency between src: %v, dest: %v (check assets)", src, dest)
 		return 999999, err
 	}
-	return latency, nil
+	return info.LatencyMs, nil
 }
 
 // getHaversineDistance func return HaversineDistance
@@ -578,76 +533,55 @@ func getHaversineDistance(a1 float64, b1 float64, a2 float64, b2 float64) (dista
 	return (earthRadius * c)
 }
 
-// RecommendVmRandom func prioritize specs randomly
-func RecommendVmRandom(nsId string, specList *[]mcir.TbSpecInfo) ([]mcir.TbSpecInfo, error) {
-
-	result := []mcir.TbSpecInfo{}
-
-	for i := range *specList {
-		result = append(result, (*specList)[i])
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
-
-	Max := float32(result[len(result)-1].CostPerHour)
-	Min := float32(result[0].CostPerHour)
-
-	for i := range result {
-		result[i].OrderInFilteredResult = uint16(i + 1)
-		result[i].EvaluationScore09 = float32((Max - result[i].CostPerHour) / (Max - Min + 0.0000001)) // Add small value to avoid NaN by division
+// evaluateSpecsByRandom func evaluates specs by random values
+func evaluateSpecsByRandom(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	values := make([]float64, len(specList))
+	for i := range values {
+		values[i] = r.Float64()
 	}
-
-	fmt.Printf("\n result : %v \n", result)
-
-	return result, nil
+	return values, false, nil
 }
 
-// RecommendVmCost func prioritize specs based on given Cost
-func RecommendVmCost(nsId string, specList *[]mcir.TbSpecInfo) ([]mcir.TbSpecInfo, error) {
-
-	result := []mcir.TbSpecInfo{}
-
-	for i := range *specList {
-		result = append(result, (*specList)[i])
+// evaluateSpecsByCost func evaluates specs by cost per hour
+// (spot prices are used with the parameter {"key": "market", "val": ["spot"]})
+func evaluateSpecsByCost(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
+	marketType := MarketTypeOnDemand
+	for _, v := range param {
+		switch v.Key {
+		case "market":
+			if len(v.Val) == 0 || (v.Val[0] != MarketTypeOnDemand && v.Val[0] != MarketTypeSpot) {
+				return nil, true, fmt.Errorf("invalid market for cost: %v (available: %s, %s)", v.Val, MarketTypeOnDemand, MarketTypeSpot)
+			}
+			marketType = v.Val[0]
+		default:
+			return nil, true, fmt.Errorf("invalid parameter key for cost: '%s' (available: market)", v.Key)
+		}
 	}
 
-	sort.Slice(result, func(i, j int) bool { return result[i].CostPerHour < result[j].CostPerHour })
-
-	Max := float32(result[len(result)-1].CostPerHour)
-	Min := float32(result[0].CostPerHour)
-
-	for i := range result {
-		result[i].OrderInFilteredResult = uint16(i + 1)
-		result[i].EvaluationScore09 = float32((Max - result[i].CostPerHour) / (Max - Min + 0.0000001)) // Add small value to avoid NaN by division
+	values := make([]float64, len(specList))
+	for i, k := range specList {
+		cost := getSpecCostPerHour(k, marketType)
+		values[i] = float64(cost)
+		if cost < 0 {
+			// unknown cost
+			values[i] = math.NaN()
+		}
 	}
-
-	fmt.Printf("\n result : %v \n", result)
-
-	return result, nil
+	return values, true, nil
 }
 
-// RecommendVmPerformance func prioritize specs based on given Performance condition
-func RecommendVmPerformance(nsId string, specList *[]mcir.TbSpecInfo) ([]mcir.TbSpecInfo, error) {
-
-	result := []mcir.TbSpecInfo{}
-
-	for i := range *specList {
-		result = append(result, (*specList)[i])
-	}
-
-	sort.Slice(result, func(i, j int) bool { return result[i].EvaluationScore01 > result[j].EvaluationScore01 })
-
-	Max := float32(result[0].EvaluationScore01)
-	Min := float32(result[len(result)-1].EvaluationScore01)
-
-	for i := range result {
-		result[i].OrderInFilteredResult = uint16(i + 1)
-		result[i].EvaluationScore09 = float32((result[i].EvaluationScore01 - Min) / (Max - Min + 0.0000001)) // Add small value to avoid NaN by division
+// evaluateSpecsByPerformance func evaluates specs by the performance score
+// (measured by benchmarks if available, EvaluationScore01 seeded from assets otherwise)
+func evaluateSpecsByPerformance(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
+	values := make([]float64, len(specList))
+	for i, k := range specList {
+		values[i] = float64(k.EvaluationScore01)
+		if score, ok := getMeasuredPerformanceScore(k.Id); ok {
+			values[i] = score
+		}
 	}
-	fmt.Printf("\n result : %v \n", result)
-
-	return result, nil
+	return values, false, nil
 }
 
 // GetRecommendList is func to get recommendation list
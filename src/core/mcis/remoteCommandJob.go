@@ -0,0 +1,505 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// CmdJobStatusRunning is const for "Running" status of a command job.
+	CmdJobStatusRunning string = "Running"
+
+	// CmdJobStatusCompleted is const for "Completed" status of a command job.
+	CmdJobStatusCompleted string = "Completed"
+
+	// CmdJobStatusCancelled is const for "Cancelled" status of a command job.
+	CmdJobStatusCancelled string = "Cancelled"
+
+	// CmdJobStatusInterrupted is const for "Interrupted" status of a command job.
+	// (the job was running when CB-Tumblebug stopped)
+	CmdJobStatusInterrupted string = "Interrupted"
+)
+
+const (
+	// CmdJobStreamStdout is const for a stdout line of a command job output.
+	CmdJobStreamStdout string = "stdout"
+
+	// CmdJobStreamStderr is const for a stderr line of a command job output.
+	CmdJobStreamStderr string = "stderr"
+
+	// CmdJobStreamVmDone is const for the end of commands in a VM.
+	CmdJobStreamVmDone string = "vmDone"
+
+	// CmdJobStreamJobDone is const for the end of a command job.
+	CmdJobStreamJobDone string = "jobDone"
+)
+
+// cmdJobOutputBufferSize is the number of output lines kept in memory for late subscribers of a running job
+const cmdJobOutputBufferSize = 10000
+
+// cmdJobSubscriberBufferSize is the channel size for each subscriber (lines are dropped for a too slow subscriber)
+const cmdJobSubscriberBufferSize = 1024
+
+// McisCmdJobInfo is struct for an asynchronous remote command job
+type McisCmdJobInfo struct {
+	JobId        string       `json:"jobId" example:"cq2a3b4c5d6e7f8g9h0i"`
+	NsId         string       `json:"nsId" example:"ns01"`
+	McisId       string       `json:"mcisId" example:"mcis01"`
+	SubGroupId   string       `json:"subGroupId,omitempty" example:"g1"`
+	VmId         string       `json:"vmId,omitempty" example:"g1-1"`
+	UserName     string       `json:"userName,omitempty" example:"cb-user"`
+	Command      []string     `json:"command"`
+	Option       SshCmdOption `json:"option"`
+	Status       string       `json:"status" example:"Running"`
+	TargetVmList []string     `json:"targetVmList"`
+	CreatedTime  string       `json:"createdTime" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string       `json:"finishedTime,omitempty" example:"2024-01-01T00:01:00Z"`
+
+	// Results is available when the job is finished
+	Results []SshCmdResult `json:"results"`
+	// VmErr is error message for each VM (SshCmdResult.Err is not kept in the persisted results)
+	VmErr map[string]string `json:"vmErr,omitempty"`
+}
+
+// McisCmdJobInfoList is struct for a list of command jobs
+type McisCmdJobInfoList struct {
+	Jobs []McisCmdJobInfo `json:"jobs"`
+}
+
+// McisCmdJobOutput is struct for an output event of a command job (delivered by streaming)
+type McisCmdJobOutput struct {
+	JobId     string `json:"jobId"`
+	VmId      string `json:"vmId,omitempty"`
+	CmdIndex  int    `json:"cmdIndex"`
+	Stream    string `json:"stream" enums:"stdout,stderr,vmDone,jobDone"`
+	Line      string `json:"line,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// cmdJobRuntime is struct for the in-memory state of a running command job
+type cmdJobRuntime struct {
+	mu          sync.Mutex
+	cancel      context.CancelFunc
+	outputs     []McisCmdJobOutput
+	subscribers map[chan McisCmdJobOutput]struct{}
+	finished    bool
+}
+
+// cmdJobRuntimes is map for running command jobs (key: nsId/jobId)
+var cmdJobRuntimes sync.Map
+
+// genCmdJobKey is func to generate a key for a command job
+func genCmdJobKey(nsId string, jobId string) string {
+	return "/ns/" + nsId + "/cmdJob/" + jobId
+}
+
+// publish is func to buffer an output and to deliver it to the subscribers
+func (rt *cmdJobRuntime) publish(output McisCmdJobOutput) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.outputs = append(rt.outputs, output)
+	if len(rt.outputs) > cmdJobOutputBufferSize {
+		rt.outputs = rt.outputs[len(rt.outputs)-cmdJobOutputBufferSize:]
+	}
+	for ch := range rt.subscribers {
+		select {
+		case ch <- output:
+		default:
+			// drop the line for a slow subscriber not to block the SSH sessions
+		}
+	}
+}
+
+// finish is func to close all subscribers of the job
+func (rt *cmdJobRuntime) finish() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.finished = true
+	for ch := range rt.subscribers {
+		close(ch)
+	}
+	rt.subscribers = map[chan McisCmdJobOutput]struct{}{}
+}
+
+// SubmitCmdJobToMcis is func to start an asynchronous remote command job to VMs in MCIS
+func SubmitCmdJobToMcis(nsId string, mcisId string, subGroupId string, vmId string, req *McisCmdReq) (McisCmdJobInfo, error) {
+
+	vmCommands, displayCommands, err := prepareRemoteCommand(nsId, mcisId, subGroupId, vmId, req)
+	if err != nil {
+		return McisCmdJobInfo{}, err
+	}
+
+	job := McisCmdJobInfo{
+		JobId:       common.GenUid(),
+		NsId:        nsId,
+		McisId:      mcisId,
+		SubGroupId:  subGroupId,
+		VmId:        vmId,
+		UserName:    req.UserName,
+		Command:     req.Command,
+		Option:      req.SshCmdOption,
+		Status:      CmdJobStatusRunning,
+		CreatedTime: time.Now().UTC().Format(time.RFC3339),
+		Results:     []SshCmdResult{},
+	}
+	for targetVmId := range vmCommands {
+		job.TargetVmList = append(job.TargetVmList, targetVmId)
+	}
+
+	err = putCmdJob(job)
+	if err != nil {
+		return McisCmdJobInfo{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt := &cmdJobRuntime{
+		cancel:      cancel,
+		subscribers: map[chan McisCmdJobOutput]struct{}{},
+	}
+	cmdJobRuntimes.Store(nsId+"/"+job.JobId, rt)
+
+	go runCmdJob(ctx, rt, job, vmCommands, displayCommands)
+
+	return job, nil
+}
+
+// runCmdJob is func to execute a command job and to persist the results
+func runCmdJob(ctx context.Context, rt *cmdJobRuntime, job McisCmdJobInfo, vmCommands map[string][]string, displayCommands map[string][]string) {
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := []SshCmdResult{}
+	vmErr := map[string]string{}
+
+	for vmId, commands := range vmCommands {
+		wg.Add(1)
+		go func(vmId string, commands []string) {
+			defer wg.Done()
+
+			result := runRemoteCommandOfJob(ctx, rt, job, vmId, commands)
+			// Do not keep secrets in the results
+			for i, c := range displayCommands[vmId] {
+				result.Command[i] = c
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if result.Err != nil {
+				vmErr[vmId] = result.Err.Error()
+				result.Err = nil
+			}
+			results = append(results, result)
+		}(vmId, commands)
+	}
+	wg.Wait()
+
+	job.Results = results
+	job.VmErr = vmErr
+	job.Status = CmdJobStatusCompleted
+	if ctx.Err() != nil {
+		job.Status = CmdJobStatusCancelled
+	}
+	job.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+
+	err := putCmdJob(job)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+	}
+
+	rt.publish(McisCmdJobOutput{
+		JobId:     job.JobId,
+		Stream:    CmdJobStreamJobDone,
+		Line:      job.Status,
+		Timestamp: job.FinishedTime,
+	})
+	rt.finish()
+	rt.cancel()
+	cmdJobRuntimes.Delete(job.NsId + "/" + job.JobId)
+
+	log.Info().Msgf("[Command job] %s/%s %s", job.NsId, job.JobId, job.Status)
+}
+
+// runRemoteCommandOfJob is func to execute commands to a VM with streaming output of a job
+func runRemoteCommandOfJob(ctx context.Context, rt *cmdJobRuntime, job McisCmdJobInfo, vmId string, cmds []string) SshCmdResult {
+
+	result := SshCmdResult{
+		McisId:   job.McisId,
+		VmId:     vmId,
+		Command:  map[int]string{},
+		Stdout:   map[int]string{},
+		Stderr:   map[int]string{},
+		ExitCode: map[int]int{},
+	}
+	for i, c := range cmds {
+		result.Command[i] = c
+	}
+
+	vmIP, _, _, err := GetVmIp(job.NsId, job.McisId, vmId)
+	result.VmIp = vmIP
+
+	if err == nil {
+		var route sshRoute
+		route, err = getSshRouteOfVm(job.NsId, job.McisId, vmId, job.UserName)
+		if err == nil {
+			handler := func(cmdIndex int, stream string, line string) {
+				rt.publish(McisCmdJobOutput{
+					JobId:     job.JobId,
+					VmId:      vmId,
+					CmdIndex:  cmdIndex,
+					Stream:    stream,
+					Line:      line,
+					Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+				})
+			}
+			result.Stdout, result.Stderr, result.ExitCode, err = runSSHWithContext(ctx, route, cmds, job.Option, handler)
+		}
+	}
+
+	done := McisCmdJobOutput{
+		JobId:     job.JobId,
+		VmId:      vmId,
+		CmdIndex:  len(result.Stdout) - 1,
+		Stream:    CmdJobStreamVmDone,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("[Command job] %s/%s", job.JobId, vmId)
+		result.Err = err
+		done.Line = err.Error()
+	}
+	rt.publish(done)
+
+	return result
+}
+
+// putCmdJob is func to persist a command job
+func putCmdJob(job McisCmdJobInfo) error {
+	val, err := json.Marshal(job)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genCmdJobKey(job.NsId, job.JobId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetCmdJob is func to get a command job (results are included when the job is finished)
+func GetCmdJob(nsId string, jobId string) (McisCmdJobInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return McisCmdJobInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genCmdJobKey(nsId, jobId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return McisCmdJobInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The command job " + jobId + " does not exist.")
+		return McisCmdJobInfo{}, err
+	}
+
+	job := McisCmdJobInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &job)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return McisCmdJobInfo{}, err
+	}
+
+	if job.Status == CmdJobStatusRunning {
+		if _, ok := cmdJobRuntimes.Load(nsId + "/" + jobId); !ok {
+			job.Status = CmdJobStatusInterrupted
+		}
+	}
+	return job, nil
+}
+
+// ListCmdJob is func to list command jobs in a namespace (optionally filtered by MCIS)
+func ListCmdJob(nsId string, mcisId string) (McisCmdJobInfoList, error) {
+
+	result := McisCmdJobInfoList{Jobs: []McisCmdJobInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/cmdJob"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	for _, v := range keyValue {
+		job := McisCmdJobInfo{}
+		err = json.Unmarshal([]byte(v.Value), &job)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		if mcisId != "" && job.McisId != mcisId {
+			continue
+		}
+		if job.Status == CmdJobStatusRunning {
+			if _, ok := cmdJobRuntimes.Load(nsId + "/" + job.JobId); !ok {
+				job.Status = CmdJobStatusInterrupted
+			}
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+	return result, nil
+}
+
+// CancelCmdJob is func to cancel a running command job (SSH sessions of the job are closed)
+func CancelCmdJob(nsId string, jobId string) (McisCmdJobInfo, error) {
+
+	job, err := GetCmdJob(nsId, jobId)
+	if err != nil {
+		return McisCmdJobInfo{}, err
+	}
+
+	value, ok := cmdJobRuntimes.Load(nsId + "/" + jobId)
+	if !ok {
+		err := fmt.Errorf("The command job " + jobId + " is not running (status: " + job.Status + ")")
+		return job, err
+	}
+	value.(*cmdJobRuntime).cancel()
+
+	log.Info().Msgf("[Command job] cancel requested %s/%s", nsId, jobId)
+	return job, nil
+}
+
+// DelCmdJob is func to delete a finished command job
+func DelCmdJob(nsId string, jobId string) error {
+
+	job, err := GetCmdJob(nsId, jobId)
+	if err != nil {
+		return err
+	}
+	if job.Status == CmdJobStatusRunning {
+		err := fmt.Errorf("The command job " + jobId + " is running. Cancel it first.")
+		return err
+	}
+
+	err = common.CBStore.Delete(genCmdJobKey(nsId, jobId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// SubscribeCmdJob is func to subscribe the output of a command job.
+// It returns the outputs so far and a channel for following outputs (closed when the job is finished),
+// and a func to stop the subscription.
+// For a finished job, outputs are reconstructed from the persisted results and the channel is closed.
+func SubscribeCmdJob(nsId string, jobId string) ([]McisCmdJobOutput, <-chan McisCmdJobOutput, func(), error) {
+
+	value, ok := cmdJobRuntimes.Load(nsId + "/" + jobId)
+	if ok {
+		rt := value.(*cmdJobRuntime)
+		rt.mu.Lock()
+		defer rt.mu.Unlock()
+
+		backlog := make([]McisCmdJobOutput, len(rt.outputs))
+		copy(backlog, rt.outputs)
+
+		ch := make(chan McisCmdJobOutput, cmdJobSubscriberBufferSize)
+		if rt.finished {
+			close(ch)
+			return backlog, ch, func() {}, nil
+		}
+		rt.subscribers[ch] = struct{}{}
+		unsubscribe := func() {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			if _, exists := rt.subscribers[ch]; exists {
+				delete(rt.subscribers, ch)
+				close(ch)
+			}
+		}
+		return backlog, ch, unsubscribe, nil
+	}
+
+	job, err := GetCmdJob(nsId, jobId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	backlog := []McisCmdJobOutput{}
+	for _, result := range job.Results {
+		for i := 0; i < len(result.Command); i++ {
+			for _, stream := range []string{CmdJobStreamStdout, CmdJobStreamStderr} {
+				text := result.Stdout[i]
+				if stream == CmdJobStreamStderr {
+					text = result.Stderr[i]
+				}
+				if text == "" {
+					continue
+				}
+				for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+					backlog = append(backlog, McisCmdJobOutput{
+						JobId:     jobId,
+						VmId:      result.VmId,
+						CmdIndex:  i,
+						Stream:    stream,
+						Line:      line,
+						Timestamp: job.FinishedTime,
+					})
+				}
+			}
+		}
+		backlog = append(backlog, McisCmdJobOutput{
+			JobId:     jobId,
+			VmId:      result.VmId,
+			CmdIndex:  len(result.Stdout) - 1,
+			Stream:    CmdJobStreamVmDone,
+			Line:      job.VmErr[result.VmId],
+			Timestamp: job.FinishedTime,
+		})
+	}
+	backlog = append(backlog, McisCmdJobOutput{
+		JobId:     jobId,
+		Stream:    CmdJobStreamJobDone,
+		Line:      job.Status,
+		Timestamp: job.FinishedTime,
+	})
+
+	ch := make(chan McisCmdJobOutput)
+	close(ch)
+	return backlog, ch, func() {}, nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/pkg/sftp"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// FileTransferMaxSize is the maximum size of a file to upload or download (100 MiB)
+const FileTransferMaxSize int64 = 100 * 1024 * 1024
+
+// fileTransferResultFileName is the name of the per-VM result file in the download archive
+const fileTransferResultFileName = "results.json"
+
+// McisFileTransferResult is struct for the result of a file transfer to/from a VM
+type McisFileTransferResult struct {
+	McisId string `json:"mcisId"`
+	VmId   string `json:"vmId"`
+	VmIp   string `json:"vmIp"`
+	Path   string `json:"path" example:"/home/cb-user/app.conf"`
+	Size   int64  `json:"size" example:"1024"`
+	// Checksum is SHA-256 of the transferred content (calculated by CB-Tumblebug)
+	Checksum string `json:"checksum"`
+	// RemoteChecksum is SHA-256 of the file on the VM (calculated by sha256sum in the VM)
+	RemoteChecksum string `json:"remoteChecksum"`
+	// Verified is true if Checksum and RemoteChecksum are the same
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// McisFileTransferResults is struct for Set of file transfer results in terms of MCIS
+type McisFileTransferResults struct {
+	Results []McisFileTransferResult `json:"results"`
+}
+
+// UploadFileToMcis is func to upload a file to VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP
+func UploadFileToMcis(nsId string, mcisId string, subGroupId string, vmId string, userName string, targetPath string, mode os.FileMode, content []byte) (McisFileTransferResults, error) {
+
+	result := McisFileTransferResults{Results: []McisFileTransferResult{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	if targetPath == "" || strings.HasSuffix(targetPath, "/") {
+		err := fmt.Errorf("A file path on the VM is required (path: '%s')", targetPath)
+		return result, err
+	}
+	if int64(len(content)) > FileTransferMaxSize {
+		err := fmt.Errorf("The file size (%d bytes) exceeds the limit (%d bytes)", len(content), FileTransferMaxSize)
+		return result, err
+	}
+
+	vmList, err := getTargetVmList(nsId, mcisId, subGroupId, vmId)
+	if err != nil {
+		return result, err
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, v := range vmList {
+		wg.Add(1)
+		go func(vmId string) {
+			defer wg.Done()
+
+			vmResult := McisFileTransferResult{
+				McisId:   mcisId,
+				VmId:     vmId,
+				Path:     targetPath,
+				Size:     int64(len(content)),
+				Checksum: checksum,
+			}
+			vmResult.VmIp, _, _, _ = GetVmIp(nsId, mcisId, vmId)
+
+			err := withSftpClient(nsId, mcisId, vmId, userName, func(client *ssh.Client, sftpClient *sftp.Client) error {
+				dir := path.Dir(targetPath)
+				if dir != "." && dir != "/" {
+					if err := sftpClient.MkdirAll(dir); err != nil {
+						return fmt.Errorf("failed to create directory %s: %w", dir, err)
+					}
+				}
+				file, err := sftpClient.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+				if err != nil {
+					return err
+				}
+				_, err = io.Copy(file, bytes.NewReader(content))
+				file.Close()
+				if err != nil {
+					return err
+				}
+				if mode != 0 {
+					if err := sftpClient.Chmod(targetPath, mode); err != nil {
+						return err
+					}
+				}
+				vmResult.RemoteChecksum = getRemoteChecksum(client, targetPath)
+				return nil
+			})
+			if err != nil {
+				log.Error().Err(err).Msgf("[Upload] %s/%s", mcisId, vmId)
+				vmResult.Error = err.Error()
+			}
+			vmResult.Verified = err == nil && vmResult.RemoteChecksum == vmResult.Checksum
+
+			mu.Lock()
+			result.Results = append(result.Results, vmResult)
+			mu.Unlock()
+		}(v)
+	}
+	wg.Wait()
+
+	sort.Slice(result.Results, func(i, j int) bool {
+		return result.Results[i].VmId < result.Results[j].VmId
+	})
+	return result, nil
+}
+
+// DownloadFileFromMcis is func to download a file from VMs in MCIS (all VMs, VMs in a subGroup, or a VM) by SFTP.
+// Files are written to w as a zip archive ({vmId}/{file name}) with results.json for the per-VM results.
+func DownloadFileFromMcis(nsId string, mcisId string, subGroupId string, vmId string, userName string, sourcePath string, w io.Writer) (McisFileTransferResults, error) {
+
+	result := McisFileTransferResults{Results: []McisFileTransferResult{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	if sourcePath == "" || strings.HasSuffix(sourcePath, "/") {
+		err := fmt.Errorf("A file path on the VM is required (path: '%s')", sourcePath)
+		return result, err
+	}
+
+	vmList, err := getTargetVmList(nsId, mcisId, subGroupId, vmId)
+	if err != nil {
+		return result, err
+	}
+	sort.Strings(vmList)
+
+	archive := zip.NewWriter(w)
+	for _, vmId := range vmList {
+		vmResult := McisFileTransferResult{
+			McisId: mcisId,
+			VmId:   vmId,
+			Path:   sourcePath,
+		}
+		vmResult.VmIp, _, _, _ = GetVmIp(nsId, mcisId, vmId)
+
+		err := withSftpClient(nsId, mcisId, vmId, userName, func(client *ssh.Client, sftpClient *sftp.Client) error {
+			info, err := sftpClient.Stat(sourcePath)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return fmt.Errorf("%s is a directory", sourcePath)
+			}
+			if info.Size() > FileTransferMaxSize {
+				return fmt.Errorf("The file size (%d bytes) exceeds the limit (%d bytes)", info.Size(), FileTransferMaxSize)
+			}
+
+			file, err := sftpClient.Open(sourcePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			entry, err := archive.Create(vmId + "/" + path.Base(sourcePath))
+			if err != nil {
+				return err
+			}
+			hash := sha256.New()
+			size, err := io.Copy(io.MultiWriter(entry, hash), io.LimitReader(file, FileTransferMaxSize))
+			if err != nil {
+				return err
+			}
+			vmResult.Size = size
+			vmResult.Checksum = hex.EncodeToString(hash.Sum(nil))
+			vmResult.RemoteChecksum = getRemoteChecksum(client, sourcePath)
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msgf("[Download] %s/%s", mcisId, vmId)
+			vmResult.Error = err.Error()
+		}
+		vmResult.Verified = err == nil && vmResult.RemoteChecksum == vmResult.Checksum
+		result.Results = append(result.Results, vmResult)
+	}
+
+	entry, err := archive.Create(fileTransferResultFileName)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	resultJson, _ := json.MarshalIndent(result, "", "  ")
+	if _, err := entry.Write(resultJson); err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = archive.Close()
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	return result, nil
+}
+
+// withSftpClient is func to open a SFTP session to a VM through the bastion node and to run fn with it
+func withSftpClient(nsId string, mcisId string, vmId string, userName string, fn func(client *ssh.Client, sftpClient *sftp.Client) error) error {
+
+	route, err := getSshRouteOfVm(nsId, mcisId, vmId, userName)
+	if err != nil {
+		return err
+	}
+
+	conn, err := sshPool.acquire(route)
+	if err != nil {
+		return err
+	}
+	defer sshPool.release(conn)
+
+	sftpClient, err := sftp.NewClient(conn.client)
+	if err != nil {
+		sshPool.handleChannelError(conn, err)
+		return fmt.Errorf("failed to start SFTP session: %w", err)
+	}
+	defer sftpClient.Close()
+
+	return fn(conn.client, sftpClient)
+}
+
+// getRemoteChecksum is func to get SHA-256 of a file in the VM (returns "" if sha256sum is not available)
+func getRemoteChecksum(client *ssh.Client, filePath string) string {
+	session, err := client.NewSession()
+	if err != nil {
+		return ""
+	}
+	defer session.Close()
+
+	out, err := session.Output("sha256sum -- " + shellQuote(filePath))
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// shellQuote is func to quote a string as a single argument for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
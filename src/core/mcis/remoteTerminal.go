@@ -0,0 +1,415 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// TerminalStatusActive is const for "Active" status of a terminal session.
+	TerminalStatusActive string = "Active"
+
+	// TerminalStatusClosed is const for "Closed" status of a terminal session.
+	TerminalStatusClosed string = "Closed"
+)
+
+// terminalDefaultCols and terminalDefaultRows are the default PTY size
+const (
+	terminalDefaultCols = 80
+	terminalDefaultRows = 24
+)
+
+// TerminalSessionInfo is struct for a web terminal session to a VM
+type TerminalSessionInfo struct {
+	SessionId   string `json:"sessionId" example:"cq2a3b4c5d6e7f8g9h0i"`
+	NsId        string `json:"nsId" example:"ns01"`
+	McisId      string `json:"mcisId" example:"mcis01"`
+	VmId        string `json:"vmId" example:"g1-1"`
+	UserName    string `json:"userName" example:"cb-user"`
+	RequestedBy string `json:"requestedBy" example:"default"`
+	ClientIp    string `json:"clientIp" example:"127.0.0.1"`
+	Status      string `json:"status" example:"Active"`
+	StartedTime string `json:"startedTime" example:"2024-01-01T00:00:00Z"`
+	EndedTime   string `json:"endedTime,omitempty" example:"2024-01-01T00:10:00Z"`
+	// RecordingFile is the asciicast v2 recording of the session output
+	RecordingFile string `json:"recordingFile"`
+}
+
+// TerminalSessionInfoList is struct for a list of terminal sessions
+type TerminalSessionInfoList struct {
+	Sessions []TerminalSessionInfo `json:"sessions"`
+}
+
+// TerminalSession is an interactive PTY session to a VM through the bastion node (on a pooled connection).
+// Output read from the session is recorded in asciicast v2 format.
+type TerminalSession struct {
+	Info TerminalSessionInfo
+
+	conn      *sshPooledConn
+	session   *ssh.Session
+	stdin     io.WriteCloser
+	stdout    io.Reader
+	recorder  *asciicastRecorder
+	closeOnce sync.Once
+}
+
+// genTerminalSessionKey is func to generate a key for a terminal session
+func genTerminalSessionKey(nsId string, sessionId string) string {
+	return "/ns/" + nsId + "/terminalSession/" + sessionId
+}
+
+// terminalRecordingDir is func to get the directory for terminal recordings
+func terminalRecordingDir() string {
+	return filepath.Join(os.Getenv("CBTUMBLEBUG_ROOT"), "meta_db", "terminal")
+}
+
+// OpenTerminalSession is func to open an interactive PTY session to a VM
+// with the same key lookup and bastion routing of remote commands
+func OpenTerminalSession(nsId string, mcisId string, vmId string, userName string, requestedBy string, clientIp string, cols int, rows int) (*TerminalSession, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	err = common.CheckString(vmId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	check, _ := CheckVm(nsId, mcisId, vmId)
+	if !check {
+		err := fmt.Errorf("The vm " + vmId + " does not exist.")
+		return nil, err
+	}
+	if cols <= 0 {
+		cols = terminalDefaultCols
+	}
+	if rows <= 0 {
+		rows = terminalDefaultRows
+	}
+
+	route, err := getSshRouteOfVm(nsId, mcisId, vmId, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sshPool.acquire(route)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	t := &TerminalSession{
+		conn: conn,
+	}
+	t.Info = TerminalSessionInfo{
+		SessionId:   common.GenUid(),
+		NsId:        nsId,
+		McisId:      mcisId,
+		VmId:        vmId,
+		UserName:    route.Target.UserName,
+		RequestedBy: requestedBy,
+		ClientIp:    clientIp,
+		Status:      TerminalStatusActive,
+		StartedTime: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	err = t.start(cols, rows)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		t.Close()
+		return nil, err
+	}
+
+	log.Info().Msgf("[Terminal] opened %s (%s/%s/%s) by %s", t.Info.SessionId, nsId, mcisId, vmId, requestedBy)
+	return t, nil
+}
+
+// start is func to request a PTY and a shell, and to start the recording
+func (t *TerminalSession) start(cols int, rows int) error {
+	session, err := sshPool.newSession(t.conn)
+	if err != nil {
+		return err
+	}
+	t.session = session
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty("xterm-256color", rows, cols, modes); err != nil {
+		return err
+	}
+	if t.stdin, err = session.StdinPipe(); err != nil {
+		return err
+	}
+	if t.stdout, err = session.StdoutPipe(); err != nil {
+		return err
+	}
+	// with a PTY, stderr of the shell is delivered through stdout
+	session.Stderr = io.Discard
+
+	dir := terminalRecordingDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	t.Info.RecordingFile = filepath.Join(dir, t.Info.SessionId+".cast")
+	title := fmt.Sprintf("%s/%s/%s by %s", t.Info.NsId, t.Info.McisId, t.Info.VmId, t.Info.RequestedBy)
+	t.recorder, err = newAsciicastRecorder(t.Info.RecordingFile, cols, rows, title)
+	if err != nil {
+		return err
+	}
+
+	if err := putTerminalSessionInfo(t.Info); err != nil {
+		return err
+	}
+
+	return session.Shell()
+}
+
+// Read is func to read output of the session (the output is recorded)
+func (t *TerminalSession) Read(p []byte) (int, error) {
+	n, err := t.stdout.Read(p)
+	if n > 0 {
+		t.recorder.output(p[:n])
+	}
+	return n, err
+}
+
+// Write is func to send input to the session (input is not recorded to avoid leaking secrets)
+func (t *TerminalSession) Write(p []byte) (int, error) {
+	return t.stdin.Write(p)
+}
+
+// Resize is func to change the PTY size of the session
+func (t *TerminalSession) Resize(cols int, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		return fmt.Errorf("Invalid terminal size (cols: %d, rows: %d)", cols, rows)
+	}
+	t.recorder.resize(cols, rows)
+	return t.session.WindowChange(rows, cols)
+}
+
+// Wait is func to wait until the shell of the session exits
+func (t *TerminalSession) Wait() error {
+	return t.session.Wait()
+}
+
+// Close is func to close the session and to finalize the recording
+func (t *TerminalSession) Close() error {
+	t.closeOnce.Do(func() {
+		if t.session != nil {
+			t.session.Close()
+		}
+		sshPool.release(t.conn)
+		if t.recorder != nil {
+			t.recorder.close()
+		}
+
+		if t.Info.RecordingFile != "" {
+			t.Info.Status = TerminalStatusClosed
+			t.Info.EndedTime = time.Now().UTC().Format(time.RFC3339)
+			if err := putTerminalSessionInfo(t.Info); err != nil {
+				log.Error().Err(err).Msg("")
+			}
+			log.Info().Msgf("[Terminal] closed %s", t.Info.SessionId)
+		}
+	})
+	return nil
+}
+
+// putTerminalSessionInfo is func to persist a terminal session info
+func putTerminalSessionInfo(info TerminalSessionInfo) error {
+	val, err := json.Marshal(info)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genTerminalSessionKey(info.NsId, info.SessionId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetTerminalSession is func to get a terminal session info
+func GetTerminalSession(nsId string, sessionId string) (TerminalSessionInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return TerminalSessionInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genTerminalSessionKey(nsId, sessionId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return TerminalSessionInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The terminal session " + sessionId + " does not exist.")
+		return TerminalSessionInfo{}, err
+	}
+
+	info := TerminalSessionInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &info)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return TerminalSessionInfo{}, err
+	}
+	return info, nil
+}
+
+// ListTerminalSession is func to list terminal sessions in a namespace (optionally filtered by MCIS and requester)
+func ListTerminalSession(nsId string, mcisId string, requestedBy string) (TerminalSessionInfoList, error) {
+
+	result := TerminalSessionInfoList{Sessions: []TerminalSessionInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/terminalSession"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	for _, v := range keyValue {
+		info := TerminalSessionInfo{}
+		err = json.Unmarshal([]byte(v.Value), &info)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		if mcisId != "" && info.McisId != mcisId {
+			continue
+		}
+		if requestedBy != "" && info.RequestedBy != requestedBy {
+			continue
+		}
+		result.Sessions = append(result.Sessions, info)
+	}
+	return result, nil
+}
+
+// asciicastRecorder is a writer for asciicast v2 recordings (https://docs.asciinema.org/manual/asciicast/v2/)
+type asciicastRecorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	writer  *bufio.Writer
+	start   time.Time
+	pending []byte
+}
+
+// newAsciicastRecorder is func to create a recording file with the asciicast v2 header
+func newAsciicastRecorder(fileName string, cols int, rows int, title string) (*asciicastRecorder, error) {
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	r := &asciicastRecorder{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		start:  time.Now(),
+	}
+
+	header := map[string]interface{}{
+		"version":   2,
+		"width":     cols,
+		"height":    rows,
+		"timestamp": r.start.Unix(),
+		"title":     title,
+		"env":       map[string]string{"TERM": "xterm-256color"},
+	}
+	line, _ := json.Marshal(header)
+	r.writer.Write(line)
+	r.writer.WriteString("\n")
+	return r, nil
+}
+
+// event is func to write an event line ([time, code, data])
+func (r *asciicastRecorder) event(code string, data string) {
+	line, _ := json.Marshal([]interface{}{time.Since(r.start).Seconds(), code, data})
+	r.writer.Write(line)
+	r.writer.WriteString("\n")
+	r.writer.Flush()
+}
+
+// output is func to record output data (an incomplete UTF-8 sequence is kept for the next event)
+func (r *asciicastRecorder) output(p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.pending, p...)
+	cut := len(data)
+	for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				cut = len(data) - i
+			}
+			break
+		}
+	}
+	r.pending = append([]byte{}, data[cut:]...)
+	if cut > 0 {
+		r.event("o", string(data[:cut]))
+	}
+}
+
+// resize is func to record a resize event
+func (r *asciicastRecorder) resize(cols int, rows int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// close is func to flush and close the recording file
+func (r *asciicastRecorder) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) > 0 {
+		r.event("o", string(r.pending))
+		r.pending = nil
+	}
+	r.writer.Flush()
+	r.file.Close()
+}
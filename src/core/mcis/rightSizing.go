@@ -0,0 +1,885 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+// Action of a right-sizing recommendation
+const (
+	// RightSizingActionKeep is const for keeping the current spec.
+	RightSizingActionKeep string = "keep"
+
+	// RightSizingActionDownsize is const for changing to a smaller (cheaper) spec.
+	RightSizingActionDownsize string = "downsize"
+
+	// RightSizingActionUpsize is const for changing to a larger spec.
+	RightSizingActionUpsize string = "upsize"
+)
+
+// Status of a spec change
+const (
+	// SpecChangeStatusPending is const for "Pending" status of a spec change step.
+	SpecChangeStatusPending string = "Pending"
+
+	// SpecChangeStatusRunning is const for "Running" status of a spec change or step.
+	SpecChangeStatusRunning string = "Running"
+
+	// SpecChangeStatusSucceeded is const for "Succeeded" status of a spec change or step.
+	SpecChangeStatusSucceeded string = "Succeeded"
+
+	// SpecChangeStatusFailed is const for "Failed" status of a spec change or step.
+	SpecChangeStatusFailed string = "Failed"
+
+	// SpecChangeStatusSkipped is const for "Skipped" status of a spec change step (not processed after a failure).
+	SpecChangeStatusSkipped string = "Skipped"
+
+	// SpecChangeStatusInterrupted is const for "Interrupted" status of a spec change.
+	// (the spec change was running when CB-Tumblebug stopped)
+	SpecChangeStatusInterrupted string = "Interrupted"
+)
+
+// hoursPerMonth is the number of hours to estimate a monthly cost
+const hoursPerMonth float64 = 730
+
+// rightSizingMaxCandidates is the number of candidate specs to keep in a recommendation
+const rightSizingMaxCandidates int = 3
+
+// RightSizingReq is struct for the request of right-sizing recommendations
+type RightSizingReq struct {
+	// SubGroupId is to get the recommendation only for the subGroup (optional)
+	SubGroupId string `json:"subGroupId" example:"g1"`
+	// Source is the source of monitoring data (default: agentless if enabled for the MCIS, otherwise dragonfly)
+	Source string `json:"source" example:"agentless" enums:"agentless,dragonfly"`
+	// WindowHours is the monitoring window to evaluate utilization (default: 24)
+	WindowHours int `json:"windowHours" example:"24"`
+	// LowUtilization is the peak utilization (%) under which a spec is regarded as oversized (default: 30)
+	LowUtilization float64 `json:"lowUtilization" example:"30"`
+	// HighUtilization is the peak utilization (%) over which a spec is regarded as undersized (default: 80)
+	HighUtilization float64 `json:"highUtilization" example:"80"`
+	// TargetUtilization is the peak utilization (%) expected with the recommended spec (default: 60)
+	TargetUtilization float64 `json:"targetUtilization" example:"60"`
+}
+
+// VmUtilization is struct for the utilization of a VM in the monitoring window
+type VmUtilization struct {
+	VmId string `json:"vmId" example:"g1-1"`
+	// CpuAvg and CpuPeak are the average and 95th percentile of CPU utilization (%)
+	CpuAvg  float64 `json:"cpuAvg" example:"12.5"`
+	CpuPeak float64 `json:"cpuPeak" example:"20.1"`
+	// MemAvg and MemPeak are the average and 95th percentile of memory utilization (%)
+	MemAvg  float64 `json:"memAvg" example:"35.2"`
+	MemPeak float64 `json:"memPeak" example:"40.3"`
+	// Samples is the number of data points in the window (0 if only on-demand monitoring data is used)
+	Samples int    `json:"samples" example:"288"`
+	Err     string `json:"err,omitempty"`
+}
+
+// RightSizingSpec is struct for a spec in a right-sizing recommendation
+type RightSizingSpec struct {
+	SpecId         string  `json:"specId" example:"aws-ap-northeast-2-t3-small"`
+	ConnectionName string  `json:"connectionName" example:"aws-ap-northeast-2"`
+	CspSpecName    string  `json:"cspSpecName" example:"t3.small"`
+	VCPU           uint16  `json:"vCPU" example:"2"`
+	MemoryGiB      float32 `json:"memoryGiB" example:"2"`
+	// CostPerHour is the cost of the spec for the market of VMs (0 if unknown)
+	CostPerHour float32 `json:"costPerHour" example:"0.026"`
+}
+
+// SubGroupRightSizing is struct for the right-sizing recommendation of a subGroup
+type SubGroupRightSizing struct {
+	SubGroupId string `json:"subGroupId" example:"g1"`
+	// Action is the recommended action (keep, downsize, upsize)
+	Action string `json:"action" example:"downsize" enums:"keep,downsize,upsize"`
+	Reason string `json:"reason" example:"CPU peak 20.1% or memory peak 40.3% is under 30%"`
+	// MarketType is the market of VMs in the subGroup (the cost is evaluated for the market)
+	MarketType string `json:"marketType,omitempty" example:"spot"`
+
+	CurrentSpec     RightSizingSpec  `json:"currentSpec"`
+	RecommendedSpec *RightSizingSpec `json:"recommendedSpec,omitempty"`
+	// Candidates are the alternatives for the action (ordered by cost)
+	Candidates []RightSizingSpec `json:"candidates,omitempty"`
+
+	// RunningVmCount is the number of running VMs evaluated in the subGroup
+	RunningVmCount int `json:"runningVmCount" example:"3"`
+	// CpuPeak and MemPeak are the max of VM peaks (%) in the subGroup
+	CpuPeak float64 `json:"cpuPeak" example:"20.1"`
+	MemPeak float64 `json:"memPeak" example:"40.3"`
+
+	// MonthlyCostCurrent, MonthlyCostRecommended are the estimated monthly costs of running VMs (CostPerHour x 730h)
+	MonthlyCostCurrent     float64 `json:"monthlyCostCurrent" example:"113.88"`
+	MonthlyCostRecommended float64 `json:"monthlyCostRecommended" example:"56.94"`
+	// EstimatedMonthlySavings is MonthlyCostCurrent - MonthlyCostRecommended (negative for upsize)
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings" example:"56.94"`
+
+	Vms []VmUtilization `json:"vms"`
+}
+
+// RightSizingResult is struct for right-sizing recommendations of an MCIS
+type RightSizingResult struct {
+	NsId        string `json:"nsId" example:"ns01"`
+	McisId      string `json:"mcisId" example:"mcis01"`
+	Source      string `json:"source" example:"agentless"`
+	Start       string `json:"start" example:"2024-03-01T00:00:00Z"`
+	End         string `json:"end" example:"2024-03-02T00:00:00Z"`
+	WindowHours int    `json:"windowHours" example:"24"`
+
+	SubGroups []SubGroupRightSizing `json:"subGroups"`
+	// TotalEstimatedMonthlySavings is the sum of savings by all recommendations
+	TotalEstimatedMonthlySavings float64 `json:"totalEstimatedMonthlySavings" example:"56.94"`
+}
+
+// RightSizingApplyReq is struct for the request to apply a right-sizing recommendation by a rolling spec change
+type RightSizingApplyReq struct {
+	RightSizingReq
+	// TargetSpecId is the spec to change to (optional, the recommended spec of the subGroup is used if empty)
+	TargetSpecId string `json:"targetSpecId" example:"aws-ap-northeast-2-t3-small"`
+}
+
+// SpecChangeStep is struct for the replacement of a VM in a rolling spec change
+type SpecChangeStep struct {
+	OldVmId      string `json:"oldVmId" example:"g1-1"`
+	NewVmId      string `json:"newVmId,omitempty" example:"g1-4"`
+	Status       string `json:"status" example:"Succeeded"`
+	StartedTime  string `json:"startedTime,omitempty" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string `json:"finishedTime,omitempty" example:"2024-01-01T00:05:00Z"`
+	Error        string `json:"error,omitempty"`
+}
+
+// SpecChangeInfo is struct for a rolling spec change of a subGroup
+// (VMs are replaced one by one: a VM with the target spec is added to the subGroup, and then the old VM is terminated)
+type SpecChangeInfo struct {
+	ChangeId     string `json:"changeId" example:"cq2a3b4c5d6e7f8g9h0i"`
+	NsId         string `json:"nsId" example:"ns01"`
+	McisId       string `json:"mcisId" example:"mcis01"`
+	SubGroupId   string `json:"subGroupId" example:"g1"`
+	FromSpecId   string `json:"fromSpecId" example:"aws-ap-northeast-2-t3-medium"`
+	ToSpecId     string `json:"toSpecId" example:"aws-ap-northeast-2-t3-small"`
+	Status       string `json:"status" example:"Running"`
+	StartedTime  string `json:"startedTime" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string `json:"finishedTime,omitempty" example:"2024-01-01T00:20:00Z"`
+
+	EstimatedMonthlySavings float64          `json:"estimatedMonthlySavings" example:"56.94"`
+	Steps                   []SpecChangeStep `json:"steps"`
+}
+
+// SpecChangeInfoList is struct for a list of spec changes
+type SpecChangeInfoList struct {
+	SpecChanges []SpecChangeInfo `json:"specChanges"`
+}
+
+// specChangeRunning is map for running spec changes (key: nsId/mcisId/subGroupId, value: changeId)
+var specChangeRunning sync.Map
+
+// genSpecChangeKey is func to generate a key for a spec change
+func genSpecChangeKey(nsId string, changeId string) string {
+	return "/ns/" + nsId + "/specChange/" + changeId
+}
+
+// setRightSizingReqDefault is func to validate a right-sizing request and to fill default values
+func setRightSizingReqDefault(req *RightSizingReq) error {
+	if req.WindowHours == 0 {
+		req.WindowHours = 24
+	}
+	if req.LowUtilization == 0 {
+		req.LowUtilization = 30
+	}
+	if req.HighUtilization == 0 {
+		req.HighUtilization = 80
+	}
+	if req.TargetUtilization == 0 {
+		req.TargetUtilization = 60
+	}
+	if req.WindowHours < 1 {
+		return fmt.Errorf("windowHours should be a positive number")
+	}
+	if !(0 < req.LowUtilization && req.LowUtilization < req.TargetUtilization &&
+		req.TargetUtilization < req.HighUtilization && req.HighUtilization <= 100) {
+		return fmt.Errorf("0 < lowUtilization < targetUtilization < highUtilization <= 100 is required")
+	}
+	return nil
+}
+
+// GetRightSizing is func to recommend smaller or larger specs for subGroups of an MCIS
+// by the utilization of running VMs in a monitoring window and the spec catalog
+func GetRightSizing(nsId string, mcisId string, req *RightSizingReq) (RightSizingResult, error) {
+
+	result := RightSizingResult{}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	check, _ := CheckMcis(nsId, mcisId)
+	if !check {
+		err := fmt.Errorf("The mcis " + mcisId + " does not exist.")
+		return result, err
+	}
+	err = setRightSizingReqDefault(req)
+	if err != nil {
+		return result, err
+	}
+
+	var subGroupList []string
+	if req.SubGroupId != "" {
+		subGroupList = []string{req.SubGroupId}
+	} else {
+		subGroupList, err = ListSubGroupId(nsId, mcisId)
+		if err != nil {
+			return result, err
+		}
+	}
+	sort.Strings(subGroupList)
+
+	end := time.Now()
+	start := end.Add(-time.Duration(req.WindowHours) * time.Hour)
+	// keep about 1000 points per VM at most
+	stepSec := int(math.Max(60, math.Ceil(end.Sub(start).Seconds()/1000)))
+
+	utilization, source, err := getVmUtilization(nsId, mcisId, req, start, end, stepSec)
+	if err != nil {
+		return result, err
+	}
+
+	result.NsId = nsId
+	result.McisId = mcisId
+	result.Source = source
+	result.Start = start.UTC().Format(time.RFC3339)
+	result.End = end.UTC().Format(time.RFC3339)
+	result.WindowHours = req.WindowHours
+	result.SubGroups = []SubGroupRightSizing{}
+
+	for _, subGroupId := range subGroupList {
+		recommendation, err := getSubGroupRightSizing(nsId, mcisId, subGroupId, req, utilization)
+		if err != nil {
+			log.Warn().Err(err).Msgf("[RightSizing] skip the subGroup %s/%s/%s", nsId, mcisId, subGroupId)
+			continue
+		}
+		result.SubGroups = append(result.SubGroups, recommendation)
+		if recommendation.Action != RightSizingActionKeep {
+			result.TotalEstimatedMonthlySavings += recommendation.EstimatedMonthlySavings
+		}
+	}
+	result.TotalEstimatedMonthlySavings = roundCost(result.TotalEstimatedMonthlySavings)
+
+	return result, nil
+}
+
+// getVmUtilization is func to get cpu and mem utilization of VMs in the window from monitoring history.
+// For VMs without history, on-demand monitoring data (GetMonitoringData) is used as a single sample.
+func getVmUtilization(nsId string, mcisId string, req *RightSizingReq, start time.Time, end time.Time, stepSec int) (map[string]*VmUtilization, string, error) {
+
+	utilization := map[string]*VmUtilization{}
+	source := ""
+	missing := map[string]map[string]bool{}
+
+	for _, metric := range []string{monMetricCpu, monMetricMem} {
+		history, err := GetMonitoringHistory(nsId, mcisId, &MonitoringHistoryReq{
+			Metric:      metric,
+			Source:      req.Source,
+			Start:       start.UTC().Format(time.RFC3339),
+			End:         end.UTC().Format(time.RFC3339),
+			StepSec:     stepSec,
+			Aggregation: monAggregationAvg,
+			SubGroupId:  req.SubGroupId,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		source = history.Source
+		missing[metric] = map[string]bool{}
+
+		for _, series := range history.VmSeries {
+			u, ok := utilization[series.VmId]
+			if !ok {
+				u = &VmUtilization{VmId: series.VmId}
+				utilization[series.VmId] = u
+			}
+			if len(series.Points) == 0 {
+				missing[metric][series.VmId] = true
+				if series.Err != "" {
+					u.Err = series.Err
+				}
+				continue
+			}
+			values := make([]float64, len(series.Points))
+			for i, p := range series.Points {
+				values[i] = p.Value
+			}
+			avg := aggregateMetricValues(values, monAggregationAvg)
+			peak := percentileFloat64(values, 95)
+			if metric == monMetricCpu {
+				u.CpuAvg, u.CpuPeak = roundCost(avg), roundCost(peak)
+			} else {
+				u.MemAvg, u.MemPeak = roundCost(avg), roundCost(peak)
+			}
+			if len(values) > u.Samples {
+				u.Samples = len(values)
+			}
+		}
+
+		if len(missing[metric]) == 0 {
+			continue
+		}
+		onDemand, err := GetMonitoringData(nsId, mcisId, metric)
+		if err != nil {
+			log.Warn().Err(err).Msg("[RightSizing] failed to get on-demand monitoring data")
+			continue
+		}
+		for _, v := range onDemand.McisMonitoring {
+			if !missing[metric][v.VmId] || v.Err != "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(v.Value, 64)
+			if err != nil {
+				continue
+			}
+			u := utilization[v.VmId]
+			if metric == monMetricCpu {
+				u.CpuAvg, u.CpuPeak = roundCost(value), roundCost(value)
+			} else {
+				u.MemAvg, u.MemPeak = roundCost(value), roundCost(value)
+			}
+			delete(missing[metric], v.VmId)
+		}
+	}
+
+	// VMs without any data for a metric are not evaluated
+	for metric, vmIds := range missing {
+		for vmId := range vmIds {
+			u := utilization[vmId]
+			if u.Err == "" {
+				u.Err = "no " + metric + " monitoring data in the window"
+			}
+		}
+	}
+	return utilization, source, nil
+}
+
+// getSubGroupRightSizing is func to recommend a spec for a subGroup by the peak utilization of its running VMs
+func getSubGroupRightSizing(nsId string, mcisId string, subGroupId string, req *RightSizingReq, utilization map[string]*VmUtilization) (SubGroupRightSizing, error) {
+
+	recommendation := SubGroupRightSizing{SubGroupId: subGroupId, Action: RightSizingActionKeep, Vms: []VmUtilization{}}
+
+	vmIdList, err := ListVmBySubGroup(nsId, mcisId, subGroupId)
+	if err != nil {
+		return recommendation, err
+	}
+	sort.Strings(vmIdList)
+
+	var specId, marketType string
+	evaluated := 0
+	for _, vmId := range vmIdList {
+		vm, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil || vm.Status != StatusRunning {
+			continue
+		}
+		if specId == "" {
+			specId = vm.SpecId
+			marketType = vm.Market.MarketType
+			recommendation.MarketType = marketType
+		}
+		recommendation.RunningVmCount++
+
+		u, ok := utilization[vmId]
+		if !ok {
+			u = &VmUtilization{VmId: vmId, Err: "no monitoring data"}
+		}
+		recommendation.Vms = append(recommendation.Vms, *u)
+		if u.Err != "" {
+			continue
+		}
+		evaluated++
+		recommendation.CpuPeak = math.Max(recommendation.CpuPeak, u.CpuPeak)
+		recommendation.MemPeak = math.Max(recommendation.MemPeak, u.MemPeak)
+	}
+	if recommendation.RunningVmCount == 0 {
+		return recommendation, fmt.Errorf("no running VM in the subGroup %s", subGroupId)
+	}
+
+	currentSpec, err := getSpecInNsOrCommon(nsId, specId)
+	if err != nil {
+		return recommendation, err
+	}
+	recommendation.CurrentSpec = toRightSizingSpec(currentSpec, marketType)
+	vmCount := float64(recommendation.RunningVmCount)
+	recommendation.MonthlyCostCurrent = roundCost(float64(recommendation.CurrentSpec.CostPerHour) * hoursPerMonth * vmCount)
+	recommendation.MonthlyCostRecommended = recommendation.MonthlyCostCurrent
+
+	if evaluated == 0 {
+		recommendation.Reason = "no monitoring data of running VMs in the window"
+		return recommendation, nil
+	}
+
+	cpuPeak, memPeak := recommendation.CpuPeak, recommendation.MemPeak
+	switch {
+	case cpuPeak > req.HighUtilization || memPeak > req.HighUtilization:
+		recommendation.Action = RightSizingActionUpsize
+		recommendation.Reason = fmt.Sprintf("CPU peak %.1f%% or memory peak %.1f%% is over %.0f%%", cpuPeak, memPeak, req.HighUtilization)
+	case cpuPeak < req.LowUtilization || memPeak < req.LowUtilization:
+		recommendation.Action = RightSizingActionDownsize
+		recommendation.Reason = fmt.Sprintf("CPU peak %.1f%% or memory peak %.1f%% is under %.0f%%", cpuPeak, memPeak, req.LowUtilization)
+	default:
+		recommendation.Reason = fmt.Sprintf("CPU peak %.1f%% and memory peak %.1f%% are between %.0f%% and %.0f%%", cpuPeak, memPeak, req.LowUtilization, req.HighUtilization)
+		return recommendation, nil
+	}
+
+	// resources required to keep the peak utilization around the target
+	requiredVCPU := math.Max(1, math.Ceil(float64(currentSpec.VCPU)*cpuPeak/req.TargetUtilization))
+	requiredMemoryGiB := float64(currentSpec.MemoryGiB) * memPeak / req.TargetUtilization
+
+	candidates, err := getRightSizingCandidates(currentSpec, marketType, recommendation.Action, requiredVCPU, requiredMemoryGiB)
+	if err != nil {
+		return recommendation, err
+	}
+	if len(candidates) == 0 {
+		recommendation.Reason += ", but no proper spec is found in the same connection"
+		recommendation.Action = RightSizingActionKeep
+		return recommendation, nil
+	}
+	if len(candidates) > rightSizingMaxCandidates {
+		candidates = candidates[:rightSizingMaxCandidates]
+	}
+	recommendation.Candidates = candidates
+	recommendation.RecommendedSpec = &candidates[0]
+	recommendation.MonthlyCostRecommended = roundCost(float64(candidates[0].CostPerHour) * hoursPerMonth * vmCount)
+	if recommendation.CurrentSpec.CostPerHour > 0 && candidates[0].CostPerHour > 0 {
+		recommendation.EstimatedMonthlySavings = roundCost(recommendation.MonthlyCostCurrent - recommendation.MonthlyCostRecommended)
+	}
+
+	return recommendation, nil
+}
+
+// getRightSizingCandidates is func to find specs in the connection of the current spec
+// which satisfy the required resources for the action (ordered by cost, unknown cost last)
+func getRightSizingCandidates(currentSpec mcir.TbSpecInfo, marketType string, action string, requiredVCPU float64, requiredMemoryGiB float64) ([]RightSizingSpec, error) {
+
+	filter := mcir.FilterSpecsByRangeRequest{
+		ConnectionName: currentSpec.ConnectionName,
+		VCPU:           mcir.Range{Min: float32(requiredVCPU)},
+		MemoryGiB:      mcir.Range{Min: float32(requiredMemoryGiB)},
+	}
+	if action == RightSizingActionDownsize {
+		filter.VCPU.Max = float32(currentSpec.VCPU)
+		filter.MemoryGiB.Max = currentSpec.MemoryGiB
+	}
+	specList, err := mcir.FilterSpecsByRange(common.SystemCommonNs, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	currentCost := getSpecCostPerHour(currentSpec, marketType)
+	now := time.Now()
+	candidates := []RightSizingSpec{}
+	for _, spec := range specList {
+		if spec.Id == currentSpec.Id || spec.CspSpecName == currentSpec.CspSpecName {
+			continue
+		}
+		// FilterSpecsByRange matches names partially
+		if spec.ConnectionName != currentSpec.ConnectionName {
+			continue
+		}
+		if spec.AcceleratorCount != currentSpec.AcceleratorCount {
+			continue
+		}
+		if getSpecAvailabilityScore(spec.ConnectionName, spec.CspSpecName, now) < specAvailabilityThreshold {
+			continue
+		}
+		cost := getSpecCostPerHour(spec, marketType)
+		switch action {
+		case RightSizingActionDownsize:
+			if spec.VCPU == currentSpec.VCPU && spec.MemoryGiB == currentSpec.MemoryGiB {
+				continue
+			}
+			if currentCost > 0 && cost > 0 && cost >= currentCost {
+				continue
+			}
+		case RightSizingActionUpsize:
+			if spec.VCPU <= currentSpec.VCPU && spec.MemoryGiB <= currentSpec.MemoryGiB {
+				continue
+			}
+		}
+		candidates = append(candidates, toRightSizingSpec(spec, marketType))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i].CostPerHour, candidates[j].CostPerHour
+		if (ci > 0) != (cj > 0) {
+			return ci > 0
+		}
+		if ci != cj {
+			return ci < cj
+		}
+		if candidates[i].VCPU != candidates[j].VCPU {
+			return candidates[i].VCPU < candidates[j].VCPU
+		}
+		if candidates[i].MemoryGiB != candidates[j].MemoryGiB {
+			return candidates[i].MemoryGiB < candidates[j].MemoryGiB
+		}
+		return candidates[i].SpecId < candidates[j].SpecId
+	})
+	return candidates, nil
+}
+
+// getSpecInNsOrCommon is func to get a spec in a namespace (or the common namespace, as in CreateVm)
+func getSpecInNsOrCommon(nsId string, specId string) (mcir.TbSpecInfo, error) {
+	specInfo, err := getSpecForBenchmark(nsId, specId)
+	if err != nil {
+		specInfo, err = getSpecForBenchmark(common.SystemCommonNs, specId)
+	}
+	return specInfo, err
+}
+
+// toRightSizingSpec is func to summarize a spec with the cost for the market
+func toRightSizingSpec(spec mcir.TbSpecInfo, marketType string) RightSizingSpec {
+	return RightSizingSpec{
+		SpecId:         spec.Id,
+		ConnectionName: spec.ConnectionName,
+		CspSpecName:    spec.CspSpecName,
+		VCPU:           spec.VCPU,
+		MemoryGiB:      spec.MemoryGiB,
+		CostPerHour:    getSpecCostPerHour(spec, marketType),
+	}
+}
+
+// percentileFloat64 is func to get the p-th percentile (nearest rank) of values
+func percentileFloat64(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// roundCost is func to round a value to 2 decimal places
+func roundCost(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ApplyRightSizing is func to start a rolling spec change of a subGroup to the target (or recommended) spec
+func ApplyRightSizing(nsId string, mcisId string, req *RightSizingApplyReq) (SpecChangeInfo, error) {
+
+	if req.SubGroupId == "" {
+		return SpecChangeInfo{}, fmt.Errorf("subGroupId is required")
+	}
+
+	recommendation, err := GetRightSizing(nsId, mcisId, &req.RightSizingReq)
+	if err != nil {
+		return SpecChangeInfo{}, err
+	}
+	if len(recommendation.SubGroups) == 0 {
+		err := fmt.Errorf("No running VM to change the spec in the subGroup " + req.SubGroupId)
+		return SpecChangeInfo{}, err
+	}
+	subGroup := recommendation.SubGroups[0]
+
+	targetSpecId := req.TargetSpecId
+	savings := subGroup.EstimatedMonthlySavings
+	if targetSpecId == "" {
+		if subGroup.RecommendedSpec == nil {
+			err := fmt.Errorf("No spec change is recommended for the subGroup %s (%s)", req.SubGroupId, subGroup.Reason)
+			return SpecChangeInfo{}, err
+		}
+		targetSpecId = subGroup.RecommendedSpec.SpecId
+	} else {
+		targetSpec, err := getSpecInNsOrCommon(nsId, targetSpecId)
+		if err != nil {
+			return SpecChangeInfo{}, err
+		}
+		if targetSpec.ConnectionName != subGroup.CurrentSpec.ConnectionName {
+			err := fmt.Errorf("The spec %s is not in the connection of the subGroup %s (%s)", targetSpecId, req.SubGroupId, subGroup.CurrentSpec.ConnectionName)
+			return SpecChangeInfo{}, err
+		}
+		savings = 0
+		if cost := float64(getSpecCostPerHour(targetSpec, subGroup.MarketType)); subGroup.CurrentSpec.CostPerHour > 0 && cost > 0 {
+			savings = roundCost(subGroup.MonthlyCostCurrent - cost*hoursPerMonth*float64(subGroup.RunningVmCount))
+		}
+	}
+	return StartSpecChange(nsId, mcisId, req.SubGroupId, targetSpecId, savings)
+}
+
+// StartSpecChange is func to start a rolling spec change of VMs in a subGroup in background
+func StartSpecChange(nsId string, mcisId string, subGroupId string, targetSpecId string, estimatedMonthlySavings float64) (SpecChangeInfo, error) {
+
+	vmIdList, err := ListVmBySubGroup(nsId, mcisId, subGroupId)
+	if err != nil {
+		return SpecChangeInfo{}, err
+	}
+	sort.Strings(vmIdList)
+
+	change := SpecChangeInfo{
+		ChangeId:                common.GenUid(),
+		NsId:                    nsId,
+		McisId:                  mcisId,
+		SubGroupId:              subGroupId,
+		ToSpecId:                targetSpecId,
+		Status:                  SpecChangeStatusRunning,
+		StartedTime:             time.Now().UTC().Format(time.RFC3339),
+		EstimatedMonthlySavings: estimatedMonthlySavings,
+		Steps:                   []SpecChangeStep{},
+	}
+	for _, vmId := range vmIdList {
+		vm, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil || vm.Status != StatusRunning || vm.SpecId == targetSpecId {
+			continue
+		}
+		if change.FromSpecId == "" {
+			change.FromSpecId = vm.SpecId
+		}
+		change.Steps = append(change.Steps, SpecChangeStep{OldVmId: vmId, Status: SpecChangeStatusPending})
+	}
+	if len(change.Steps) == 0 {
+		err := fmt.Errorf("No running VM to change to the spec %s in the subGroup %s", targetSpecId, subGroupId)
+		return SpecChangeInfo{}, err
+	}
+
+	runningKey := nsId + "/" + mcisId + "/" + subGroupId
+	if changeId, loaded := specChangeRunning.LoadOrStore(runningKey, change.ChangeId); loaded {
+		err := fmt.Errorf("The spec change %v is running for the subGroup %s", changeId, subGroupId)
+		return SpecChangeInfo{}, err
+	}
+	err = putSpecChange(change)
+	if err != nil {
+		specChangeRunning.Delete(runningKey)
+		return SpecChangeInfo{}, err
+	}
+
+	go runSpecChange(change)
+
+	log.Info().Msgf("[SpecChange] %s/%s/%s to %s (%s)", nsId, mcisId, subGroupId, targetSpecId, change.ChangeId)
+	return change, nil
+}
+
+// runSpecChange is func to replace VMs of a spec change one by one (stops at the first failure)
+func runSpecChange(change SpecChangeInfo) {
+
+	defer specChangeRunning.Delete(change.NsId + "/" + change.McisId + "/" + change.SubGroupId)
+
+	change.Status = SpecChangeStatusSucceeded
+	for i := range change.Steps {
+		step := &change.Steps[i]
+		if change.Status == SpecChangeStatusFailed {
+			step.Status = SpecChangeStatusSkipped
+			continue
+		}
+		step.Status = SpecChangeStatusRunning
+		step.StartedTime = time.Now().UTC().Format(time.RFC3339)
+		if err := putSpecChange(change); err != nil {
+			log.Error().Err(err).Msg("")
+		}
+
+		newVmId, err := replaceVmWithSpec(change.NsId, change.McisId, step.OldVmId, change.ToSpecId)
+		step.NewVmId = newVmId
+		step.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+		if err != nil {
+			log.Error().Err(err).Msgf("[SpecChange] %s failed to replace %s", change.ChangeId, step.OldVmId)
+			step.Status = SpecChangeStatusFailed
+			step.Error = err.Error()
+			change.Status = SpecChangeStatusFailed
+		} else {
+			log.Info().Msgf("[SpecChange] %s replaced %s by %s", change.ChangeId, step.OldVmId, newVmId)
+			step.Status = SpecChangeStatusSucceeded
+		}
+	}
+
+	change.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+	if err := putSpecChange(change); err != nil {
+		log.Error().Err(err).Msg("")
+	}
+	log.Info().Msgf("[SpecChange] %s/%s %s", change.NsId, change.ChangeId, change.Status)
+}
+
+// replaceVmWithSpec is func to add a VM with the spec to the subGroup of a VM (scale-out with the VM as a template)
+// and to terminate the VM after the new VM is running
+func replaceVmWithSpec(nsId string, mcisId string, vmId string, specId string) (string, error) {
+
+	vmObj, err := GetVmObject(nsId, mcisId, vmId)
+	if err != nil {
+		return "", err
+	}
+	vmIdsBefore, err := ListVmBySubGroup(nsId, mcisId, vmObj.SubGroupId)
+	if err != nil {
+		return "", err
+	}
+
+	vmTemplate := &TbVmReq{}
+	vmTemplate.Name = vmObj.SubGroupId
+	vmTemplate.ConnectionName = vmObj.ConnectionName
+	vmTemplate.ImageId = vmObj.ImageId
+	vmTemplate.SpecId = specId
+	vmTemplate.VNetId = vmObj.VNetId
+	vmTemplate.SubnetId = vmObj.SubnetId
+	vmTemplate.SecurityGroupIds = vmObj.SecurityGroupIds
+	vmTemplate.SshKeyId = vmObj.SshKeyId
+	vmTemplate.VmUserAccount = vmObj.VmUserAccount
+	vmTemplate.VmUserPassword = vmObj.VmUserPassword
+	vmTemplate.RootDiskType = vmObj.RootDiskType
+	vmTemplate.RootDiskSize = vmObj.RootDiskSize
+	vmTemplate.Market = vmObj.Market
+	vmTemplate.Description = vmObj.Description
+	vmTemplate.SubGroupSize = "1"
+
+	_, err = CreateMcisGroupVm(nsId, mcisId, vmTemplate, true)
+	if err != nil {
+		return "", err
+	}
+
+	vmIdsAfter, err := ListVmBySubGroup(nsId, mcisId, vmObj.SubGroupId)
+	if err != nil {
+		return "", err
+	}
+	existing := map[string]bool{}
+	for _, v := range vmIdsBefore {
+		existing[v] = true
+	}
+	newVmId := ""
+	for _, v := range vmIdsAfter {
+		if !existing[v] {
+			newVmId = v
+		}
+	}
+	if newVmId == "" {
+		return "", fmt.Errorf("no VM is added to the subGroup %s", vmObj.SubGroupId)
+	}
+
+	newVm, err := GetVmObject(nsId, mcisId, newVmId)
+	if err != nil {
+		return newVmId, err
+	}
+	if newVm.Status != StatusRunning {
+		// keep the old VM to serve
+		return newVmId, fmt.Errorf("the new VM %s is not running (%s: %s), the VM %s is kept", newVmId, newVm.Status, newVm.SystemMessage, vmId)
+	}
+
+	err = DelMcisVm(nsId, mcisId, vmId, "")
+	if err != nil {
+		return newVmId, fmt.Errorf("the new VM %s is running, but failed to terminate the VM %s: %s", newVmId, vmId, err.Error())
+	}
+	return newVmId, nil
+}
+
+// putSpecChange is func to persist a spec change
+func putSpecChange(change SpecChangeInfo) error {
+	val, err := json.Marshal(change)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genSpecChangeKey(change.NsId, change.ChangeId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// isSpecChangeRunning is func to check whether a spec change is running in this CB-Tumblebug process
+func isSpecChangeRunning(change SpecChangeInfo) bool {
+	changeId, ok := specChangeRunning.Load(change.NsId + "/" + change.McisId + "/" + change.SubGroupId)
+	return ok && changeId == change.ChangeId
+}
+
+// GetSpecChange is func to get a spec change with the states of VM replacements
+func GetSpecChange(nsId string, changeId string) (SpecChangeInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SpecChangeInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genSpecChangeKey(nsId, changeId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SpecChangeInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The spec change " + changeId + " does not exist.")
+		return SpecChangeInfo{}, err
+	}
+
+	change := SpecChangeInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &change)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SpecChangeInfo{}, err
+	}
+	if change.Status == SpecChangeStatusRunning && !isSpecChangeRunning(change) {
+		change.Status = SpecChangeStatusInterrupted
+	}
+	return change, nil
+}
+
+// ListSpecChange is func to list spec changes in a namespace (optionally filtered by MCIS)
+func ListSpecChange(nsId string, mcisId string) (SpecChangeInfoList, error) {
+
+	result := SpecChangeInfoList{SpecChanges: []SpecChangeInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/specChange"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		change := SpecChangeInfo{}
+		err = json.Unmarshal([]byte(v.Value), &change)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		if mcisId != "" && change.McisId != mcisId {
+			continue
+		}
+		if change.Status == SpecChangeStatusRunning && !isSpecChangeRunning(change) {
+			change.Status = SpecChangeStatusInterrupted
+		}
+		result.SpecChanges = append(result.SpecChanges, change)
+	}
+	sort.Slice(result.SpecChanges, func(i, j int) bool {
+		return result.SpecChanges[i].StartedTime > result.SpecChanges[j].StartedTime
+	})
+	return result, nil
+}
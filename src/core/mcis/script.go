@@ -0,0 +1,663 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ScriptExecStatusRunning is const for "Running" status of a script execution.
+	ScriptExecStatusRunning string = "Running"
+
+	// ScriptExecStatusSucceeded is const for "Succeeded" status of a script execution.
+	// (the script exited with 0 in all target VMs)
+	ScriptExecStatusSucceeded string = "Succeeded"
+
+	// ScriptExecStatusFailed is const for "Failed" status of a script execution.
+	ScriptExecStatusFailed string = "Failed"
+)
+
+// ScriptParameter is struct for a parameter of a script (exported as an environment variable to the script)
+type ScriptParameter struct {
+	Name        string `json:"name" validate:"required" example:"WEB_PORT"`
+	Description string `json:"description" example:"port of the web server"`
+	Required    bool   `json:"required" example:"false"`
+	Default     string `json:"default" example:"80"`
+}
+
+// ScriptReq is struct for a request to create or update a script in the script library of a namespace
+type ScriptReq struct {
+	// Name is required to create a script (ignored to update a script)
+	Name        string `json:"name" example:"setweb"`
+	Description string `json:"description" example:"install and start nginx"`
+	// Content is the script to execute in VMs (a shebang line selects the interpreter, bash by default).
+	// Built-in functions of remote commands (e.g., $$Func(GetPublicIPs(separator=' '))) can be used.
+	Content    string            `json:"content" validate:"required" example:"#!/bin/bash\nsudo apt-get update && sudo apt-get install -y nginx\n"`
+	Parameters []ScriptParameter `json:"parameters"`
+}
+
+// ScriptInfo is struct for a version of a script in the script library of a namespace
+type ScriptInfo struct {
+	Name        string            `json:"name" example:"setweb"`
+	Description string            `json:"description" example:"install and start nginx"`
+	Content     string            `json:"content"`
+	Parameters  []ScriptParameter `json:"parameters"`
+	Version     int               `json:"version" example:"1"`
+	CreatedTime string            `json:"createdTime" example:"2024-01-01T00:00:00Z"`
+	UpdatedTime string            `json:"updatedTime" example:"2024-01-01T00:00:00Z"`
+}
+
+// ScriptInfoList is struct for a list of scripts (or versions of a script)
+type ScriptInfoList struct {
+	Script []ScriptInfo `json:"script"`
+}
+
+// ScriptExecReq is struct for a request to execute a script of the script library to VMs in MCIS
+type ScriptExecReq struct {
+	ScriptName string `json:"scriptName" validate:"required" example:"setweb"`
+	// Version of the script to execute (0: the latest version)
+	Version int `json:"version" validate:"min=0" example:"0" default:"0"`
+	// Params is values of the script parameters (built-in functions of remote commands can be used)
+	Params   map[string]string `json:"params"`
+	UserName string            `json:"userName" example:"cb-user" default:""`
+	SshCmdOption
+}
+
+// ScriptExecutionInfo is struct for a record of a script execution
+type ScriptExecutionInfo struct {
+	ExecutionId  string            `json:"executionId" example:"cq2a3b4c5d6e7f8g9h0i"`
+	NsId         string            `json:"nsId" example:"ns01"`
+	ScriptName   string            `json:"scriptName" example:"setweb"`
+	Version      int               `json:"version" example:"1"`
+	McisId       string            `json:"mcisId" example:"mcis01"`
+	SubGroupId   string            `json:"subGroupId,omitempty" example:"g1"`
+	VmId         string            `json:"vmId,omitempty" example:"g1-1"`
+	UserName     string            `json:"userName,omitempty" example:"cb-user"`
+	Params       map[string]string `json:"params"`
+	Status       string            `json:"status" example:"Succeeded"`
+	StartedTime  string            `json:"startedTime" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string            `json:"finishedTime,omitempty" example:"2024-01-01T00:01:00Z"`
+
+	Results []SshCmdResult `json:"results"`
+	// VmErr is error message for each VM (SshCmdResult.Err is not kept in the record)
+	VmErr map[string]string `json:"vmErr,omitempty"`
+	// Error is the error of the execution request (e.g., no target VM)
+	Error string `json:"error,omitempty"`
+}
+
+// ScriptExecutionInfoList is struct for a list of script executions
+type ScriptExecutionInfoList struct {
+	Executions []ScriptExecutionInfo `json:"executions"`
+}
+
+// genScriptKey is func to generate a key for the latest version of a script
+func genScriptKey(nsId string, scriptName string) string {
+	return "/ns/" + nsId + "/script/" + scriptName
+}
+
+// genScriptVersionKey is func to generate a key for a version of a script
+func genScriptVersionKey(nsId string, scriptName string, version int) string {
+	return genScriptKey(nsId, scriptName) + "/version/" + strconv.Itoa(version)
+}
+
+// genScriptExecutionKey is func to generate a key for a script execution
+func genScriptExecutionKey(nsId string, executionId string) string {
+	return "/ns/" + nsId + "/scriptExecution/" + executionId
+}
+
+// validateScriptReq is func to validate a script request
+func validateScriptReq(u *ScriptReq) error {
+	err := validate.Struct(u)
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, p := range u.Parameters {
+		if !envNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("Invalid parameter name: '%s' (the name is used as an environment variable)", p.Name)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("Duplicated parameter name: '%s'", p.Name)
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
+// CreateScript is func to create a script in the script library of a namespace (version 1)
+func CreateScript(nsId string, u *ScriptReq) (ScriptInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	err = common.CheckString(u.Name)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	err = validateScriptReq(u)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	check, err := common.CheckNs(nsId)
+	if !check {
+		err := fmt.Errorf("The namespace " + nsId + " does not exist.")
+		return ScriptInfo{}, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genScriptKey(nsId, u.Name))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	if keyValue != nil {
+		err := fmt.Errorf("The script " + u.Name + " already exists.")
+		return ScriptInfo{}, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	script := ScriptInfo{
+		Name:        u.Name,
+		Description: u.Description,
+		Content:     u.Content,
+		Parameters:  u.Parameters,
+		Version:     1,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+	err = putScript(nsId, script)
+	if err != nil {
+		return ScriptInfo{}, err
+	}
+	return script, nil
+}
+
+// UpdateScript is func to update a script (a new version is added, and previous versions are kept)
+func UpdateScript(nsId string, scriptName string, u *ScriptReq) (ScriptInfo, error) {
+
+	err := validateScriptReq(u)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+
+	latest, err := GetScript(nsId, scriptName, 0)
+	if err != nil {
+		return ScriptInfo{}, err
+	}
+
+	script := ScriptInfo{
+		Name:        scriptName,
+		Description: u.Description,
+		Content:     u.Content,
+		Parameters:  u.Parameters,
+		Version:     latest.Version + 1,
+		CreatedTime: latest.CreatedTime,
+		UpdatedTime: time.Now().UTC().Format(time.RFC3339),
+	}
+	err = putScript(nsId, script)
+	if err != nil {
+		return ScriptInfo{}, err
+	}
+	return script, nil
+}
+
+// putScript is func to store a version of a script as the latest version
+func putScript(nsId string, script ScriptInfo) error {
+	val, err := json.Marshal(script)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genScriptVersionKey(nsId, script.Name, script.Version), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genScriptKey(nsId, script.Name), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetScript is func to get a version of a script (version 0: the latest version)
+func GetScript(nsId string, scriptName string, version int) (ScriptInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	err = common.CheckString(scriptName)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+
+	key := genScriptKey(nsId, scriptName)
+	if version > 0 {
+		key = genScriptVersionKey(nsId, scriptName, version)
+	}
+	keyValue, err := common.CBStore.Get(key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	if keyValue == nil {
+		if version > 0 {
+			err := fmt.Errorf("The version %d of the script %s does not exist.", version, scriptName)
+			return ScriptInfo{}, err
+		}
+		err := fmt.Errorf("The script " + scriptName + " does not exist.")
+		return ScriptInfo{}, err
+	}
+
+	script := ScriptInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &script)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptInfo{}, err
+	}
+	return script, nil
+}
+
+// ListScript is func to list the latest versions of scripts in a namespace
+func ListScript(nsId string) (ScriptInfoList, error) {
+
+	result := ScriptInfoList{Script: []ScriptInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/script"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		script := ScriptInfo{}
+		err = json.Unmarshal([]byte(v.Value), &script)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		result.Script = append(result.Script, script)
+	}
+	return result, nil
+}
+
+// ListScriptVersion is func to list all versions of a script
+func ListScriptVersion(nsId string, scriptName string) (ScriptInfoList, error) {
+
+	result := ScriptInfoList{Script: []ScriptInfo{}}
+
+	_, err := GetScript(nsId, scriptName, 0)
+	if err != nil {
+		return result, err
+	}
+
+	key := genScriptKey(nsId, scriptName) + "/version"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		script := ScriptInfo{}
+		err = json.Unmarshal([]byte(v.Value), &script)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		result.Script = append(result.Script, script)
+	}
+	sort.Slice(result.Script, func(i, j int) bool {
+		return result.Script[i].Version < result.Script[j].Version
+	})
+	return result, nil
+}
+
+// DelScript is func to delete a script with all versions (execution records are kept)
+func DelScript(nsId string, scriptName string) error {
+
+	_, err := GetScript(nsId, scriptName, 0)
+	if err != nil {
+		return err
+	}
+
+	key := genScriptKey(nsId, scriptName)
+	keyValue, err := common.CBStore.GetList(key+"/version", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	for _, v := range keyValue {
+		err = common.CBStore.Delete(v.Key)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return err
+		}
+	}
+	err = common.CBStore.Delete(key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// ExecScriptToMcis is func to execute a script of the script library to VMs in MCIS (all VMs, VMs in a subGroup, or a VM).
+// The execution is recorded with the results of each VM.
+func ExecScriptToMcis(nsId string, mcisId string, subGroupId string, vmId string, req *ScriptExecReq) (ScriptExecutionInfo, error) {
+
+	err := validate.Struct(req)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptExecutionInfo{}, err
+	}
+
+	script, err := GetScript(nsId, req.ScriptName, req.Version)
+	if err != nil {
+		return ScriptExecutionInfo{}, err
+	}
+
+	params, err := resolveScriptParams(script, req.Params)
+	if err != nil {
+		return ScriptExecutionInfo{}, err
+	}
+
+	execution := ScriptExecutionInfo{
+		ExecutionId: common.GenUid(),
+		NsId:        nsId,
+		ScriptName:  script.Name,
+		Version:     script.Version,
+		McisId:      mcisId,
+		SubGroupId:  subGroupId,
+		VmId:        vmId,
+		UserName:    req.UserName,
+		Params:      req.Params,
+		Status:      ScriptExecStatusRunning,
+		StartedTime: time.Now().UTC().Format(time.RFC3339),
+		Results:     []SshCmdResult{},
+	}
+	err = putScriptExecution(execution)
+	if err != nil {
+		return ScriptExecutionInfo{}, err
+	}
+	log.Info().Msgf("[Script] execute %s (version %d) to %s/%s (%s)", script.Name, script.Version, nsId, mcisId, execution.ExecutionId)
+
+	cmdReq := &McisCmdReq{
+		UserName:     req.UserName,
+		SshCmdOption: req.SshCmdOption,
+	}
+	var results []SshCmdResult
+	vmList, err := getTargetVmList(nsId, mcisId, subGroupId, vmId)
+	if err == nil {
+		results, err = remoteScriptToVms(nsId, mcisId, vmList, script, params, cmdReq)
+	}
+
+	execution.Status = ScriptExecStatusSucceeded
+	execution.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		execution.Status = ScriptExecStatusFailed
+		execution.Error = err.Error()
+	}
+	execution.VmErr = map[string]string{}
+	for _, result := range results {
+		// the whole script is not kept in each result
+		result.Command = map[int]string{0: fmt.Sprintf("script %s (version %d)", script.Name, script.Version)}
+		if result.Err != nil {
+			execution.VmErr[result.VmId] = result.Err.Error()
+			result.Err = nil
+			execution.Status = ScriptExecStatusFailed
+		}
+		if code, ok := result.ExitCode[0]; !ok || code != 0 {
+			execution.Status = ScriptExecStatusFailed
+		}
+		execution.Results = append(execution.Results, result)
+	}
+	sort.Slice(execution.Results, func(i, j int) bool {
+		return execution.Results[i].VmId < execution.Results[j].VmId
+	})
+
+	putErr := putScriptExecution(execution)
+	if putErr != nil {
+		log.Error().Err(putErr).Msg("")
+	}
+	if err != nil {
+		return execution, err
+	}
+	return execution, nil
+}
+
+// resolveScriptParams is func to get the values of the script parameters (given values or defaults)
+func resolveScriptParams(script ScriptInfo, given map[string]string) (map[string]string, error) {
+	params := map[string]string{}
+	declared := map[string]bool{}
+	for _, p := range script.Parameters {
+		declared[p.Name] = true
+		value, ok := given[p.Name]
+		if !ok {
+			if p.Required {
+				err := fmt.Errorf("The parameter %s of the script %s is required", p.Name, script.Name)
+				return nil, err
+			}
+			value = p.Default
+		}
+		params[p.Name] = value
+	}
+	for name := range given {
+		if !declared[name] {
+			err := fmt.Errorf("The parameter %s is not defined in the script %s (version %d)", name, script.Name, script.Version)
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// remoteScriptToVms is func to execute a script with the parameters to VMs in MCIS by SSH
+func remoteScriptToVms(nsId string, mcisId string, vmList []string, script ScriptInfo, params map[string]string, req *McisCmdReq) ([]SshCmdResult, error) {
+
+	req.Command = []string{buildScriptCommand(script, params)}
+	err := validateMcisCmdReq(nsId, mcisId, req)
+	if err != nil {
+		return nil, err
+	}
+
+	vmCommands, displayCommands, err := expandScriptCommand(nsId, mcisId, vmList, script, params)
+	if err != nil {
+		return nil, err
+	}
+	return runRemoteCommandToVms(nsId, mcisId, vmCommands, displayCommands, req), nil
+}
+
+// expandScriptCommand is func to get the script command for the VMs (map[vmId][]command)
+// and the commands to display, in which secrets are masked.
+// Built-in functions in the parameters are evaluated for each VM before the values are quoted for shell.
+func expandScriptCommand(nsId string, mcisId string, vmList []string, script ScriptInfo, params map[string]string) (map[string][]string, map[string][]string, error) {
+
+	vmCommands := make(map[string][]string)
+	displayCommands := make(map[string][]string)
+	for i, vmId := range vmList {
+		for _, maskSecret := range []bool{false, true} {
+			values := make(map[string]string, len(params))
+			for name, value := range params {
+				expanded, err := processCommandFuncs(value, nsId, mcisId, vmId, i, maskSecret, false)
+				if err != nil {
+					return nil, nil, err
+				}
+				// the value is not evaluated again with the script command
+				values[name] = strings.ReplaceAll(expanded, cmdFuncPrefix, "\\"+cmdFuncPrefix)
+			}
+			cmd, err := processCommand(buildScriptCommand(script, values), nsId, mcisId, vmId, i, maskSecret)
+			if err != nil {
+				return nil, nil, err
+			}
+			if maskSecret {
+				displayCommands[vmId] = []string{cmd}
+			} else {
+				vmCommands[vmId] = []string{cmd}
+			}
+		}
+	}
+	return vmCommands, displayCommands, nil
+}
+
+// buildScriptCommand is func to build a remote command that writes the script to a temporary file,
+// executes it with the parameters as environment variables, and removes the file
+func buildScriptCommand(script ScriptInfo, params map[string]string) string {
+
+	// interpreter from the shebang line (bash by default); the file does not need the exec permission
+	interpreter := "bash"
+	content := script.Content
+	if strings.HasPrefix(content, "#!") {
+		firstLine := strings.SplitN(content, "\n", 2)[0]
+		if s := strings.TrimSpace(strings.TrimPrefix(firstLine, "#!")); s != "" {
+			interpreter = s
+		}
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	delimiter := "TB_SCRIPT_EOF_" + common.GenUid()
+	for strings.Contains(content, delimiter) {
+		delimiter = "TB_SCRIPT_EOF_" + common.GenUid()
+	}
+
+	var cmd strings.Builder
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd.WriteString("export " + name + "=" + shellQuote(params[name]) + "\n")
+	}
+	cmd.WriteString("tb_script=$(mktemp) || exit 1\n")
+	cmd.WriteString("cat > \"$tb_script\" <<'" + delimiter + "'\n")
+	cmd.WriteString(content)
+	cmd.WriteString(delimiter + "\n")
+	cmd.WriteString(interpreter + " \"$tb_script\"\n")
+	cmd.WriteString("tb_rc=$?; rm -f \"$tb_script\"; exit $tb_rc")
+	return cmd.String()
+}
+
+// putScriptExecution is func to persist a script execution
+func putScriptExecution(execution ScriptExecutionInfo) error {
+	val, err := json.Marshal(execution)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genScriptExecutionKey(execution.NsId, execution.ExecutionId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetScriptExecution is func to get a script execution
+func GetScriptExecution(nsId string, executionId string) (ScriptExecutionInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptExecutionInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genScriptExecutionKey(nsId, executionId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptExecutionInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The script execution " + executionId + " does not exist.")
+		return ScriptExecutionInfo{}, err
+	}
+
+	execution := ScriptExecutionInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &execution)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return ScriptExecutionInfo{}, err
+	}
+	return execution, nil
+}
+
+// ListScriptExecution is func to list script executions in a namespace (optionally filtered by script and MCIS)
+func ListScriptExecution(nsId string, scriptName string, mcisId string) (ScriptExecutionInfoList, error) {
+
+	result := ScriptExecutionInfoList{Executions: []ScriptExecutionInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/scriptExecution"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		execution := ScriptExecutionInfo{}
+		err = json.Unmarshal([]byte(v.Value), &execution)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		if scriptName != "" && execution.ScriptName != scriptName {
+			continue
+		}
+		if mcisId != "" && execution.McisId != mcisId {
+			continue
+		}
+		result.Executions = append(result.Executions, execution)
+	}
+	sort.Slice(result.Executions, func(i, j int) bool {
+		return result.Executions[i].StartedTime > result.Executions[j].StartedTime
+	})
+	return result, nil
+}
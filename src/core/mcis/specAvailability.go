@@ -0,0 +1,303 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ProvisioningFailureCapacity    string = "capacity"
+	ProvisioningFailureQuota       string = "quota"
+	ProvisioningFailureUnsupported string = "unsupported"
+	ProvisioningFailureOther       string = "other"
+)
+
+const (
+	// specAvailabilityHalfLife is the half-life of the penalty of provisioning failures
+	specAvailabilityHalfLife = 6 * time.Hour
+
+	// specAvailabilityThreshold is the default minimum availability score of specs in recommendation
+	specAvailabilityThreshold = 0.3
+
+	// specAvailabilityMaxFailures is the number of recent failures kept for a spec
+	specAvailabilityMaxFailures = 10
+)
+
+// provisioningFailurePenalty is the penalty added to the availability of a spec by a failure of each reason.
+// Other failures (e.g., invalid requests, network errors) are recorded but not counted against the spec.
+var provisioningFailurePenalty = map[string]float64{
+	ProvisioningFailureCapacity:    2.0,
+	ProvisioningFailureQuota:       2.0,
+	ProvisioningFailureUnsupported: 2.0,
+	ProvisioningFailureOther:       0,
+}
+
+// provisioningFailurePatterns is the list of (lowercase) patterns in error messages of CSPs for each reason
+var provisioningFailurePatterns = []struct {
+	reason   string
+	patterns []string
+}{
+	{ProvisioningFailureQuota, []string{"quota", "limitexceeded", "limit exceeded", "exceeded the limit", "exceeds the limit", "operationnotallowed"}},
+	{ProvisioningFailureCapacity, []string{"insufficientinstancecapacity", "insufficient capacity", "zonalresourceunavailable", "zone_resource_pool_exhausted", "resource_pool_exhausted", "out of stock", "sold out", "nostock", "allocationfailed", "overconstrainedallocationrequest", "capacity"}},
+	{ProvisioningFailureUnsupported, []string{"unsupported", "not supported", "skunotavailable", "not available", "notavailable", "not offered", "invalidparametercombination", "does not exist in zone", "not found in zone"}},
+}
+
+// ProvisioningFailure is struct for a failure of VM provisioning
+type ProvisioningFailure struct {
+	Reason     string `json:"reason" example:"capacity" enums:"capacity,quota,unsupported,other"`
+	Message    string `json:"message" example:"InsufficientInstanceCapacity: ..."`
+	NsId       string `json:"nsId" example:"ns01"`
+	McisId     string `json:"mcisId" example:"mcis01"`
+	VmId       string `json:"vmId" example:"g1-1"`
+	OccurredAt string `json:"occurredAt" example:"2024-01-01T00:00:00Z"`
+}
+
+// SpecAvailabilityInfo is struct for the availability of a spec in a connection (region)
+type SpecAvailabilityInfo struct {
+	ConnectionName string `json:"connectionName" example:"aws-ap-northeast-2"`
+	CspSpecName    string `json:"cspSpecName" example:"t2.small"`
+	SpecId         string `json:"specId" example:"aws+ap-northeast-2+t2.small"`
+	// AvailabilityScore is the availability of the spec (1 is available, decreased by recent failures and recovered with decay)
+	AvailabilityScore float64 `json:"availabilityScore" example:"0.85"`
+	// Penalty is the accumulated penalty of failures at UpdatedAt (AvailabilityScore = exp(-decayed penalty))
+	Penalty       float64               `json:"penalty" example:"0.5"`
+	UpdatedAt     string                `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+	SuccessCount  int                   `json:"successCount" example:"3"`
+	FailureCount  int                   `json:"failureCount" example:"1"`
+	LastSuccessAt string                `json:"lastSuccessAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	LastFailureAt string                `json:"lastFailureAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	Failures      []ProvisioningFailure `json:"failures"`
+}
+
+// SpecAvailabilityInfoList is struct for a list of spec availabilities
+type SpecAvailabilityInfoList struct {
+	SpecAvailability []SpecAvailabilityInfo `json:"specAvailability"`
+}
+
+// specAvailabilityStore is the in-memory cache of spec availabilities (persisted in the key-value store)
+var specAvailabilityStore = struct {
+	sync.RWMutex
+	loaded       bool
+	availability map[string]SpecAvailabilityInfo
+}{availability: map[string]SpecAvailabilityInfo{}}
+
+// genSpecAvailabilityKey is func to generate a key of the availability of a spec in a connection
+func genSpecAvailabilityKey(connectionName string, cspSpecName string) string {
+	return "/specAvailability/" + connectionName + "+" + cspSpecName
+}
+
+// loadSpecAvailabilityStore is func to load spec availabilities from the key-value store (once)
+func loadSpecAvailabilityStore() {
+	specAvailabilityStore.RLock()
+	loaded := specAvailabilityStore.loaded
+	specAvailabilityStore.RUnlock()
+	if loaded {
+		return
+	}
+
+	specAvailabilityStore.Lock()
+	defer specAvailabilityStore.Unlock()
+	if specAvailabilityStore.loaded {
+		return
+	}
+	keyValue, err := common.CBStore.GetList("/specAvailability", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	for _, v := range keyValue {
+		info := SpecAvailabilityInfo{}
+		err = json.Unmarshal([]byte(v.Value), &info)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		specAvailabilityStore.availability[genSpecAvailabilityKey(info.ConnectionName, info.CspSpecName)] = info
+	}
+	specAvailabilityStore.loaded = true
+}
+
+// classifyProvisioningFailure is func to get the reason code of a provisioning failure from the error message
+func classifyProvisioningFailure(err error) string {
+	msg := strings.ToLower(err.Error())
+	for _, p := range provisioningFailurePatterns {
+		for _, pattern := range p.patterns {
+			if strings.Contains(msg, pattern) {
+				return p.reason
+			}
+		}
+	}
+	return ProvisioningFailureOther
+}
+
+// decayedPenalty is func to get the penalty decayed from the updated time to now
+func decayedPenalty(info SpecAvailabilityInfo, now time.Time) float64 {
+	if info.Penalty <= 0 {
+		return 0
+	}
+	updatedAt, err := time.Parse(time.RFC3339, info.UpdatedAt)
+	if err != nil {
+		return info.Penalty
+	}
+	elapsed := now.Sub(updatedAt)
+	if elapsed <= 0 {
+		return info.Penalty
+	}
+	return info.Penalty * math.Pow(0.5, float64(elapsed)/float64(specAvailabilityHalfLife))
+}
+
+// recordProvisioningResult is func to record the result of provisioning a VM with a spec (err is nil for success)
+// to the availability of the spec
+func recordProvisioningResult(nsId string, mcisId string, vmId string, connectionName string, cspSpecName string, specId string, provisionErr error) {
+
+	if connectionName == "" || cspSpecName == "" {
+		return
+	}
+	loadSpecAvailabilityStore()
+
+	specAvailabilityStore.Lock()
+	defer specAvailabilityStore.Unlock()
+
+	now := time.Now()
+	key := genSpecAvailabilityKey(connectionName, cspSpecName)
+	info, ok := specAvailabilityStore.availability[key]
+	if !ok {
+		info = SpecAvailabilityInfo{ConnectionName: connectionName, CspSpecName: cspSpecName, Failures: []ProvisioningFailure{}}
+	}
+	if specId != "" {
+		info.SpecId = specId
+	}
+
+	penalty := decayedPenalty(info, now)
+	if provisionErr == nil {
+		// the spec is available now
+		penalty = 0
+		info.SuccessCount++
+		info.LastSuccessAt = now.UTC().Format(time.RFC3339)
+	} else {
+		reason := classifyProvisioningFailure(provisionErr)
+		penalty += provisioningFailurePenalty[reason]
+		info.FailureCount++
+		info.LastFailureAt = now.UTC().Format(time.RFC3339)
+		info.Failures = append(info.Failures, ProvisioningFailure{
+			Reason:     reason,
+			Message:    provisionErr.Error(),
+			NsId:       nsId,
+			McisId:     mcisId,
+			VmId:       vmId,
+			OccurredAt: info.LastFailureAt,
+		})
+		if len(info.Failures) > specAvailabilityMaxFailures {
+			info.Failures = info.Failures[len(info.Failures)-specAvailabilityMaxFailures:]
+		}
+		log.Info().Msgf("[Availability] %s in %s failed to be provisioned (%s)", cspSpecName, connectionName, reason)
+	}
+	info.Penalty = penalty
+	info.UpdatedAt = now.UTC().Format(time.RFC3339)
+	info.AvailabilityScore = math.Exp(-penalty)
+
+	val, err := json.Marshal(info)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	err = common.CBStore.Put(key, string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	specAvailabilityStore.availability[key] = info
+}
+
+// getSpecAvailabilityScore is func to get the current availability score of a spec (1 if no failure is recorded)
+func getSpecAvailabilityScore(connectionName string, cspSpecName string, now time.Time) float64 {
+	loadSpecAvailabilityStore()
+
+	specAvailabilityStore.RLock()
+	info, ok := specAvailabilityStore.availability[genSpecAvailabilityKey(connectionName, cspSpecName)]
+	specAvailabilityStore.RUnlock()
+	if !ok {
+		return 1
+	}
+	return math.Exp(-decayedPenalty(info, now))
+}
+
+// evaluateSpecsByAvailability func evaluates specs by the availability score from recent provisioning results
+func evaluateSpecsByAvailability(specList []mcir.TbSpecInfo, param []ParameterKeyVal) ([]float64, bool, error) {
+	now := time.Now()
+	values := make([]float64, len(specList))
+	for i, k := range specList {
+		values[i] = getSpecAvailabilityScore(k.ConnectionName, k.CspSpecName, now)
+	}
+	return values, false, nil
+}
+
+// ListSpecAvailability is func to list availabilities of specs with recorded provisioning results
+func ListSpecAvailability(connectionName string) (SpecAvailabilityInfoList, error) {
+	loadSpecAvailabilityStore()
+
+	now := time.Now()
+	result := SpecAvailabilityInfoList{SpecAvailability: []SpecAvailabilityInfo{}}
+
+	specAvailabilityStore.RLock()
+	for _, info := range specAvailabilityStore.availability {
+		if connectionName != "" && info.ConnectionName != connectionName {
+			continue
+		}
+		info.AvailabilityScore = math.Exp(-decayedPenalty(info, now))
+		result.SpecAvailability = append(result.SpecAvailability, info)
+	}
+	specAvailabilityStore.RUnlock()
+
+	sort.Slice(result.SpecAvailability, func(i, j int) bool {
+		a, b := result.SpecAvailability[i], result.SpecAvailability[j]
+		if a.AvailabilityScore != b.AvailabilityScore {
+			return a.AvailabilityScore < b.AvailabilityScore
+		}
+		if a.ConnectionName != b.ConnectionName {
+			return a.ConnectionName < b.ConnectionName
+		}
+		return a.CspSpecName < b.CspSpecName
+	})
+	return result, nil
+}
+
+// DelAllSpecAvailability is func to delete all recorded provisioning results (all specs are regarded as available)
+func DelAllSpecAvailability() error {
+	loadSpecAvailabilityStore()
+
+	specAvailabilityStore.Lock()
+	defer specAvailabilityStore.Unlock()
+
+	for key := range specAvailabilityStore.availability {
+		err := common.CBStore.Delete(key)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return err
+		}
+		delete(specAvailabilityStore.availability, key)
+	}
+	return nil
+}
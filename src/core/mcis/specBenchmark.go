@@ -0,0 +1,500 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+// SpecEvaluationStatusBenchmarked is the evaluation status of specs with scores from measured benchmark results
+const SpecEvaluationStatusBenchmarked string = "benchmarked"
+
+const (
+	// specBenchmarkMaxSamples is the number of recent samples of each metric kept (and averaged) for a spec
+	specBenchmarkMaxSamples = 10
+
+	// specBenchmarkDefaultSeedScore is the scale of the overall score if no spec has a seeded score
+	specBenchmarkDefaultSeedScore = 100.0
+)
+
+// specBenchmarkMetrics is the list of benchmark actions aggregated into scores of specs.
+// The score of each metric is stored in EvaluationScore02 ~ EvaluationScore10 in the order,
+// and the overall performance score is stored in EvaluationScore01.
+var specBenchmarkMetrics = []string{"cpus", "cpum", "memR", "memW", "fioR", "fioW", "dbR", "dbW", "rtt"}
+
+// specBenchmarkOverallExcluded is the set of metrics not reflected in the overall performance score
+var specBenchmarkOverallExcluded = map[string]bool{"rtt": true}
+
+// specBenchmarkResultPattern is the pattern of a valid benchmark result (a number with an optional unit)
+var specBenchmarkResultPattern = regexp.MustCompile(`^\s*([0-9]+(\.[0-9]+)?)\s*([a-zA-Z/%]*)\s*$`)
+
+// SpecBenchmarkSample is struct for a benchmark result of a spec
+type SpecBenchmarkSample struct {
+	Metric     string  `json:"metric" example:"cpus"`
+	Value      float64 `json:"value" example:"9.24"`
+	Unit       string  `json:"unit" example:"sec"`
+	NsId       string  `json:"nsId" example:"ns01"`
+	McisId     string  `json:"mcisId" example:"mcis01"`
+	MeasuredAt string  `json:"measuredAt" example:"2024-01-01T00:00:00Z"`
+}
+
+// SpecBenchmarkHistory is struct for benchmark results of a spec and the scores aggregated from them
+type SpecBenchmarkHistory struct {
+	SpecId       string `json:"specId" example:"aws+ap-northeast-2+t2.small"`
+	ProviderName string `json:"providerName" example:"aws"`
+	RegionName   string `json:"regionName" example:"ap-northeast-2"`
+	// SeedScore is EvaluationScore01 of the spec before benchmarking (e.g., from assets/cloudspec.csv)
+	SeedScore float32 `json:"seedScore" example:"78.43"`
+	// Latest is the latest result of each metric
+	Latest SpecBenchmarkInfo `json:"latest"`
+	// Average is the average of recent samples of each metric
+	Average map[string]float64 `json:"average"`
+	// Score is the score of each metric (100 is the median of benchmarked specs) and the overall performance score ("overall")
+	Score     map[string]float64    `json:"score"`
+	UpdatedAt string                `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
+	Samples   []SpecBenchmarkSample `json:"samples"`
+}
+
+// SpecBenchmarkHistoryList is struct for a list of benchmark histories of specs
+type SpecBenchmarkHistoryList struct {
+	SpecBenchmark []SpecBenchmarkHistory `json:"specBenchmark"`
+}
+
+// specBenchmarkStore is the in-memory cache of benchmark histories of specs (persisted in the key-value store)
+var specBenchmarkStore = struct {
+	sync.RWMutex
+	loaded  bool
+	history map[string]SpecBenchmarkHistory
+}{history: map[string]SpecBenchmarkHistory{}}
+
+// genSpecBenchmarkKey is func to generate a key of the benchmark history of a spec
+func genSpecBenchmarkKey(specId string) string {
+	return "/specBenchmark/" + specId
+}
+
+// loadSpecBenchmarkStore is func to load benchmark histories of specs from the key-value store (once)
+func loadSpecBenchmarkStore() {
+	specBenchmarkStore.RLock()
+	loaded := specBenchmarkStore.loaded
+	specBenchmarkStore.RUnlock()
+	if loaded {
+		return
+	}
+
+	specBenchmarkStore.Lock()
+	defer specBenchmarkStore.Unlock()
+	if specBenchmarkStore.loaded {
+		return
+	}
+	keyValue, err := common.CBStore.GetList("/specBenchmark", true)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return
+	}
+	for _, v := range keyValue {
+		history := SpecBenchmarkHistory{}
+		err = json.Unmarshal([]byte(v.Value), &history)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		specBenchmarkStore.history[history.SpecId] = history
+	}
+	specBenchmarkStore.loaded = true
+}
+
+// isSpecBenchmarkMetric is func to check whether results of a benchmark action are aggregated into scores of specs
+func isSpecBenchmarkMetric(action string) bool {
+	for _, metric := range specBenchmarkMetrics {
+		if metric == action {
+			return true
+		}
+	}
+	return false
+}
+
+// isLowerBetterBenchmark is func to check whether a lower value is better for a benchmark result
+// (results in time such as elapsed seconds or milliseconds, which is the case of milkyway without unit)
+func isLowerBetterBenchmark(unit string) bool {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "s", "sec", "secs", "second", "seconds", "ms", "msec", "msecs", "millisecond", "milliseconds", "us", "usec":
+		return true
+	}
+	return false
+}
+
+// parseBenchmarkResult is func to get the value and unit from a benchmark result
+func parseBenchmarkResult(result string, unit string) (float64, string, error) {
+	matched := specBenchmarkResultPattern.FindStringSubmatch(result)
+	if matched == nil {
+		return 0, "", fmt.Errorf("not a valid benchmark result: '%s'", result)
+	}
+	value, err := strconv.ParseFloat(matched[1], 64)
+	if err != nil {
+		return 0, "", err
+	}
+	if strings.TrimSpace(unit) == "" {
+		unit = matched[3]
+	}
+	return value, strings.TrimSpace(unit), nil
+}
+
+// setSpecBenchmarkInfo is func to set the result of a metric to SpecBenchmarkInfo
+func setSpecBenchmarkInfo(info *SpecBenchmarkInfo, metric string, result string) {
+	switch metric {
+	case "cpus":
+		info.Cpus = result
+	case "cpum":
+		info.Cpum = result
+	case "memR":
+		info.MemR = result
+	case "memW":
+		info.MemW = result
+	case "fioR":
+		info.FioR = result
+	case "fioW":
+		info.FioW = result
+	case "dbR":
+		info.DbR = result
+	case "dbW":
+		info.DbW = result
+	case "rtt":
+		info.Rtt = result
+	}
+}
+
+// getSpecForBenchmark is func to get a spec benchmarked in a namespace (or the common namespace)
+func getSpecForBenchmark(nsId string, specId string) (mcir.TbSpecInfo, error) {
+	specInfo := mcir.TbSpecInfo{}
+	tempInterface, err := mcir.GetResource(nsId, common.StrSpec, specId)
+	if err != nil {
+		return specInfo, err
+	}
+	err = common.CopySrcToDest(&tempInterface, &specInfo)
+	return specInfo, err
+}
+
+// recordSpecBenchmarkResults is func to persist results of a benchmark action per spec
+// and to update evaluation scores of the benchmarked specs
+func recordSpecBenchmarkResults(nsId string, mcisId string, action string, content BenchmarkInfoArray) {
+
+	if !isSpecBenchmarkMetric(action) {
+		return
+	}
+	loadSpecBenchmarkStore()
+
+	// specs are updated after releasing the lock of specBenchmarkStore
+	for _, history := range addSpecBenchmarkSamples(nsId, mcisId, action, content) {
+		applySpecBenchmarkScores(history)
+	}
+}
+
+// addSpecBenchmarkSamples is func to add results of a benchmark action to histories of specs
+// and to return the histories rescored with the results
+func addSpecBenchmarkSamples(nsId string, mcisId string, action string, content BenchmarkInfoArray) []SpecBenchmarkHistory {
+
+	specBenchmarkStore.Lock()
+	defer specBenchmarkStore.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	changed := map[string]bool{}
+	for _, k := range content.ResultArray {
+		if k.SpecId == "" {
+			continue
+		}
+		value, unit, err := parseBenchmarkResult(k.Result, k.Unit)
+		if err != nil {
+			log.Debug().Msgf("[Benchmark] skip the %s result of %s: %s", action, k.SpecId, err.Error())
+			continue
+		}
+
+		history, ok := specBenchmarkStore.history[k.SpecId]
+		if !ok {
+			history = SpecBenchmarkHistory{SpecId: k.SpecId, Samples: []SpecBenchmarkSample{}}
+			specInfo, err := getSpecForBenchmark(common.SystemCommonNs, k.SpecId)
+			if err != nil {
+				specInfo, err = getSpecForBenchmark(nsId, k.SpecId)
+			}
+			if err == nil {
+				history.ProviderName = specInfo.ProviderName
+				history.RegionName = specInfo.RegionName
+				if specInfo.EvaluationStatus != SpecEvaluationStatusBenchmarked {
+					history.SeedScore = specInfo.EvaluationScore01
+				}
+			}
+		}
+
+		history.Samples = append(history.Samples, SpecBenchmarkSample{
+			Metric:     action,
+			Value:      value,
+			Unit:       unit,
+			NsId:       nsId,
+			McisId:     mcisId,
+			MeasuredAt: now,
+		})
+		// keep recent samples of each metric only
+		count := 0
+		kept := []SpecBenchmarkSample{}
+		for i := len(history.Samples) - 1; i >= 0; i-- {
+			if history.Samples[i].Metric == action {
+				count++
+				if count > specBenchmarkMaxSamples {
+					continue
+				}
+			}
+			kept = append(kept, history.Samples[i])
+		}
+		for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+			kept[i], kept[j] = kept[j], kept[i]
+		}
+		history.Samples = kept
+
+		setSpecBenchmarkInfo(&history.Latest, action, k.Result)
+		history.Latest.SpecId = k.SpecId
+		history.Latest.EvaledTime = now
+		history.UpdatedAt = now
+		specBenchmarkStore.history[k.SpecId] = history
+		changed[k.SpecId] = true
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+	return updateSpecBenchmarkScores(changed)
+}
+
+// updateSpecBenchmarkScores is func to aggregate benchmark histories of the changed specs into their scores
+// against the medians of all benchmarked specs, and to return the rescored histories.
+// Scores of the other specs are kept until their results change.
+// (the caller should hold the lock of specBenchmarkStore)
+func updateSpecBenchmarkScores(changed map[string]bool) []SpecBenchmarkHistory {
+
+	// average of recent samples of the changed specs, and the unit of each metric
+	units := map[string]string{}
+	for specId, history := range specBenchmarkStore.history {
+		for _, s := range history.Samples {
+			units[s.Metric] = s.Unit
+		}
+		if !changed[specId] {
+			continue
+		}
+		sum := map[string]float64{}
+		count := map[string]int{}
+		for _, s := range history.Samples {
+			sum[s.Metric] += s.Value
+			count[s.Metric]++
+		}
+		history.Average = map[string]float64{}
+		for metric, c := range count {
+			history.Average[metric] = sum[metric] / float64(c)
+		}
+		specBenchmarkStore.history[specId] = history
+	}
+
+	// median of averages among benchmarked specs (the reference of scores)
+	median := map[string]float64{}
+	for _, metric := range specBenchmarkMetrics {
+		values := []float64{}
+		for _, history := range specBenchmarkStore.history {
+			if v, ok := history.Average[metric]; ok && v > 0 {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			median[metric] = medianFloat64(values)
+		}
+	}
+
+	// scale of the overall score (median of seeded scores) to be comparable with scores of specs not benchmarked
+	seedScores := []float64{}
+	for _, history := range specBenchmarkStore.history {
+		if history.SeedScore > 0 {
+			seedScores = append(seedScores, float64(history.SeedScore))
+		}
+	}
+	seedScale := specBenchmarkDefaultSeedScore
+	if len(seedScores) > 0 {
+		sort.Float64s(seedScores)
+		seedScale = seedScores[len(seedScores)/2]
+	}
+
+	var updated []SpecBenchmarkHistory
+	for specId := range changed {
+		history := specBenchmarkStore.history[specId]
+		history.Score = map[string]float64{}
+		logSum := 0.0
+		logCount := 0
+		for _, metric := range specBenchmarkMetrics {
+			v, ok := history.Average[metric]
+			if !ok || v <= 0 || median[metric] <= 0 {
+				continue
+			}
+			ratio := v / median[metric]
+			if isLowerBetterBenchmark(units[metric]) {
+				ratio = median[metric] / v
+			}
+			history.Score[metric] = ratio * 100
+			if !specBenchmarkOverallExcluded[metric] {
+				logSum += math.Log(ratio)
+				logCount++
+			}
+		}
+		if logCount > 0 {
+			// geometric mean of relative performances
+			history.Score["overall"] = seedScale * math.Exp(logSum/float64(logCount))
+		}
+
+		val, err := json.Marshal(history)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		err = common.CBStore.Put(genSpecBenchmarkKey(specId), string(val))
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		specBenchmarkStore.history[specId] = history
+		updated = append(updated, history)
+	}
+	return updated
+}
+
+// applySpecBenchmarkScores is func to update EvaluationScore01 ~ EvaluationScore10 of the benchmarked spec
+// (in the common namespace and namespaces where the spec is benchmarked)
+func applySpecBenchmarkScores(history SpecBenchmarkHistory) {
+	overall, ok := history.Score["overall"]
+	if !ok {
+		return
+	}
+
+	nsIds := []string{common.SystemCommonNs}
+	for _, s := range history.Samples {
+		found := false
+		for _, ns := range nsIds {
+			if ns == s.NsId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			nsIds = append(nsIds, s.NsId)
+		}
+	}
+
+	for _, nsId := range nsIds {
+		specInfo, err := getSpecForBenchmark(nsId, history.SpecId)
+		if err != nil {
+			continue
+		}
+		scores := []*float32{
+			&specInfo.EvaluationScore02, &specInfo.EvaluationScore03, &specInfo.EvaluationScore04,
+			&specInfo.EvaluationScore05, &specInfo.EvaluationScore06, &specInfo.EvaluationScore07,
+			&specInfo.EvaluationScore08, &specInfo.EvaluationScore09, &specInfo.EvaluationScore10,
+		}
+		for i, metric := range specBenchmarkMetrics {
+			*scores[i] = float32(history.Score[metric])
+		}
+		specInfo.EvaluationScore01 = float32(overall)
+		specInfo.EvaluationStatus = SpecEvaluationStatusBenchmarked
+
+		// namespace and id cannot be given to UpdateSpec
+		specInfo.Namespace = ""
+		specInfo.Id = ""
+		_, err = mcir.UpdateSpec(nsId, history.SpecId, specInfo)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+		}
+	}
+}
+
+// getMeasuredPerformanceScore is func to get the overall performance score of a spec from benchmark results
+func getMeasuredPerformanceScore(specId string) (float64, bool) {
+	loadSpecBenchmarkStore()
+
+	specBenchmarkStore.RLock()
+	defer specBenchmarkStore.RUnlock()
+	history, ok := specBenchmarkStore.history[specId]
+	if !ok {
+		return 0, false
+	}
+	score, ok := history.Score["overall"]
+	return score, ok
+}
+
+// GetSpecBenchmarkHistory is func to get the benchmark history of a spec
+func GetSpecBenchmarkHistory(specId string) (SpecBenchmarkHistory, error) {
+	loadSpecBenchmarkStore()
+
+	specBenchmarkStore.RLock()
+	defer specBenchmarkStore.RUnlock()
+	history, ok := specBenchmarkStore.history[specId]
+	if !ok {
+		return SpecBenchmarkHistory{}, fmt.Errorf("no benchmark result of the spec %s", specId)
+	}
+	return history, nil
+}
+
+// ListSpecBenchmarkHistory is func to list benchmark histories of specs (only specs in the region if given)
+func ListSpecBenchmarkHistory(regionName string) (SpecBenchmarkHistoryList, error) {
+	loadSpecBenchmarkStore()
+
+	result := SpecBenchmarkHistoryList{SpecBenchmark: []SpecBenchmarkHistory{}}
+
+	specBenchmarkStore.RLock()
+	for _, history := range specBenchmarkStore.history {
+		if regionName != "" && !strings.EqualFold(history.RegionName, regionName) {
+			continue
+		}
+		result.SpecBenchmark = append(result.SpecBenchmark, history)
+	}
+	specBenchmarkStore.RUnlock()
+
+	sort.Slice(result.SpecBenchmark, func(i, j int) bool {
+		return result.SpecBenchmark[i].SpecId < result.SpecBenchmark[j].SpecId
+	})
+	return result, nil
+}
+
+// DelAllSpecBenchmarkHistory is func to delete benchmark histories of all specs
+// (scores already applied to specs are kept until specs are reloaded)
+func DelAllSpecBenchmarkHistory() error {
+	loadSpecBenchmarkStore()
+
+	specBenchmarkStore.Lock()
+	defer specBenchmarkStore.Unlock()
+
+	for specId := range specBenchmarkStore.history {
+		err := common.CBStore.Delete(genSpecBenchmarkKey(specId))
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			return err
+		}
+		delete(specBenchmarkStore.history, specId)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/cloud-barista/cb-tumblebug/src/core/mcir"
+	"github.com/rs/zerolog/log"
+)
+
+// spotReplacementInProgress is the set of interrupted spot VMs being replaced (to avoid duplicated replacement)
+var spotReplacementInProgress sync.Map
+
+// getSpecCostPerHour is func to get the cost per hour of a spec in the market
+// (the on-demand price is used if the spot price is unknown)
+func getSpecCostPerHour(spec mcir.TbSpecInfo, marketType string) float32 {
+	if marketType == MarketTypeSpot && spec.SpotCostPerHour > 0 {
+		return spec.SpotCostPerHour
+	}
+	return spec.CostPerHour
+}
+
+// isSpotInterruption is func to check whether the status change of a VM is an interruption of a spot VM by the CSP
+// (the VM was running and stopped or terminated without any action requested to CB-Tumblebug)
+func isSpotInterruption(vm TbVmInfo, newStatus string) bool {
+	if vm.Market.MarketType != MarketTypeSpot || vm.SpotInterruption != nil {
+		return false
+	}
+	if vm.TargetAction != ActionComplete || vm.Status != StatusRunning {
+		return false
+	}
+	switch newStatus {
+	case StatusSuspending, StatusSuspended, StatusTerminating, StatusTerminated:
+		return true
+	}
+	return false
+}
+
+// handleSpotInterruption is func to record the interruption of a spot VM
+// and to replace the VM in background if it is requested by the market option
+func handleSpotInterruption(nsId string, mcisId string, vm *TbVmInfo, newStatus string) string {
+	vm.SpotInterruption = &SpotInterruptionInfo{
+		DetectedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:     newStatus,
+	}
+	systemMessage := "spot VM is interrupted by the CSP (" + newStatus + ")"
+	log.Warn().Msgf("[Spot] %s/%s/%s: %s", nsId, mcisId, vm.Id, systemMessage)
+
+	if vm.Market.SpotInterruptionAction == SpotInterruptionActionReplace {
+		systemMessage += ", replacing the VM"
+		go replaceInterruptedSpotVm(nsId, mcisId, vm.Id)
+	}
+	return systemMessage
+}
+
+// replaceInterruptedSpotVm is func to add a new VM to the subGroup of an interrupted spot VM (scale-out)
+func replaceInterruptedSpotVm(nsId string, mcisId string, vmId string) {
+
+	key := common.GenMcisKey(nsId, mcisId, vmId)
+	if _, loaded := spotReplacementInProgress.LoadOrStore(key, true); loaded {
+		return
+	}
+	defer spotReplacementInProgress.Delete(key)
+
+	replacementVmId, err := func() (string, error) {
+		vm, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil {
+			return "", err
+		}
+		if vm.SubGroupId == "" {
+			return "", fmt.Errorf("the VM %s is not in a subGroup", vmId)
+		}
+		vmIdsBefore, err := ListVmBySubGroup(nsId, mcisId, vm.SubGroupId)
+		if err != nil {
+			return "", err
+		}
+		_, err = ScaleOutMcisSubGroup(nsId, mcisId, vm.SubGroupId, "1")
+		if err != nil {
+			return "", err
+		}
+		vmIdsAfter, err := ListVmBySubGroup(nsId, mcisId, vm.SubGroupId)
+		if err != nil {
+			return "", err
+		}
+		existing := map[string]bool{}
+		for _, v := range vmIdsBefore {
+			existing[v] = true
+		}
+		for _, v := range vmIdsAfter {
+			if !existing[v] {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("no VM is added to the subGroup %s", vm.SubGroupId)
+	}()
+
+	vm, getErr := GetVmObject(nsId, mcisId, vmId)
+	if getErr != nil {
+		log.Error().Err(getErr).Msg("")
+		return
+	}
+	if vm.SpotInterruption == nil {
+		vm.SpotInterruption = &SpotInterruptionInfo{DetectedAt: time.Now().UTC().Format(time.RFC3339), Status: vm.Status}
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("[Spot] failed to replace the interrupted VM %s/%s/%s", nsId, mcisId, vmId)
+		vm.SpotInterruption.Message = "Failed to replace the VM: " + err.Error()
+		vm.SystemMessage = "spot VM is interrupted by the CSP, failed to replace the VM"
+	} else {
+		log.Info().Msgf("[Spot] the interrupted VM %s/%s/%s is replaced by %s", nsId, mcisId, vmId, replacementVmId)
+		vm.SpotInterruption.ReplacementVmId = replacementVmId
+		vm.SystemMessage = "spot VM is interrupted by the CSP, replaced by " + replacementVmId
+	}
+	UpdateVmInfo(nsId, mcisId, vm)
+}
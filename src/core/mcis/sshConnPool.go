@@ -0,0 +1,293 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// sshPoolIdleTimeout is the duration to keep an unused SSH connection in the pool
+	sshPoolIdleTimeout = 5 * time.Minute
+
+	// sshPoolKeepaliveInterval is the interval to check pooled SSH connections by keepalive requests
+	sshPoolKeepaliveInterval = 30 * time.Second
+
+	// sshPoolKeepaliveTimeout is the timeout of a keepalive request
+	sshPoolKeepaliveTimeout = 10 * time.Second
+
+	// sshPoolReuseCheckAfter is the idle duration after which a connection is checked before reuse
+	sshPoolReuseCheckAfter = 10 * time.Second
+
+	// sshPoolMaxUsersPerConn is the maximum number of concurrent users (sessions) of a pooled connection
+	// (below MaxSessions of OpenSSH server, 10 by default). Extra users get a dedicated connection.
+	sshPoolMaxUsersPerConn = 8
+)
+
+// sshPoolKey is the key of pooled SSH connections (bastion chain, target, user)
+type sshPoolKey struct {
+	// BastionChain is user@endpoint of the bastions joined by ">" ("direct" if there is no bastion)
+	BastionChain   string
+	TargetEndPoint string
+	TargetUserName string
+}
+
+// sshPooledConn is an SSH connection to a target through a bastion, shared by multiple sessions
+type sshPooledConn struct {
+	key            sshPoolKey
+	client         *ssh.Client
+	bastionClients []*ssh.Client
+	refCount       int
+	lastUsed       time.Time
+	broken         bool
+	// dedicated is true for a connection out of the pool (closed on release)
+	dedicated bool
+}
+
+// sshConnPool is a pool of SSH connections with idle timeouts and keepalives
+type sshConnPool struct {
+	mu    sync.Mutex
+	conns map[sshPoolKey]*sshPooledConn
+	once  sync.Once
+}
+
+// sshPool is the SSH connection pool for remote commands, file transfers and terminals
+var sshPool = &sshConnPool{conns: map[sshPoolKey]*sshPooledConn{}}
+
+// acquire is func to get a pooled SSH connection to the target (dials a new one if there is no usable connection).
+// Bastion chains of the route are tried in order, so the next chain is used if a bastion is down.
+// The caller should release the connection after use.
+func (p *sshConnPool) acquire(route sshRoute) (*sshPooledConn, error) {
+	p.once.Do(func() {
+		go p.maintain()
+	})
+
+	if len(route.BastionChains) == 0 {
+		return nil, fmt.Errorf("no bastion to connect to %s", route.Target.EndPoint)
+	}
+
+	// reuse a connection through any of the bastion chains
+	for _, chain := range route.BastionChains {
+		if conn := p.reuse(newSshPoolKey(chain, route.Target)); conn != nil {
+			return conn, nil
+		}
+	}
+
+	var errs []error
+	tried := map[sshPoolKey]bool{}
+	tryChains := func(chains [][]sshInfo) *sshPooledConn {
+		for _, chain := range chains {
+			key := newSshPoolKey(chain, route.Target)
+			if tried[key] {
+				continue
+			}
+			tried[key] = true
+			client, bastionClients, err := dialSshThroughBastions(chain, route.Target)
+			if err != nil {
+				log.Warn().Err(err).Msgf("[SSH pool] failed to connect to %s via %s", key.TargetEndPoint, key.BastionChain)
+				errs = append(errs, fmt.Errorf("via %s: %w", key.BastionChain, err))
+				continue
+			}
+			return p.add(&sshPooledConn{
+				key:            key,
+				client:         client,
+				bastionClients: bastionClients,
+				refCount:       1,
+				lastUsed:       time.Now(),
+			})
+		}
+		return nil
+	}
+
+	if conn := tryChains(route.BastionChains); conn != nil {
+		return conn, nil
+	}
+	// failover to other bastions
+	if route.FallbackChains != nil {
+		if conn := tryChains(route.FallbackChains()); conn != nil {
+			log.Info().Msgf("[SSH pool] connected to %s via %s (failover)", conn.key.TargetEndPoint, conn.key.BastionChain)
+			return conn, nil
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// newSshPoolKey is func to get the pool key of a bastion chain and a target
+func newSshPoolKey(chain []sshInfo, target sshInfo) sshPoolKey {
+	hops := make([]string, 0, len(chain))
+	for _, hop := range chain {
+		hops = append(hops, hop.UserName+"@"+hop.EndPoint)
+	}
+	if len(hops) == 0 {
+		hops = append(hops, "direct")
+	}
+	return sshPoolKey{
+		BastionChain:   strings.Join(hops, ">"),
+		TargetEndPoint: target.EndPoint,
+		TargetUserName: target.UserName,
+	}
+}
+
+// reuse is func to get a usable pooled connection of the key (nil if there is none)
+func (p *sshConnPool) reuse(key sshPoolKey) *sshPooledConn {
+	p.mu.Lock()
+	conn, ok := p.conns[key]
+	if !ok || conn.broken || conn.refCount >= sshPoolMaxUsersPerConn {
+		p.mu.Unlock()
+		return nil
+	}
+	conn.refCount++
+	idle := time.Since(conn.lastUsed)
+	p.mu.Unlock()
+
+	// check a connection idle for a while before reuse (the VM may be rebooted)
+	if idle < sshPoolReuseCheckAfter || sendKeepalive(conn.client) == nil {
+		return conn
+	}
+	log.Debug().Msgf("[SSH pool] stale connection to %s", key.TargetEndPoint)
+	p.invalidate(conn)
+	p.release(conn)
+	return nil
+}
+
+// add is func to put a newly dialed connection to the pool (returns the connection to use)
+func (p *sshConnPool) add(newConn *sshPooledConn) *sshPooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := newConn.key
+	if existing, ok := p.conns[key]; ok && !existing.broken {
+		if existing.refCount < sshPoolMaxUsersPerConn {
+			// another caller has dialed the same target meanwhile
+			newConn.close()
+			existing.refCount++
+			return existing
+		}
+		newConn.dedicated = true
+		return newConn
+	}
+	p.conns[key] = newConn
+	log.Debug().Msgf("[SSH pool] new connection to %s via %s (pool size: %d)", key.TargetEndPoint, key.BastionChain, len(p.conns))
+	return newConn
+}
+
+// release is func to return a connection to the pool
+func (p *sshConnPool) release(conn *sshPooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.refCount--
+	conn.lastUsed = time.Now()
+	if (conn.broken || conn.dedicated) && conn.refCount <= 0 {
+		conn.close()
+	}
+}
+
+// invalidate is func to remove a broken connection from the pool (closed when no one uses it)
+func (p *sshConnPool) invalidate(conn *sshPooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.broken = true
+	if p.conns[conn.key] == conn {
+		delete(p.conns, conn.key)
+	}
+	if conn.refCount <= 0 {
+		conn.close()
+	}
+}
+
+// newSession is func to open a session on a pooled connection.
+// The connection is invalidated if it is broken (not if the server just refused a new channel).
+func (p *sshConnPool) newSession(conn *sshPooledConn) (*ssh.Session, error) {
+	session, err := conn.client.NewSession()
+	if err != nil {
+		p.handleChannelError(conn, err)
+		return nil, err
+	}
+	return session, nil
+}
+
+// handleChannelError is func to invalidate a connection on an error of opening a channel, except refusals by the server
+func (p *sshConnPool) handleChannelError(conn *sshPooledConn, err error) {
+	var openChannelErr *ssh.OpenChannelError
+	if errors.As(err, &openChannelErr) {
+		return
+	}
+	p.invalidate(conn)
+}
+
+// maintain is func to close idle connections and to send keepalives periodically
+func (p *sshConnPool) maintain() {
+	ticker := time.NewTicker(sshPoolKeepaliveInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.mu.Lock()
+		conns := make([]*sshPooledConn, 0, len(p.conns))
+		for key, conn := range p.conns {
+			if conn.refCount <= 0 && time.Since(conn.lastUsed) > sshPoolIdleTimeout {
+				delete(p.conns, key)
+				conn.close()
+				continue
+			}
+			conns = append(conns, conn)
+		}
+		p.mu.Unlock()
+
+		var wg sync.WaitGroup
+		for _, conn := range conns {
+			wg.Add(1)
+			go func(conn *sshPooledConn) {
+				defer wg.Done()
+				if err := sendKeepalive(conn.client); err != nil {
+					log.Debug().Err(err).Msgf("[SSH pool] keepalive failed for %s", conn.key.TargetEndPoint)
+					p.invalidate(conn)
+				}
+			}(conn)
+		}
+		wg.Wait()
+	}
+}
+
+// close is func to close the SSH clients of the connection (from the target to the first bastion)
+func (conn *sshPooledConn) close() {
+	conn.client.Close()
+	for i := len(conn.bastionClients) - 1; i >= 0; i-- {
+		conn.bastionClients[i].Close()
+	}
+}
+
+// sendKeepalive is func to check an SSH connection by a keepalive request
+func sendKeepalive(client *ssh.Client) error {
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(sshPoolKeepaliveTimeout):
+		return fmt.Errorf("keepalive timeout (%s)", sshPoolKeepaliveTimeout)
+	}
+}
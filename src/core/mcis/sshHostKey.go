@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// SshHostKeyInfo is struct for the SSH host key of a VM (recorded on the first connection, trust-on-first-use)
+type SshHostKeyInfo struct {
+	// HostKey is base64 of the host public key in SSH wire format
+	HostKey     string `json:"hostKey,omitempty"`
+	KeyType     string `json:"keyType,omitempty" example:"ssh-ed25519"`
+	Fingerprint string `json:"fingerprint,omitempty" example:"SHA256:dgMCTA4PQQWAP1cOgzYhjIFCsRMI5WgXJ+7eB+Ig3Rs"`
+	FirstUsedAt string `json:"firstUsedAt,omitempty" example:"2024-01-01T00:00:00Z"`
+}
+
+// vmHostKeyCallback is func to get a HostKeyCallback that verifies the host key of a VM.
+// The host key is recorded to TbVmInfo on the first connection, and a different key on
+// subsequent connections fails the connection.
+func vmHostKeyCallback(nsId string, mcisId string, vmId string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		vmInfo, err := GetVmObject(nsId, mcisId, vmId)
+		if err != nil {
+			return err
+		}
+
+		hostKey := base64.StdEncoding.EncodeToString(key.Marshal())
+		fingerprint := ssh.FingerprintSHA256(key)
+
+		if vmInfo.SshHostKeyInfo.HostKey == "" {
+			vmInfo.SshHostKeyInfo = SshHostKeyInfo{
+				HostKey:     hostKey,
+				KeyType:     key.Type(),
+				Fingerprint: fingerprint,
+				FirstUsedAt: time.Now().UTC().Format(time.RFC3339),
+			}
+			UpdateVmInfo(nsId, mcisId, vmInfo)
+			log.Info().Msgf("[SSH] recorded host key of %s/%s/%s (%s %s)", nsId, mcisId, vmId, key.Type(), fingerprint)
+			return nil
+		}
+
+		if vmInfo.SshHostKeyInfo.HostKey != hostKey {
+			err := fmt.Errorf("SSH host key of VM %s/%s (%s) has changed: expected %s %s, but got %s %s. "+
+				"It may be a man-in-the-middle attack. If the change is expected (e.g., the VM was rebuilt), "+
+				"reset the recorded host key of the VM",
+				mcisId, vmId, hostname,
+				vmInfo.SshHostKeyInfo.KeyType, vmInfo.SshHostKeyInfo.Fingerprint,
+				key.Type(), fingerprint)
+			log.Error().Err(err).Msg("")
+			return err
+		}
+		return nil
+	}
+}
+
+// GetVmSshHostKey is func to get the recorded SSH host key of a VM
+func GetVmSshHostKey(nsId string, mcisId string, vmId string) (SshHostKeyInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SshHostKeyInfo{}, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SshHostKeyInfo{}, err
+	}
+	err = common.CheckString(vmId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return SshHostKeyInfo{}, err
+	}
+
+	vmInfo, err := GetVmObject(nsId, mcisId, vmId)
+	if err != nil {
+		return SshHostKeyInfo{}, err
+	}
+	return vmInfo.SshHostKeyInfo, nil
+}
+
+// ResetVmSshHostKey is func to reset the recorded SSH host key of a VM (recorded again on the next connection)
+func ResetVmSshHostKey(nsId string, mcisId string, vmId string) error {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CheckString(vmId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+
+	vmInfo, err := GetVmObject(nsId, mcisId, vmId)
+	if err != nil {
+		return err
+	}
+	vmInfo.SshHostKeyInfo = SshHostKeyInfo{}
+	UpdateVmInfo(nsId, mcisId, vmInfo)
+
+	log.Info().Msgf("[SSH] reset host key of %s/%s/%s", nsId, mcisId, vmId)
+	return nil
+}
@@ -0,0 +1,895 @@
+/*
+Copyright 2019 The Cloud-Barista Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mcis is to manage multi-cloud infra service
+package mcis
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cbstore_utils "github.com/cloud-barista/cb-store/utils"
+	"github.com/cloud-barista/cb-tumblebug/src/core/common"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// WorkflowStatusPending is const for "Pending" status of a workflow step.
+	WorkflowStatusPending string = "Pending"
+
+	// WorkflowStatusRunning is const for "Running" status of a workflow run or step.
+	WorkflowStatusRunning string = "Running"
+
+	// WorkflowStatusSucceeded is const for "Succeeded" status of a workflow run or step.
+	WorkflowStatusSucceeded string = "Succeeded"
+
+	// WorkflowStatusFailed is const for "Failed" status of a workflow run or step.
+	WorkflowStatusFailed string = "Failed"
+
+	// WorkflowStatusSkipped is const for "Skipped" status of a workflow step.
+	// (a step that the step depends on did not succeed)
+	WorkflowStatusSkipped string = "Skipped"
+
+	// WorkflowStatusInterrupted is const for "Interrupted" status of a workflow run.
+	// (the run was running when CB-Tumblebug stopped)
+	WorkflowStatusInterrupted string = "Interrupted"
+)
+
+// workflowVarPattern is the pattern of a reference to an output of a previous step: $$Var(stepId.outputName)
+// with an optional escape option: $$Var(stepId.outputName, escape=none)
+var workflowVarPattern = regexp.MustCompile(`\$\$Var\(\s*([A-Za-z0-9+-]+)\.([A-Za-z_][A-Za-z0-9_]*)\s*(?:,\s*escape\s*=\s*((?i:shell|none))\s*)?\)`)
+
+// WorkflowStepOutput is struct for an output of a workflow step captured as a variable for later steps
+type WorkflowStepOutput struct {
+	// Name of the variable (referenced by $$Var(stepId.name) in later steps)
+	Name string `json:"name" validate:"required" example:"masterIp"`
+	// CmdIndex is the index of the command whose stdout is captured
+	CmdIndex int `json:"cmdIndex" validate:"min=0" example:"0" default:"0"`
+	// Regex to extract the value from stdout (the first capture group, or the whole match). Trimmed stdout if empty.
+	Regex string `json:"regex" example:"token: (\\S+)"`
+	// Separator to join values from multiple VMs (ordered by VM ID)
+	Separator string `json:"separator" example:"," default:","`
+}
+
+// WorkflowStep is struct for a step of a workflow
+type WorkflowStep struct {
+	Id string `json:"id" validate:"required" example:"install-master"`
+	// DependsOn is the steps to be succeeded before this step
+	DependsOn []string `json:"dependsOn" example:"install-master"`
+
+	// Target VMs of the step: VMs in SubGroupId, VmId, or VMs with Label (all VMs in MCIS if none is given)
+	SubGroupId string `json:"subGroupId,omitempty" example:"g1"`
+	VmId       string `json:"vmId,omitempty"`
+	Label      string `json:"label,omitempty"`
+
+	// Command to execute (built-in functions and $$Var(stepId.outputName) can be used).
+	// A variable is quoted for shell unless escape=none is given: $$Var(stepId.outputName, escape=none)
+	Command []string `json:"command,omitempty" example:"echo $$Var(install-master.masterIp)"`
+	// ScriptName of the script library to execute instead of Command
+	ScriptName string `json:"scriptName,omitempty"`
+	// ScriptParams is parameters of the script ($$Var(stepId.outputName) can be used, always passed as it is)
+	ScriptParams map[string]string `json:"scriptParams,omitempty"`
+
+	UserName string `json:"userName,omitempty" example:"cb-user"`
+	SshCmdOption
+
+	Outputs []WorkflowStepOutput `json:"outputs" validate:"dive"`
+}
+
+// WorkflowReq is struct for a request to create or update a workflow (DAG of steps)
+type WorkflowReq struct {
+	// Name is required to create a workflow (ignored to update a workflow)
+	Name        string         `json:"name" example:"ceph"`
+	Description string         `json:"description" example:"deploy ceph (master first, then workers)"`
+	Steps       []WorkflowStep `json:"steps" validate:"required,min=1,dive"`
+}
+
+// WorkflowInfo is struct for a workflow
+type WorkflowInfo struct {
+	Name        string         `json:"name" example:"ceph"`
+	Description string         `json:"description" example:"deploy ceph (master first, then workers)"`
+	Steps       []WorkflowStep `json:"steps"`
+	CreatedTime string         `json:"createdTime" example:"2024-01-01T00:00:00Z"`
+	UpdatedTime string         `json:"updatedTime" example:"2024-01-01T00:00:00Z"`
+}
+
+// WorkflowInfoList is struct for a list of workflows
+type WorkflowInfoList struct {
+	Workflow []WorkflowInfo `json:"workflow"`
+}
+
+// WorkflowRunReq is struct for a request to run a workflow to MCIS
+type WorkflowRunReq struct {
+	WorkflowName string `json:"workflowName" validate:"required" example:"ceph"`
+}
+
+// WorkflowStepRunInfo is struct for the state of a step in a workflow run
+type WorkflowStepRunInfo struct {
+	StepId       string            `json:"stepId" example:"install-master"`
+	Status       string            `json:"status" example:"Succeeded"`
+	StartedTime  string            `json:"startedTime,omitempty" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string            `json:"finishedTime,omitempty" example:"2024-01-01T00:01:00Z"`
+	Outputs      map[string]string `json:"outputs,omitempty"`
+	Results      []SshCmdResult    `json:"results,omitempty"`
+	// VmErr is error message for each VM (SshCmdResult.Err is not kept in the record)
+	VmErr map[string]string `json:"vmErr,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+// WorkflowRunInfo is struct for a run of a workflow
+type WorkflowRunInfo struct {
+	RunId        string                `json:"runId" example:"cq2a3b4c5d6e7f8g9h0i"`
+	NsId         string                `json:"nsId" example:"ns01"`
+	WorkflowName string                `json:"workflowName" example:"ceph"`
+	McisId       string                `json:"mcisId" example:"mcis01"`
+	Status       string                `json:"status" example:"Running"`
+	StartedTime  string                `json:"startedTime" example:"2024-01-01T00:00:00Z"`
+	FinishedTime string                `json:"finishedTime,omitempty" example:"2024-01-01T00:10:00Z"`
+	Steps        []WorkflowStepRunInfo `json:"steps"`
+}
+
+// WorkflowRunInfoList is struct for a list of workflow runs
+type WorkflowRunInfoList struct {
+	Runs []WorkflowRunInfo `json:"runs"`
+}
+
+// workflowRunning is map for running workflow runs (key: nsId/runId)
+var workflowRunning sync.Map
+
+// genWorkflowKey is func to generate a key for a workflow
+func genWorkflowKey(nsId string, workflowName string) string {
+	return "/ns/" + nsId + "/workflow/" + workflowName
+}
+
+// genWorkflowRunKey is func to generate a key for a workflow run
+func genWorkflowRunKey(nsId string, runId string) string {
+	return "/ns/" + nsId + "/workflowRun/" + runId
+}
+
+// validateWorkflowReq is func to validate steps of a workflow (unique IDs, dependencies without cycles, variable references)
+func validateWorkflowReq(u *WorkflowReq) error {
+
+	err := validate.Struct(u)
+	if err != nil {
+		return err
+	}
+
+	steps := map[string]WorkflowStep{}
+	for _, step := range u.Steps {
+		err := common.CheckString(step.Id)
+		if err != nil {
+			return err
+		}
+		if _, ok := steps[step.Id]; ok {
+			return fmt.Errorf("Duplicated step ID: '%s'", step.Id)
+		}
+		if (len(step.Command) == 0) == (step.ScriptName == "") {
+			return fmt.Errorf("Step '%s': either command or scriptName is required", step.Id)
+		}
+		targets := 0
+		for _, t := range []string{step.SubGroupId, step.VmId, step.Label} {
+			if t != "" {
+				targets++
+			}
+		}
+		if targets > 1 {
+			return fmt.Errorf("Step '%s': only one of subGroupId, vmId and label can be given", step.Id)
+		}
+		for name := range step.Env {
+			if !envNamePattern.MatchString(name) {
+				return fmt.Errorf("Step '%s': invalid environment variable name: '%s'", step.Id, name)
+			}
+		}
+		for _, output := range step.Outputs {
+			if !envNamePattern.MatchString(output.Name) {
+				return fmt.Errorf("Step '%s': invalid output name: '%s'", step.Id, output.Name)
+			}
+			if output.Regex != "" {
+				if _, err := regexp.Compile(output.Regex); err != nil {
+					return fmt.Errorf("Step '%s': invalid regex of output '%s': %w", step.Id, output.Name, err)
+				}
+			}
+		}
+		steps[step.Id] = step
+	}
+
+	for _, step := range u.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := steps[dep]; !ok {
+				return fmt.Errorf("Step '%s' depends on an unknown step '%s'", step.Id, dep)
+			}
+		}
+	}
+
+	// ancestors of each step (also detects cycles)
+	ancestors := map[string]map[string]bool{}
+	visiting := map[string]bool{}
+	var visit func(id string) error
+	visit = func(id string) error {
+		if _, ok := ancestors[id]; ok {
+			return nil
+		}
+		if visiting[id] {
+			return fmt.Errorf("Steps have a circular dependency (at '%s')", id)
+		}
+		visiting[id] = true
+		result := map[string]bool{}
+		for _, dep := range steps[id].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+			result[dep] = true
+			for a := range ancestors[dep] {
+				result[a] = true
+			}
+		}
+		visiting[id] = false
+		ancestors[id] = result
+		return nil
+	}
+	for _, step := range u.Steps {
+		if err := visit(step.Id); err != nil {
+			return err
+		}
+	}
+
+	// variables can reference outputs of ancestor steps only
+	for _, step := range u.Steps {
+		texts := append([]string{}, step.Command...)
+		for _, v := range step.ScriptParams {
+			texts = append(texts, v)
+		}
+		for _, text := range texts {
+			for _, m := range workflowVarPattern.FindAllStringSubmatch(text, -1) {
+				refStep, refName := m[1], m[2]
+				if !ancestors[step.Id][refStep] {
+					return fmt.Errorf("Step '%s' references %s, but does not depend on the step '%s'", step.Id, m[0], refStep)
+				}
+				found := false
+				for _, output := range steps[refStep].Outputs {
+					if output.Name == refName {
+						found = true
+					}
+				}
+				if !found {
+					return fmt.Errorf("Step '%s' references %s, but the step '%s' has no output '%s'", step.Id, m[0], refStep, refName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CreateWorkflow is func to create a workflow in a namespace
+func CreateWorkflow(nsId string, u *WorkflowReq) (WorkflowInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	err = common.CheckString(u.Name)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	err = validateWorkflowReq(u)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	check, err := common.CheckNs(nsId)
+	if !check {
+		err := fmt.Errorf("The namespace " + nsId + " does not exist.")
+		return WorkflowInfo{}, err
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genWorkflowKey(nsId, u.Name))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	if keyValue != nil {
+		err := fmt.Errorf("The workflow " + u.Name + " already exists.")
+		return WorkflowInfo{}, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	workflow := WorkflowInfo{
+		Name:        u.Name,
+		Description: u.Description,
+		Steps:       u.Steps,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+	err = putWorkflow(nsId, workflow)
+	if err != nil {
+		return WorkflowInfo{}, err
+	}
+	return workflow, nil
+}
+
+// UpdateWorkflow is func to update steps of a workflow
+func UpdateWorkflow(nsId string, workflowName string, u *WorkflowReq) (WorkflowInfo, error) {
+
+	err := validateWorkflowReq(u)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+
+	workflow, err := GetWorkflow(nsId, workflowName)
+	if err != nil {
+		return WorkflowInfo{}, err
+	}
+	workflow.Description = u.Description
+	workflow.Steps = u.Steps
+	workflow.UpdatedTime = time.Now().UTC().Format(time.RFC3339)
+
+	err = putWorkflow(nsId, workflow)
+	if err != nil {
+		return WorkflowInfo{}, err
+	}
+	return workflow, nil
+}
+
+// putWorkflow is func to store a workflow
+func putWorkflow(nsId string, workflow WorkflowInfo) error {
+	val, err := json.Marshal(workflow)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genWorkflowKey(nsId, workflow.Name), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetWorkflow is func to get a workflow
+func GetWorkflow(nsId string, workflowName string) (WorkflowInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	err = common.CheckString(workflowName)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genWorkflowKey(nsId, workflowName))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The workflow " + workflowName + " does not exist.")
+		return WorkflowInfo{}, err
+	}
+
+	workflow := WorkflowInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &workflow)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowInfo{}, err
+	}
+	return workflow, nil
+}
+
+// ListWorkflow is func to list workflows in a namespace
+func ListWorkflow(nsId string) (WorkflowInfoList, error) {
+
+	result := WorkflowInfoList{Workflow: []WorkflowInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/workflow"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		workflow := WorkflowInfo{}
+		err = json.Unmarshal([]byte(v.Value), &workflow)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		result.Workflow = append(result.Workflow, workflow)
+	}
+	return result, nil
+}
+
+// DelWorkflow is func to delete a workflow (records of runs are kept)
+func DelWorkflow(nsId string, workflowName string) error {
+
+	_, err := GetWorkflow(nsId, workflowName)
+	if err != nil {
+		return err
+	}
+	err = common.CBStore.Delete(genWorkflowKey(nsId, workflowName))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// RunWorkflowToMcis is func to start a run of a workflow to MCIS.
+// Steps are executed in the order of dependencies (independent steps in parallel),
+// and a step is skipped if a step that it depends on did not succeed.
+func RunWorkflowToMcis(nsId string, mcisId string, req *WorkflowRunReq) (WorkflowRunInfo, error) {
+
+	err := validate.Struct(req)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowRunInfo{}, err
+	}
+	err = common.CheckString(mcisId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowRunInfo{}, err
+	}
+	check, _ := CheckMcis(nsId, mcisId)
+	if !check {
+		err := fmt.Errorf("The mcis " + mcisId + " does not exist.")
+		return WorkflowRunInfo{}, err
+	}
+
+	workflow, err := GetWorkflow(nsId, req.WorkflowName)
+	if err != nil {
+		return WorkflowRunInfo{}, err
+	}
+
+	run := WorkflowRunInfo{
+		RunId:        common.GenUid(),
+		NsId:         nsId,
+		WorkflowName: workflow.Name,
+		McisId:       mcisId,
+		Status:       WorkflowStatusRunning,
+		StartedTime:  time.Now().UTC().Format(time.RFC3339),
+		Steps:        []WorkflowStepRunInfo{},
+	}
+	for _, step := range workflow.Steps {
+		run.Steps = append(run.Steps, WorkflowStepRunInfo{StepId: step.Id, Status: WorkflowStatusPending})
+	}
+	err = putWorkflowRun(run)
+	if err != nil {
+		return WorkflowRunInfo{}, err
+	}
+
+	workflowRunning.Store(nsId+"/"+run.RunId, struct{}{})
+	go runWorkflow(run, workflow)
+
+	log.Info().Msgf("[Workflow] run %s to %s/%s (%s)", workflow.Name, nsId, mcisId, run.RunId)
+	return run, nil
+}
+
+// runWorkflow is func to execute steps of a workflow run in the order of dependencies
+func runWorkflow(run WorkflowRunInfo, workflow WorkflowInfo) {
+
+	defer workflowRunning.Delete(run.NsId + "/" + run.RunId)
+
+	type stepDone struct {
+		index   int
+		stepRun WorkflowStepRunInfo
+	}
+	doneCh := make(chan stepDone)
+
+	stepIndex := map[string]int{}
+	for i, step := range workflow.Steps {
+		stepIndex[step.Id] = i
+	}
+	outputs := map[string]map[string]string{}
+	running := 0
+
+	for {
+		// start steps whose dependencies are finished (until no more changes)
+		for changed := true; changed; {
+			changed = false
+			for i, step := range workflow.Steps {
+				if run.Steps[i].Status != WorkflowStatusPending {
+					continue
+				}
+				ready, skip := true, false
+				for _, dep := range step.DependsOn {
+					switch run.Steps[stepIndex[dep]].Status {
+					case WorkflowStatusSucceeded:
+					case WorkflowStatusFailed, WorkflowStatusSkipped:
+						skip = true
+					default:
+						ready = false
+					}
+				}
+				if skip {
+					run.Steps[i].Status = WorkflowStatusSkipped
+					changed = true
+					continue
+				}
+				if !ready {
+					continue
+				}
+
+				run.Steps[i].Status = WorkflowStatusRunning
+				run.Steps[i].StartedTime = time.Now().UTC().Format(time.RFC3339)
+				changed = true
+				running++
+
+				vars := map[string]map[string]string{}
+				for k, v := range outputs {
+					vars[k] = v
+				}
+				go func(index int, step WorkflowStep, startedTime string) {
+					stepRun := runWorkflowStep(run.NsId, run.McisId, step, vars)
+					stepRun.StartedTime = startedTime
+					doneCh <- stepDone{index: index, stepRun: stepRun}
+				}(i, step, run.Steps[i].StartedTime)
+			}
+		}
+		if err := putWorkflowRun(run); err != nil {
+			log.Error().Err(err).Msg("")
+		}
+
+		if running == 0 {
+			break
+		}
+		done := <-doneCh
+		running--
+		run.Steps[done.index] = done.stepRun
+		outputs[done.stepRun.StepId] = done.stepRun.Outputs
+		log.Info().Msgf("[Workflow] %s/%s step %s %s", run.NsId, run.RunId, done.stepRun.StepId, done.stepRun.Status)
+	}
+
+	run.Status = WorkflowStatusSucceeded
+	for _, stepRun := range run.Steps {
+		if stepRun.Status != WorkflowStatusSucceeded {
+			run.Status = WorkflowStatusFailed
+		}
+	}
+	run.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+	if err := putWorkflowRun(run); err != nil {
+		log.Error().Err(err).Msg("")
+	}
+	log.Info().Msgf("[Workflow] %s/%s %s", run.NsId, run.RunId, run.Status)
+}
+
+// runWorkflowStep is func to execute a step of a workflow and to capture its outputs
+func runWorkflowStep(nsId string, mcisId string, step WorkflowStep, vars map[string]map[string]string) (stepRun WorkflowStepRunInfo) {
+
+	stepRun = WorkflowStepRunInfo{
+		StepId:  step.Id,
+		Status:  WorkflowStatusFailed,
+		Outputs: map[string]string{},
+		Results: []SshCmdResult{},
+		VmErr:   map[string]string{},
+	}
+	defer func() {
+		stepRun.FinishedTime = time.Now().UTC().Format(time.RFC3339)
+	}()
+
+	// substitute is func to replace variables with outputs of previous steps (quoted for shell if quote is set)
+	substitute := func(text string, quote bool) string {
+		return workflowVarPattern.ReplaceAllStringFunc(text, func(m string) string {
+			sub := workflowVarPattern.FindStringSubmatch(m)
+			// built-in functions in a captured output are not evaluated
+			value := strings.ReplaceAll(vars[sub[1]][sub[2]], cmdFuncPrefix, "\\"+cmdFuncPrefix)
+			if !quote || strings.EqualFold(sub[3], "none") {
+				return value
+			}
+			return shellQuote(value)
+		})
+	}
+
+	var vmList []string
+	var err error
+	if step.Label != "" {
+		vmList, err = getLabelVmList(nsId, mcisId, step.Label)
+	} else {
+		vmList, err = getTargetVmList(nsId, mcisId, step.SubGroupId, step.VmId)
+	}
+	if err != nil {
+		stepRun.Error = err.Error()
+		return stepRun
+	}
+
+	req := &McisCmdReq{
+		UserName:     step.UserName,
+		SshCmdOption: step.SshCmdOption,
+	}
+	var results []SshCmdResult
+	if step.ScriptName != "" {
+		var script ScriptInfo
+		script, err = GetScript(nsId, step.ScriptName, 0)
+		if err != nil {
+			stepRun.Error = err.Error()
+			return stepRun
+		}
+		given := map[string]string{}
+		for k, v := range step.ScriptParams {
+			// parameters are quoted for shell when the script is executed
+			given[k] = substitute(v, false)
+		}
+		var params map[string]string
+		params, err = resolveScriptParams(script, given)
+		if err != nil {
+			stepRun.Error = err.Error()
+			return stepRun
+		}
+		results, err = remoteScriptToVms(nsId, mcisId, vmList, script, params, req)
+	} else {
+		for _, c := range step.Command {
+			req.Command = append(req.Command, substitute(c, true))
+		}
+		results, err = remoteCommandToVms(nsId, mcisId, vmList, req)
+	}
+	if err != nil {
+		stepRun.Error = err.Error()
+		return stepRun
+	}
+	if len(results) == 0 {
+		stepRun.Error = "No target VM"
+		return stepRun
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].VmId < results[j].VmId
+	})
+
+	succeeded := true
+	for _, result := range results {
+		if !isCmdSucceeded(result, len(req.Command), step.ContinueOnError) {
+			succeeded = false
+		}
+		if result.Err != nil {
+			stepRun.VmErr[result.VmId] = result.Err.Error()
+			result.Err = nil
+		}
+		if step.ScriptName != "" {
+			// the whole script is not kept in each result
+			result.Command = map[int]string{0: "script " + step.ScriptName}
+		}
+		stepRun.Results = append(stepRun.Results, result)
+	}
+
+	for _, output := range step.Outputs {
+		value, err := captureWorkflowOutput(output, results)
+		if err != nil {
+			stepRun.Error = err.Error()
+			succeeded = false
+			continue
+		}
+		stepRun.Outputs[output.Name] = value
+	}
+
+	if succeeded {
+		stepRun.Status = WorkflowStatusSucceeded
+	}
+	return stepRun
+}
+
+// getLabelVmList is func to get the list of VMs with a label in MCIS
+func getLabelVmList(nsId string, mcisId string, label string) ([]string, error) {
+
+	vmList, err := ListVmByLabel(nsId, mcisId, label)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmList) == 0 {
+		err := fmt.Errorf("No VM with the label " + label + " in " + mcisId)
+		return nil, err
+	}
+	return vmList, nil
+}
+
+// remoteCommandToVms is func to command to the VMs in MCIS by SSH
+func remoteCommandToVms(nsId string, mcisId string, vmList []string, req *McisCmdReq) ([]SshCmdResult, error) {
+
+	err := validateMcisCmdReq(nsId, mcisId, req)
+	if err != nil {
+		return nil, err
+	}
+
+	vmCommands, displayCommands, err := expandRemoteCommand(nsId, mcisId, vmList, req)
+	if err != nil {
+		return nil, err
+	}
+	return runRemoteCommandToVms(nsId, mcisId, vmCommands, displayCommands, req), nil
+}
+
+// isCmdSucceeded is func to check whether commands succeeded in a VM
+// (all commands exited with 0, or the last command exited with 0 if continueOnError is set)
+func isCmdSucceeded(result SshCmdResult, numCmds int, continueOnError bool) bool {
+	if result.Err != nil || len(result.ExitCode) < numCmds {
+		return false
+	}
+	if continueOnError {
+		return result.ExitCode[numCmds-1] == 0
+	}
+	for _, code := range result.ExitCode {
+		if code != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// captureWorkflowOutput is func to get the value of an output from the results of a step (values of VMs are joined)
+func captureWorkflowOutput(output WorkflowStepOutput, results []SshCmdResult) (string, error) {
+
+	separator := output.Separator
+	if separator == "" {
+		separator = ","
+	}
+	var re *regexp.Regexp
+	if output.Regex != "" {
+		var err error
+		re, err = regexp.Compile(output.Regex)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	values := []string{}
+	for _, result := range results {
+		stdout := strings.TrimSpace(result.Stdout[output.CmdIndex])
+		if re != nil {
+			m := re.FindStringSubmatch(stdout)
+			if m == nil {
+				continue
+			}
+			stdout = m[0]
+			if len(m) > 1 {
+				stdout = m[1]
+			}
+		}
+		if stdout != "" {
+			values = append(values, stdout)
+		}
+	}
+	if len(values) == 0 {
+		err := fmt.Errorf("No value for the output '%s' (cmdIndex: %d)", output.Name, output.CmdIndex)
+		return "", err
+	}
+	return strings.Join(values, separator), nil
+}
+
+// putWorkflowRun is func to persist a workflow run
+func putWorkflowRun(run WorkflowRunInfo) error {
+	val, err := json.Marshal(run)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	err = common.CBStore.Put(genWorkflowRunKey(run.NsId, run.RunId), string(val))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
+
+// GetWorkflowRun is func to get a workflow run with the states of steps
+func GetWorkflowRun(nsId string, runId string) (WorkflowRunInfo, error) {
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowRunInfo{}, err
+	}
+
+	keyValue, err := common.CBStore.Get(genWorkflowRunKey(nsId, runId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowRunInfo{}, err
+	}
+	if keyValue == nil {
+		err := fmt.Errorf("The workflow run " + runId + " does not exist.")
+		return WorkflowRunInfo{}, err
+	}
+
+	run := WorkflowRunInfo{}
+	err = json.Unmarshal([]byte(keyValue.Value), &run)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return WorkflowRunInfo{}, err
+	}
+	if run.Status == WorkflowStatusRunning {
+		if _, ok := workflowRunning.Load(nsId + "/" + runId); !ok {
+			run.Status = WorkflowStatusInterrupted
+		}
+	}
+	return run, nil
+}
+
+// ListWorkflowRun is func to list workflow runs in a namespace (optionally filtered by workflow and MCIS)
+func ListWorkflowRun(nsId string, workflowName string, mcisId string) (WorkflowRunInfoList, error) {
+
+	result := WorkflowRunInfoList{Runs: []WorkflowRunInfo{}}
+
+	err := common.CheckString(nsId)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+
+	key := "/ns/" + nsId + "/workflowRun"
+	keyValue, err := common.CBStore.GetList(key, true)
+	keyValue = cbstore_utils.GetChildList(keyValue, key)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return result, err
+	}
+	for _, v := range keyValue {
+		run := WorkflowRunInfo{}
+		err = json.Unmarshal([]byte(v.Value), &run)
+		if err != nil {
+			log.Error().Err(err).Msg("")
+			continue
+		}
+		if workflowName != "" && run.WorkflowName != workflowName {
+			continue
+		}
+		if mcisId != "" && run.McisId != mcisId {
+			continue
+		}
+		if run.Status == WorkflowStatusRunning {
+			if _, ok := workflowRunning.Load(nsId + "/" + run.RunId); !ok {
+				run.Status = WorkflowStatusInterrupted
+			}
+		}
+		result.Runs = append(result.Runs, run)
+	}
+	sort.Slice(result.Runs, func(i, j int) bool {
+		return result.Runs[i].StartedTime > result.Runs[j].StartedTime
+	})
+	return result, nil
+}
+
+// DelWorkflowRun is func to delete a finished workflow run
+func DelWorkflowRun(nsId string, runId string) error {
+
+	run, err := GetWorkflowRun(nsId, runId)
+	if err != nil {
+		return err
+	}
+	if run.Status == WorkflowStatusRunning {
+		err := fmt.Errorf("The workflow run " + runId + " is running.")
+		return err
+	}
+
+	err = common.CBStore.Delete(genWorkflowRunKey(nsId, runId))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return err
+	}
+	return nil
+}
@@ -199,6 +199,28 @@ func setConfig() {
 		panic(err)
 	}
 
+	//
+	// Load carbonintensity
+	//
+	carbonIntensityViper := viper.New()
+	fileName = "carbonintensity"
+	carbonIntensityViper.AddConfigPath(".")
+	carbonIntensityViper.AddConfigPath("./assets/")
+	carbonIntensityViper.AddConfigPath("../assets/")
+	carbonIntensityViper.SetConfigName(fileName)
+	carbonIntensityViper.SetConfigType("yaml")
+	err = carbonIntensityViper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error reading carbonintensity config file: %w", err))
+	}
+
+	log.Info().Msg(carbonIntensityViper.ConfigFileUsed())
+	err = carbonIntensityViper.Unmarshal(&common.RuntimeCarbonIntensity)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		panic(err)
+	}
+
 	//
 	// Wait until CB-Spider is ready
 	//
@@ -339,6 +361,24 @@ func main() {
 	}()
 	defer ticker.Stop()
 
+	//Ticker for agentless (SSH-based) MCIS monitoring
+	agentlessMonitoringTicker := time.NewTicker(time.Second * 10)
+	go func() {
+		for range agentlessMonitoringTicker.C {
+			mcis.AgentlessMonitoringController()
+		}
+	}()
+	defer agentlessMonitoringTicker.Stop()
+
+	//Ticker for monitoring agent heartbeat and automatic installation
+	monitoringAgentTicker := time.NewTicker(time.Minute)
+	go func() {
+		for range monitoringAgentTicker.C {
+			mcis.MonitoringAgentController()
+		}
+	}()
+	defer monitoringAgentTicker.Stop()
+
 	go func() {
 		viper.WatchConfig()
 		viper.OnConfigChange(func(e fsnotify.Event) {